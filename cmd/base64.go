@@ -47,14 +47,14 @@ Input can be a string argument or piped from stdin.`,
 			if err != nil {
 				return err
 			}
-			fmt.Print(decoded)
-		} else {
-			// Encode to base64
-			encoded := base64.Encode(data)
-			fmt.Print(encoded)
+			_, err = fmt.Fprint(cmd.OutOrStdout(), decoded)
+			return err
 		}
 
-		return nil
+		// Encode to base64
+		encoded := base64.Encode(data)
+		_, err = fmt.Fprint(cmd.OutOrStdout(), encoded)
+		return err
 	},
 }
 
@@ -62,6 +62,7 @@ var base64Decode bool
 
 func init() {
 	rootCmd.AddCommand(base64Cmd)
+	addFileFlag(base64Cmd)
 
 	base64Cmd.Flags().BoolVarP(&base64Decode, "decode", "d", false, "decode base64 input instead of encoding")
 }
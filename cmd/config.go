@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/skatkov/devtui/internal/config"
+	"github.com/skatkov/devtui/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// appConfig holds the settings loaded before every command runs.
+var appConfig *config.Config
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect devtui configuration",
+	Long: `Inspect the configuration that provides defaults for command flags and TUIs.
+
+Settings are TOML tables named after commands, holding flag defaults:
+
+  [cssfmt]
+  indent = 4
+
+  [uuidgenerate]
+  uuid-version = 7
+
+They are merged from, in increasing precedence:
+  - the global file $XDG_CONFIG_HOME/devtui/config.toml
+  - the nearest .devtui.toml in the working directory or its parents
+  - environment variables such as DEVTUI_CSSFMT_INDENT
+Flags given on the command line always win.
+
+The [history] table controls the inputs the TUI remembers in
+$XDG_DATA_HOME/devtui/history, for the tools that show their result in a
+pager:
+
+  [history]
+  enabled = true
+  max-entries = 50
+  disable = ["hash"]
+  encrypt = ["jwt"]
+
+Encrypted history uses a key generated in $XDG_CONFIG_HOME/devtui/history.key,
+or derived from the DEVTUI_HISTORY_KEY passphrase. The passphrase is only read
+from the environment, never from a configuration file.`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration and where every value comes from",
+	Example: `  # Show the effective configuration
+  devtui config show
+
+  # See what an environment variable changes
+  DEVTUI_CSSFMT_INDENT=8 devtui config show`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		files := appConfig.Files()
+		if len(files) == 0 {
+			_, _ = fmt.Fprintf(out, "# no configuration files found (looked for %s and %s)\n",
+				config.GlobalPath(), config.ProjectFile)
+		}
+		for _, file := range files {
+			_, _ = fmt.Fprintf(out, "# read %s\n", file)
+		}
+
+		known := configKeys()
+		settings := appConfig.Settings()
+		for _, key := range known {
+			if _, ok := os.LookupEnv(config.EnvName(key)); ok {
+				settings = append(settings, config.Setting{Key: key})
+			}
+		}
+		slices.SortFunc(settings, func(a, b config.Setting) int { return strings.Compare(a.Key, b.Key) })
+		settings = slices.CompactFunc(settings, func(a, b config.Setting) bool { return a.Key == b.Key })
+
+		for _, setting := range settings {
+			effective, _ := appConfig.Lookup(setting.Key)
+			note := effective.Source
+			if !slices.Contains(known, setting.Key) {
+				note += " (unknown setting)"
+			}
+			_, err := fmt.Fprintf(out, "%s = %s  # %s\n", effective.Key, formatSetting(effective.Value), note)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.PersistentPreRunE = applyConfig
+}
+
+// applyConfig loads the configuration and uses it for the flags of cmd that
+// were not given on the command line.
+func applyConfig(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	appConfig = cfg
+
+	prefix := configPrefix(cmd)
+	if prefix == "" {
+		return nil
+	}
+	var errs []error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || flag.Name == "help" {
+			return
+		}
+		setting, ok := cfg.Lookup(prefix + flag.Name)
+		if !ok {
+			return
+		}
+		if err := setFlagValue(flag, setting.Value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", setting.Source, setting.Key, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// configPrefix returns the key prefix of cmd's settings, such as
+// "jsonschema.validate.", or "" for the root command.
+func configPrefix(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		return ""
+	}
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	return strings.ReplaceAll(path, " ", ".") + "."
+}
+
+// configKeys lists the setting keys of every flag of every command, and
+// the settings of the TUI history.
+func configKeys() []string {
+	keys := slices.Clone(history.SettingKeys)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if prefix := configPrefix(cmd); prefix != "" {
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if flag.Name != "help" {
+					keys = append(keys, prefix+flag.Name)
+				}
+			})
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+func setFlagValue(flag *pflag.Flag, value any) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		var values []string
+		if items, ok := value.([]any); ok {
+			for _, item := range items {
+				values = append(values, config.FormatValue(item))
+			}
+		} else {
+			values = []string{config.FormatValue(value)}
+		}
+		return slice.Replace(values)
+	}
+	return flag.Value.Set(config.FormatValue(value))
+}
+
+// formatSetting renders a value as it would appear in a TOML file.
+func formatSetting(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatSetting(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
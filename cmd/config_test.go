@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+// useConfig runs the test in a directory with the given .devtui.toml and an
+// empty global configuration directory.
+func useConfig(t *testing.T, project string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := writeTreeFile(t, dir, ".devtui.toml", project)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return path
+}
+
+func TestConfigDefaults(t *testing.T) {
+	useConfig(t, "[cssfmt]\nindent = 4\n")
+	t.Cleanup(func() { flagIndent = 2 })
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "config default", args: []string{"cssfmt", "a{color:red}"}, want: "a {\n    color: red;\n}"},
+		{name: "flag wins", args: []string{"cssfmt", "--indent", "2", "a{color:red}"}, want: "a {\n  color: red;\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs(tt.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+			cssfmtCmd.Flags().Lookup("indent").Changed = false
+		})
+	}
+}
+
+func TestConfigShow(t *testing.T) {
+	path := useConfig(t, "[cssfmt]\nindent = 4\n\n[nosuchcmd]\nflag = \"x\"\n")
+	t.Setenv("DEVTUI_UUIDGENERATE_UUID_VERSION", "7")
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "show"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"# read " + path + "\n",
+		"cssfmt.indent = 4  # " + path + "\n",
+		"nosuchcmd.flag = \"x\"  # " + path + " (unknown setting)\n",
+		"uuidgenerate.uuid-version = \"7\"  # DEVTUI_UUIDGENERATE_UUID_VERSION\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
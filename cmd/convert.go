@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/skatkov/devtui/internal/cmderror"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert [string or file]",
+	Short: "Convert between JSON, YAML, TOML, XML, CSV, TSV, TOON and JSON Lines",
+	Long: `Convert structured data between any two supported formats.
+
+Supported formats: json, yaml, toml, xml, csv, tsv, toon and jsonl (ndjson).
+When --from is omitted the input format is detected from the content.
+Key order is preserved, and YAML and TOML comments are carried over when the
+target format supports them (use --no-comments to drop them). XML attributes are mapped to "-name" keys and text
+content to "#text". CSV and TSV headers such as "user.name" and "tags[0]"
+describe nested values.
+
+Input can be a string argument or piped from stdin.`,
+	Example: `  # Convert YAML to TOML
+  devtui convert --from yaml --to toml < config.yaml
+
+  # Detect the input format automatically
+  devtui convert --to json < data.xml
+
+  # Convert CSV rows to JSON Lines
+  devtui convert --from csv --to jsonl < users.csv
+
+  # Convert a JSON string argument to TOON
+  devtui convert --to toon '{"users":[{"id":1,"name":"Alice"}]}'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		if len(data) == 0 {
+			return errors.New("no input provided. pipe input to this command")
+		}
+		inputStr := string(data)
+
+		to, err := converter.Lookup(convertTo)
+		if err != nil {
+			return err
+		}
+
+		var from converter.Format
+		if convertFrom == "" {
+			from, err = converter.Detect(inputStr)
+			if err != nil {
+				return fmt.Errorf("%w; specify it with --from", err)
+			}
+		} else {
+			codec, err := converter.Lookup(convertFrom)
+			if err != nil {
+				return err
+			}
+			from = codec.Format
+		}
+
+		result, err := converter.ConvertWithOptions(inputStr, from, to.Format, converter.Options{
+			NoComments: convertNoComments,
+		})
+		if err != nil {
+			return cmderror.FormatParseError("convert", inputStr, err)
+		}
+
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.TrimRight(result, "\n"))
+		return err
+	},
+}
+
+var (
+	convertFrom       string
+	convertTo         string
+	convertNoComments bool
+)
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+	addFileFlag(convertCmd)
+
+	convertCmd.Flags().StringVar(&convertFrom, "from", "", "input format (detected from content when omitted)")
+	convertCmd.Flags().StringVar(&convertTo, "to", "", "output format")
+	convertCmd.Flags().BoolVar(&convertNoComments, "no-comments", false, "drop comments instead of copying them to the output")
+	_ = convertCmd.MarkFlagRequired("to")
+
+	_ = convertCmd.RegisterFlagCompletionFunc("from", completeFormats)
+	_ = convertCmd.RegisterFlagCompletionFunc("to", completeFormats)
+}
+
+// completeFormats completes the names of the formats known to the converter.
+func completeFormats(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	formats := converter.Formats()
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
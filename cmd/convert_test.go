@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resetConvertFlags() {
+	convertFrom = ""
+	convertTo = ""
+	convertNoComments = false
+}
+
+func TestConvertCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "yaml to toml keeps key order",
+			args:  []string{"convert", "--from", "yaml", "--to", "toml"},
+			input: "zeta: 1\nalpha: two\n",
+			want:  "zeta = 1\nalpha = \"two\"\n",
+		},
+		{
+			name:  "yaml to toml keeps comments",
+			args:  []string{"convert", "--from", "yaml", "--to", "toml"},
+			input: "# service name\nname: api # public\nport: 8080\n",
+			want:  "# service name\nname = \"api\" # public\nport = 8080\n",
+		},
+		{
+			name:  "no comments",
+			args:  []string{"convert", "--from", "yaml", "--to", "toml", "--no-comments"},
+			input: "# service name\nname: api # public\nport: 8080\n",
+			want:  "name = \"api\"\nport = 8080\n",
+		},
+		{
+			name:  "detects xml input",
+			args:  []string{"convert", "--to", "yaml"},
+			input: `<root><item id="1">value</item></root>`,
+			want:  "root:\n    item:\n        -id: \"1\"\n        '#text': value\n",
+		},
+		{
+			name:  "csv to json lines",
+			args:  []string{"convert", "--from", "csv", "--to", "ndjson"},
+			input: "id,name\n1,Alice\n2,Bob\n",
+			want:  "{\"id\":\"1\",\"name\":\"Alice\"}\n{\"id\":\"2\",\"name\":\"Bob\"}\n",
+		},
+		{
+			name:  "json to toon",
+			args:  []string{"convert", "--to", "toon"},
+			input: `{"users":[{"id":1,"name":"Alice"},{"id":2,"name":"Bob"}]}`,
+			want:  "users[2]{id,name}:\n  1,Alice\n  2,Bob\n",
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"convert", "--to", "ini"},
+			input:   `{"a":1}`,
+			wantErr: "unknown format",
+		},
+		{
+			name:    "undetectable input",
+			args:    []string{"convert", "--to", "json"},
+			input:   "just some words",
+			wantErr: "--from",
+		},
+		{
+			name:    "invalid input",
+			args:    []string{"convert", "--from", "json", "--to", "yaml"},
+			input:   `{invalid`,
+			wantErr: "JSON parsing error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetConvertFlags()
+			defer resetConvertFlags()
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convert failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("convert output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertCmdRequiresTo(t *testing.T) {
+	resetConvertFlags()
+	defer resetConvertFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"convert", `{"a":1}`})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error when --to is missing")
+	}
+}
@@ -61,4 +61,5 @@ in a formatted table. Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(countCmd)
+	addFileFlag(countCmd)
 }
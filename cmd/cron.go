@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skatkov/devtui/internal/cronexpr"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+// cronTimeLayout is used for the listed run times.
+const cronTimeLayout = "2006-01-02 15:04:05 MST (Mon)"
+
+var cronCmd = &cobra.Command{
+	Use:     "cron [expression]",
+	Aliases: []string{"crontab"},
+	Short:   "Validate and explain cron expressions",
+	Long: `Validate a cron expression, explain it in words and list when it runs next.
+
+Accepts standard 5-field expressions (minute hour day-of-month month day-of-week),
+6 fields with a leading seconds or trailing year field, 7 fields with both,
+Quartz-style expressions using ?, L, W and #, and the @yearly, @annually,
+@monthly, @weekly, @daily, @midnight, @hourly, @reboot and @every <duration>
+macros.
+
+Days of the week are numbered 0-7 with Sunday as 0 or 7. Expressions with a
+seconds field that use ? are Quartz-style, where they are numbered 1-7 with
+Sunday as 1.
+
+Invalid expressions are reported with the offending field and exit non-zero,
+as do expressions that never run, such as "0 0 31 2 *" or a year in the past.`,
+	Example: `  # Explain an expression and show its next 5 runs
+  devtui cron "*/5 * * * *"
+
+  # Quartz-style expression with seconds, explained in German
+  devtui cron --locale de "0 0 12 ? * MON-FRI"
+
+  # Next 10 runs in a given time zone
+  devtui cron --next 10 --timezone Europe/Paris "0 9 * * 1"
+
+  # Validate from stdin, as JSON
+  echo "@daily" | devtui cron --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputStr, err := input.ReadFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+
+		schedule, err := cronexpr.Parse(inputStr)
+		if err != nil {
+			return fmt.Errorf("invalid cron expression: %w", err)
+		}
+		description, err := schedule.Describe(cronLocale)
+		if err != nil {
+			return err
+		}
+		if cronNext < 0 {
+			return fmt.Errorf("--next must not be negative, got %d", cronNext)
+		}
+		loc, err := time.LoadLocation(cronTimezone)
+		if err != nil {
+			return fmt.Errorf("unknown time zone %q: %w", cronTimezone, err)
+		}
+		now := time.Now().In(loc)
+		if _, ok := schedule.Next(now); !ok && !schedule.Reboot() {
+			return fmt.Errorf("cron expression %q never runs: no time from now on matches it", schedule.Expression)
+		}
+		next := schedule.NextN(now, cronNext)
+
+		out := cmd.OutOrStdout()
+		if cronJSONOutput {
+			result := struct {
+				Expression  string   `json:"expression"`
+				Description string   `json:"description"`
+				Timezone    string   `json:"timezone"`
+				Next        []string `json:"next"`
+			}{
+				Expression:  schedule.Expression,
+				Description: description,
+				Timezone:    loc.String(),
+				Next:        []string{},
+			}
+			for _, t := range next {
+				result.Next = append(result.Next, t.Format(time.RFC3339))
+			}
+			bytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(out, string(bytes))
+			return err
+		}
+
+		var b strings.Builder
+		fmt.Fprintln(&b, description)
+		if cronNext > 0 {
+			fmt.Fprintf(&b, "\nNext runs (%s):\n", loc)
+			if len(next) == 0 {
+				fmt.Fprintln(&b, "  none")
+			}
+			for _, t := range next {
+				fmt.Fprintf(&b, "  %s\n", t.Format(cronTimeLayout))
+			}
+		}
+		_, err = fmt.Fprint(out, b.String())
+		return err
+	},
+}
+
+var (
+	cronLocale     string
+	cronNext       int
+	cronTimezone   string
+	cronJSONOutput bool
+)
+
+func init() {
+	rootCmd.AddCommand(cronCmd)
+	addFileFlag(cronCmd)
+	cronCmd.Flags().StringVarP(&cronLocale, "locale", "l", "en", "language of the description ("+strings.Join(cronexpr.Locales, ", ")+")")
+	cronCmd.Flags().IntVarP(&cronNext, "next", "n", 5, "number of upcoming run times to list")
+	cronCmd.Flags().StringVarP(&cronTimezone, "timezone", "z", "Local", "IANA time zone of the listed run times")
+	cronCmd.Flags().BoolVar(&cronJSONOutput, "json", false, "output as JSON")
+}
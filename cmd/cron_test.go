@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func resetCronFlags() {
+	cronLocale = "en"
+	cronNext = 5
+	cronTimezone = "Local"
+	cronJSONOutput = false
+}
+
+func TestCronCmd(t *testing.T) {
+	resetCronFlags()
+	defer resetCronFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"cron", "--next", "3", "--timezone", "Asia/Tokyo", "0 9 * * MON"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cron command failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "At 09:00, only on Monday" {
+		t.Fatalf("cron description = %q", lines[0])
+	}
+	if lines[2] != "Next runs (Asia/Tokyo):" || len(lines) != 6 {
+		t.Fatalf("cron output = %q", buf.String())
+	}
+	for _, line := range lines[3:] {
+		if !strings.HasSuffix(line, "09:00:00 JST (Mon)") {
+			t.Errorf("unexpected run time %q", line)
+		}
+	}
+}
+
+func TestCronCmdJSON(t *testing.T) {
+	resetCronFlags()
+	defer resetCronFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("0 0 12 ? * 2-6\n"))
+	cmd.SetArgs([]string{"cron", "--json", "--locale", "es", "--timezone", "UTC"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("cron --json command failed: %v", err)
+	}
+
+	var result struct {
+		Description string   `json:"description"`
+		Next        []string `json:"next"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("cron --json output invalid JSON: %v", err)
+	}
+	if !strings.Contains(result.Description, "lunes") {
+		t.Errorf("expected a Spanish description, got %q", result.Description)
+	}
+	if len(result.Next) != 5 {
+		t.Fatalf("expected 5 run times, got %v", result.Next)
+	}
+	for _, next := range result.Next {
+		tm, err := time.Parse(time.RFC3339, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wd := tm.Weekday(); wd == time.Saturday || wd == time.Sunday || tm.Hour() != 12 {
+			t.Errorf("unexpected run time %s", next)
+		}
+	}
+}
+
+func TestCronCmdInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"cron", "*/5 * * * *."},
+		{"cron", "61 * * * *"},
+		{"cron", "--locale", "xx", "* * * * *"},
+		{"cron", "--timezone", "Mars/Olympus", "* * * * *"},
+	} {
+		resetCronFlags()
+
+		cmd := GetRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+	resetCronFlags()
+}
+
+func TestCronCmdNeverRuns(t *testing.T) {
+	for _, args := range [][]string{
+		{"cron", "0 0 31 2 *"},
+		{"cron", "0 0 12 1 1 ? 2020"},
+		{"cron", "--next", "0", "--json", "0 0 30 2 *"},
+	} {
+		resetCronFlags()
+
+		cmd := GetRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "never runs") {
+			t.Errorf("%v error = %v, want never runs", args, err)
+		}
+	}
+	resetCronFlags()
+}
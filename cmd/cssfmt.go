@@ -53,6 +53,7 @@ custom spacing. Input can be a string argument or piped from stdin.`,
 			common := &ui.CommonModel{
 				Width:  100, // Default width, will be adjusted by the TUI
 				Height: 30,  // Default height, will be adjusted by the TUI
+				Config: appConfig,
 			}
 
 			model := css.NewCSSFormatterModel(common)
@@ -101,8 +102,10 @@ var (
 
 func init() {
 	rootCmd.AddCommand(cssfmtCmd)
+	addFormatFlags(cssfmtCmd)
 	cssfmtCmd.Flags().BoolVarP(&flagTab, "tab", "t", false, "use tabs for indentation")
 	cssfmtCmd.Flags().IntVarP(&flagIndent, "indent", "i", 2, "spaces for indentation")
 	cssfmtCmd.Flags().BoolVarP(&flagSemicolon, "semicolon", "", true, "always end rule with semicolon, even if not needed")
 	cssfmtCmd.Flags().BoolVarP(&flagTui, "tui", "", false, "present result in a TUI")
+	markInteractive(cssfmtCmd, "tui")
 }
@@ -52,4 +52,5 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(csv2jsonCmd)
+	addFileFlag(csv2jsonCmd)
 }
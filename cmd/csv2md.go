@@ -66,6 +66,7 @@ var (
 
 func init() {
 	rootCmd.AddCommand(csv2mdCmd)
+	addFileFlag(csv2mdCmd)
 
 	csv2mdCmd.Flags().BoolVarP(&csv2mdAlignColumns, "align", "a", false, "align columns width")
 	csv2mdCmd.Flags().StringVarP(&csv2mdHeader, "header", "t", "", "add main header (h1) to result")
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+var csv2ndjsonCmd = &cobra.Command{
+	Use:   "csv2ndjson [string or file]",
+	Short: "Convert CSV to NDJSON (JSON Lines)",
+	Long: `Convert CSV rows to NDJSON (JSON Lines), one row at a time.
+
+Headers such as "user.name" and "tags[0]" produce nested values, as in
+csv2json. Values are kept as strings.
+
+Input can be a string argument or piped from stdin.`,
+	Example: `  # Convert CSV to JSON Lines
+  devtui csv2ndjson < users.csv > users.ndjson
+
+  # Convert a CSV string argument
+  devtui csv2ndjson 'name,age
+Alice,30'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return converter.DelimitedToJSONLines(input.ReaderFromArgsOrStdin(cmd, args), cmd.OutOrStdout(), ',')
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(csv2ndjsonCmd)
+	addFileFlag(csv2ndjsonCmd)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/diff"
+	"github.com/skatkov/devtui/internal/ui"
+	difftui "github.com/skatkov/devtui/tui/diff"
+	"github.com/spf13/cobra"
+)
+
+var diffFormats = []string{"tree", "json-patch", "merge-patch"}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <file> <file>",
+	Short: "Compare two JSON, YAML, TOML or XML documents structurally",
+	Long: `Compare two structured documents and report the paths that were added,
+removed or changed. Formatting, key order and the document format itself are
+ignored, so a JSON file can be compared with its YAML equivalent.
+
+The report is a colored tree by default; use --format json-patch for an
+RFC 6902 JSON Patch or --format merge-patch for an RFC 7386 JSON Merge Patch.
+Arrays are compared element by element.
+
+The formats are detected from the file extensions or the content unless
+--from is given. Use "-" to read one of the documents from stdin.
+
+The command exits with status 1 when the documents differ, which makes it
+usable as a check in CI.`,
+	Example: `  # Compare two config files
+  devtui diff config.json config.yaml
+
+  # Produce a JSON Patch that turns old.json into new.json
+  devtui diff old.json new.json --format json-patch
+
+  # Compare an API response against a stored fixture
+  curl -s https://api.example.com/user | devtui diff fixture.json -
+
+  # Browse the differences side by side
+  devtui diff old.toml new.toml --tui`,
+	Args: cobra.ExactArgs(2),
+	// Differences are reported through errSilent, which must not print
+	// usage or an error after the report.
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkSingleStdin(args); err != nil {
+			return err
+		}
+		left, err := readDiffDocument(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		right, err := readDiffDocument(cmd, args[1])
+		if err != nil {
+			return err
+		}
+
+		if flagTUI {
+			model := difftui.NewDiffModel(&ui.CommonModel{})
+			if err := model.SetDocuments(left, right); err != nil {
+				return err
+			}
+			p := tea.NewProgram(model)
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		changes := diff.Compare(left, right)
+		out := cmd.OutOrStdout()
+		switch diffFormat {
+		case "tree":
+			_, err = lipgloss.Fprint(out, diff.Tree(changes, diff.DefaultTreeStyles()))
+		case "json-patch":
+			err = writeJSONDocument(cmd, diff.JSONPatch(changes))
+		case "merge-patch":
+			err = writeJSONDocument(cmd, diff.MergePatch(left, right))
+		default:
+			return fmt.Errorf("unknown format %q; use one of: %s", diffFormat, strings.Join(diffFormats, ", "))
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(changes) > 0 {
+			return errSilent
+		}
+		return nil
+	},
+}
+
+// readDiffDocument reads and decodes one side of a diff.
+func readDiffDocument(cmd *cobra.Command, path string) (any, error) {
+	content, format, err := readDocument(cmd, path, diffFrom)
+	if err != nil {
+		return nil, err
+	}
+	value, err := converter.Decode(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", documentName(path), err)
+	}
+	return value, nil
+}
+
+var (
+	diffFrom   string
+	diffFormat string
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "format of both documents (detected when omitted)")
+	diffCmd.Flags().StringVar(&diffFormat, "format", "tree", "report format: "+strings.Join(diffFormats, ", "))
+	diffCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show the documents side by side in a TUI")
+	markInteractive(diffCmd, "tui")
+	addFileArgsFlag(diffCmd)
+
+	_ = diffCmd.RegisterFlagCompletionFunc("from", completeFormats)
+	_ = diffCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(diffFormats, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func resetDiffFlags() {
+	diffFrom = ""
+	diffFormat = "tree"
+	flagTUI = false
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffCmd(t *testing.T) {
+	oldJSON := writeTestFile(t, "old.json", `{"name":"api","port":8080,"debug":true}`)
+	newYAML := writeTestFile(t, "new.yaml", "port: 9090\nname: api\ntls: true\n")
+	sameYAML := writeTestFile(t, "same.yaml", "debug: true\nport: 8080\nname: api\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		want     string
+		wantDiff bool
+		wantErr  string
+	}{
+		{
+			name:     "tree",
+			args:     []string{"diff", oldJSON, newYAML},
+			want:     "~ port: 8080 → 9090\n- debug: true\n+ tls: true\n",
+			wantDiff: true,
+		},
+		{
+			name:     "json patch",
+			args:     []string{"diff", oldJSON, newYAML, "--format", "json-patch"},
+			want:     "[\n  {\n    \"op\": \"replace\",\n    \"path\": \"/port\",\n    \"value\": 9090\n  },\n  {\n    \"op\": \"remove\",\n    \"path\": \"/debug\"\n  },\n  {\n    \"op\": \"add\",\n    \"path\": \"/tls\",\n    \"value\": true\n  }\n]\n",
+			wantDiff: true,
+		},
+		{
+			name:     "merge patch",
+			args:     []string{"diff", oldJSON, newYAML, "--format", "merge-patch"},
+			want:     "{\n  \"debug\": null,\n  \"port\": 9090,\n  \"tls\": true\n}\n",
+			wantDiff: true,
+		},
+		{
+			name: "identical documents",
+			args: []string{"diff", oldJSON, sameYAML},
+			want: "",
+		},
+		{
+			name:     "stdin",
+			args:     []string{"diff", oldJSON, "-"},
+			input:    `{"name":"api","port":8080}`,
+			want:     "- debug: true\n",
+			wantDiff: true,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"diff", oldJSON, newYAML, "--format", "unified"},
+			wantErr: "unknown format",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"diff", oldJSON, filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: "no such file",
+		},
+		{
+			name:    "invalid document",
+			args:    []string{"diff", oldJSON, "-", "--from", "json"},
+			input:   `{invalid`,
+			wantErr: "JSON parsing error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetDiffFlags()
+			defer resetDiffFlags()
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if tt.wantDiff != errors.Is(err, errSilent) {
+				t.Fatalf("diff error = %v, want differences reported: %v", err, tt.wantDiff)
+			}
+			if !tt.wantDiff && err != nil {
+				t.Fatalf("diff failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("diff output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
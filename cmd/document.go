@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+// readDocument reads a structured document from a file, or from stdin when
+// path is "-", and works out its format: from is used when set, otherwise
+// the file extension or the content decides.
+func readDocument(cmd *cobra.Command, path, from string) (string, converter.Format, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = input.ReadBytesFromArgsOrStdin(cmd, nil)
+		if err != nil {
+			return "", "", fmt.Errorf("error reading from stdin: %w", err)
+		}
+	} else {
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	content := string(data)
+	if strings.TrimSpace(content) == "" {
+		return "", "", fmt.Errorf("%s: no input provided", documentName(path))
+	}
+
+	if from != "" {
+		codec, err := converter.Lookup(from)
+		if err != nil {
+			return "", "", err
+		}
+		return content, codec.Format, nil
+	}
+	if format, ok := converter.FormatFromFilename(path); ok {
+		return content, format, nil
+	}
+	format, err := converter.Detect(content)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w; specify it with --from", documentName(path), err)
+	}
+	return content, format, nil
+}
+
+// checkSingleStdin rejects argument lists that would read stdin twice.
+func checkSingleStdin(paths []string) error {
+	stdin := 0
+	for _, path := range paths {
+		if path == "-" {
+			stdin++
+		}
+	}
+	if stdin > 1 {
+		return errors.New("only one document can be read from stdin")
+	}
+	return nil
+}
+
+// documentName names a document in messages.
+func documentName(path string) string {
+	if path == "-" {
+		return "<stdin>"
+	}
+	return path
+}
+
+// writeJSONDocument writes a converter tree as indented JSON.
+func writeJSONDocument(cmd *cobra.Command, value any) error {
+	out, err := converter.Encode(value, converter.FormatJSON)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.TrimRight(out, "\n"))
+	return err
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// notEmbeddable lists the top-level commands that can't run inside another
+// command, as a recipe step or a server request: they run commands
+// themselves, start interactive sessions or only make sense in a shell.
+var notEmbeddable = []string{"run", "recipe", "config", "serve", "lsp", "completion", "help"}
+
+// interactiveAnnotation marks commands and flags that start a TUI. They
+// can't run inside another command: without a terminal they fail, and with
+// one they take it over while holding execMu.
+const interactiveAnnotation = "devtui_interactive"
+
+// markInteractive marks cmd as starting a TUI, or only its named flags when
+// given.
+func markInteractive(cmd *cobra.Command, flags ...string) {
+	if len(flags) == 0 {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[interactiveAnnotation] = "true"
+		return
+	}
+	for _, name := range flags {
+		_ = cmd.Flags().SetAnnotation(name, interactiveAnnotation, []string{"true"})
+	}
+}
+
+// interactive reports whether cmd always starts a TUI.
+func interactive(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[interactiveAnnotation]
+	return ok
+}
+
+// interactiveFlag reports whether setting flag starts a TUI.
+func interactiveFlag(flag *pflag.Flag) bool {
+	_, ok := flag.Annotations[interactiveAnnotation]
+	return ok
+}
+
+// execMu serializes embedded commands, since they run through the shared
+// root command and its flag variables.
+var execMu sync.Mutex
+
+// embeddable reports whether cmd can be run with execCommand.
+func embeddable(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if interactive(c) || c.Parent() == rootCmd && slices.Contains(notEmbeddable, c.Name()) {
+			return false
+		}
+	}
+	return cmd != rootCmd && cmd.Runnable()
+}
+
+// execCommand runs the devtui command given by args in this process, with
+// input as its stdin, and returns what it writes to stdout, also when it
+// fails.
+func execCommand(args []string, input []byte) ([]byte, error) {
+	execMu.Lock()
+	defer execMu.Unlock()
+
+	// --file is shared by the commands reading files; the command must read
+	// its stdin even while run itself is going through files.
+	files := flagFiles
+	flagFiles = nil
+	defer func() { flagFiles = files }()
+	if target, _, err := rootCmd.Find(args); err == nil {
+		defer resetFlags(target)
+	}
+
+	in, out, errOut := rootCmd.InOrStdin(), rootCmd.OutOrStdout(), rootCmd.ErrOrStderr()
+	defer func() {
+		rootCmd.SetIn(in)
+		rootCmd.SetOut(out)
+		rootCmd.SetErr(errOut)
+	}()
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetArgs(args)
+	rootCmd.SetIn(bytes.NewReader(input))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	_, err := rootCmd.ExecuteC()
+	return stdout.Bytes(), err
+}
+
+// resetFlags puts the flags of cmd back to their defaults, so that one run
+// doesn't leak its flags into a later run of the same command.
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Value.String() == flag.DefValue && !flag.Changed {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(flag.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			_ = slice.Replace(values)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+}
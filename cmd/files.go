@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+// flagFiles holds the --file patterns of commands that can read their input
+// from files instead of an argument or stdin.
+var flagFiles []string
+
+const fileFlagUsage = "read input from a file, repeatable; globs such as 'k8s/**/*.yaml' are expanded"
+
+// addFileFlag lets cmd read its input from files given with -f/--file. Each
+// file is fed to the command's RunE as stdin in turn; with several files
+// every result is preceded by a header naming the file, and a failing file
+// is reported without stopping the others.
+func addFileFlag(cmd *cobra.Command) {
+	addFileFlagAfterArgs(cmd, 0)
+}
+
+// addFileFlagAfterArgs is addFileFlag for commands that take n positional
+// arguments before the input, such as the expression of query.
+func addFileFlagAfterArgs(cmd *cobra.Command, n int) {
+	cmd.Flags().StringArrayVarP(&flagFiles, "file", "f", nil, fileFlagUsage)
+	_ = cmd.MarkFlagFilename("file")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(flagFiles) == 0 {
+			return run(cmd, args)
+		}
+		if len(args) > n {
+			return errors.New("input can't be given both as an argument and with --file")
+		}
+		return runFiles(cmd, args, run)
+	}
+}
+
+// addFileArgsFlag lets cmd, which takes the files it reads as arguments,
+// also take them with -f/--file. The files are appended to the arguments,
+// so that a command comparing or merging documents still sees all of them
+// at once.
+func addFileArgsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&flagFiles, "file", "f", nil, fileFlagUsage)
+	_ = cmd.MarkFlagFilename("file")
+
+	withFiles := func(args []string) ([]string, error) {
+		paths, err := input.ExpandFiles(flagFiles)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Concat(args, paths), nil
+	}
+	validate := cmd.Args
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		args, err := withFiles(args)
+		if err != nil || validate == nil {
+			return err
+		}
+		return validate(cmd, args)
+	}
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		args, err := withFiles(args)
+		if err != nil {
+			return err
+		}
+		return run(cmd, args)
+	}
+}
+
+func runFiles(cmd *cobra.Command, args []string, run func(*cobra.Command, []string) error) error {
+	paths, err := input.ExpandFiles(flagFiles)
+	if err != nil {
+		return err
+	}
+	if len(paths) > 1 {
+		if tui := cmd.Flags().Lookup("tui"); tui != nil && tui.Value.String() == "true" {
+			return errors.New("--tui accepts a single --file")
+		}
+	}
+
+	// Commands read the root's stdin; clearing the override restores that.
+	defer cmd.SetIn(nil)
+
+	if len(paths) == 1 {
+		if err := runFile(cmd, args, paths[0], run); err != nil {
+			return fmt.Errorf("%w (in %s)", err, paths[0])
+		}
+		return nil
+	}
+
+	failed := 0
+	for i, path := range paths {
+		if i > 0 {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout())
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "==> %s <==\n", path)
+		if err := runFile(cmd, args, path, run); err != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", path, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	}
+	return nil
+}
+
+func runFile(cmd *cobra.Command, args []string, path string, run func(*cobra.Command, []string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	cmd.SetIn(f)
+	return run(cmd, args)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/fang/v2"
+)
+
+// writeTreeFile writes a file below dir, creating parent directories.
+func writeTreeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileFlag(t *testing.T) {
+	dir := t.TempDir()
+	good := writeTreeFile(t, dir, "a.json", `{"b":1,"a":2}`)
+	writeTreeFile(t, dir, "nested/b.json", `{"c":[1,2]}`)
+	bad := writeTreeFile(t, dir, "nested/bad.json", `{"c":`)
+	schema := writeTreeFile(t, t.TempDir(), "schema.json", `{"type": "object", "required": ["a"]}`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOut    []string
+		wantErrOut string
+		wantErr    bool
+	}{
+		{
+			name:    "single file",
+			args:    []string{"jsonfmt", "-f", good},
+			wantOut: []string{"\"a\": 2"},
+		},
+		{
+			name:       "recursive glob",
+			args:       []string{"json2yaml", "--file", filepath.Join(dir, "**", "*.json"), "--file", good},
+			wantOut:    []string{"==> " + good + " <==\nb: 1\na: 2", "==> " + filepath.Join(dir, "nested", "b.json") + " <=="},
+			wantErr:    true,
+			wantErrOut: bad + ": ",
+		},
+		{
+			name:    "query expression with file",
+			args:    []string{"query", ".a", "-f", good},
+			wantOut: []string{"2"},
+		},
+		{
+			name:    "argument and file",
+			args:    []string{"jsonfmt", "-f", good, `{"a":1}`},
+			wantErr: true,
+		},
+		{
+			name:    "schema inferred from all files",
+			args:    []string{"jsonschema", "infer", "-f", filepath.Join(dir, "**", "b.json"), "-f", good},
+			wantOut: []string{`"a": {`, `"c": {`},
+		},
+		{
+			name: "schema validation of a file",
+			args: []string{"jsonschema", "validate", "--schema", schema, "-f", good},
+		},
+		{
+			// diff exits with an error when the documents differ.
+			name:    "diff of two files",
+			args:    []string{"diff", "-f", good, "--file", filepath.Join(dir, "**", "b.json"), "--format", "json-patch"},
+			wantOut: []string{`"path": "/c"`},
+			wantErr: true,
+		},
+		{
+			name:       "ndjson of two files",
+			args:       []string{"ndjson", "-f", filepath.Join(dir, "**", "b.json"), "-f", good},
+			wantErr:    true,
+			wantErrOut: "accepts at most 1 arg(s), received 2",
+		},
+		{
+			name:    "no match",
+			args:    []string{"jsonfmt", "-f", filepath.Join(dir, "*.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagFiles = nil
+			t.Cleanup(func() { flagFiles = nil })
+
+			cmd := GetRootCmd()
+			out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(errOut)
+			cmd.SetIn(strings.NewReader(""))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			if !strings.Contains(errOut.String(), tt.wantErrOut) {
+				t.Errorf("error output missing %q:\n%s", tt.wantErrOut, errOut.String())
+			}
+		})
+	}
+}
+
+func TestFileFlagErrorText(t *testing.T) {
+	bad := writeTreeFile(t, t.TempDir(), "in.json", `{"c":`)
+	flagFiles = nil
+	t.Cleanup(func() { flagFiles = nil })
+
+	cmd := GetRootCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetArgs([]string{"json2yaml", "-f", bad})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Execute() succeeded on invalid JSON")
+	}
+
+	// The printed error starts with the message, since the first word is
+	// capitalized in a terminal, and names the file after it.
+	printed := new(bytes.Buffer)
+	customErrorHandler(printed, fang.Styles{}, err)
+	got := strings.TrimSpace(printed.String())
+	if strings.HasPrefix(got, bad) || !strings.HasSuffix(got, " (in "+bad+")") {
+		t.Errorf("printed error = %q, want the message followed by (in %s)", got, bad)
+	}
+}
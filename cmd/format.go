@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/skatkov/devtui/internal/diff"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+var (
+	formatWrite bool
+	formatCheck bool
+	formatDiff  bool
+)
+
+// addFormatFlags adds --file along with the --write, --check and --diff
+// modes to a formatter. In those modes the formatter runs once per file with
+// its output captured and compared with the file: --write replaces files
+// whose formatting differs, while --check lists them (and --diff shows how
+// they differ) and fails. The output always ends in exactly one newline, so
+// that what the formatter prints passes --check.
+func addFormatFlags(cmd *cobra.Command) {
+	run := cmd.RunE
+	format := func(cmd *cobra.Command, args []string) error {
+		if tui := cmd.Flags().Lookup("tui"); tui != nil && tui.Value.String() == "true" {
+			return run(cmd, args)
+		}
+		out := &newlineWriter{w: cmd.OutOrStdout()}
+		cmd.SetOut(out)
+		// Commands use the root's streams; clearing the override restores that.
+		defer cmd.SetOut(nil)
+		if err := run(cmd, args); err != nil {
+			return err
+		}
+		return out.Close()
+	}
+	cmd.RunE = format
+	addFileFlag(cmd)
+	files := cmd.RunE
+
+	cmd.Flags().BoolVarP(&formatWrite, "write", "w", false, "rewrite files given with --file in place")
+	markLocalOnly(cmd, "write")
+	cmd.Flags().BoolVar(&formatCheck, "check", false, "list inputs that are not formatted and exit non-zero")
+	cmd.Flags().BoolVar(&formatDiff, "diff", false, "like --check, also showing a unified diff of the changes")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !formatWrite && !formatCheck && !formatDiff {
+			return files(cmd, args)
+		}
+		return runFormat(cmd, args, format)
+	}
+}
+
+func runFormat(cmd *cobra.Command, args []string, format func(*cobra.Command, []string) error) error {
+	if formatWrite && (formatCheck || formatDiff) {
+		return errors.New("--write can't be combined with --check or --diff")
+	}
+	if tui := cmd.Flags().Lookup("tui"); tui != nil && tui.Value.String() == "true" {
+		return errors.New("--tui can't be combined with --write, --check or --diff")
+	}
+
+	if len(flagFiles) == 0 {
+		if formatWrite {
+			return errors.New("--write needs files given with --file")
+		}
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return err
+		}
+		formatted, err := formatContent(cmd, data, format)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(data, formatted) {
+			reportUnformatted(cmd, "<stdin>", data, formatted)
+			return errors.New("input is not formatted")
+		}
+		return nil
+	}
+	if len(args) > 0 {
+		return errors.New("input can't be given both as an argument and with --file")
+	}
+
+	paths, err := input.ExpandFiles(flagFiles)
+	if err != nil {
+		return err
+	}
+
+	failed, unformatted := 0, 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var formatted []byte
+			formatted, err = formatContent(cmd, data, format)
+			if err == nil && !bytes.Equal(data, formatted) {
+				unformatted++
+				if formatWrite {
+					err = writeFileAtomic(path, formatted)
+					if err == nil {
+						_, _ = fmt.Fprintln(cmd.OutOrStdout(), path)
+					}
+				} else {
+					reportUnformatted(cmd, path, data, formatted)
+				}
+			}
+		}
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", path, err)
+		}
+	}
+
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	case unformatted > 0 && !formatWrite:
+		return fmt.Errorf("%d of %d files are not formatted; run with --write to fix", unformatted, len(paths))
+	}
+	return nil
+}
+
+// formatContent runs the formatter on data and returns its output.
+func formatContent(cmd *cobra.Command, data []byte, format func(*cobra.Command, []string) error) ([]byte, error) {
+	var out bytes.Buffer
+	cmd.SetIn(bytes.NewReader(data))
+	cmd.SetOut(&out)
+	// Commands use the root's streams; clearing the overrides restores that.
+	defer cmd.SetIn(nil)
+	defer cmd.SetOut(nil)
+
+	if err := format(cmd, nil); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// newlineWriter passes writes through to w but holds back trailing
+// newlines, so that the output ends in exactly one newline once closed.
+// Output is still streamed, as formatting JSON Lines needs.
+type newlineWriter struct {
+	w       io.Writer
+	pending int
+}
+
+func (n *newlineWriter) Write(p []byte) (int, error) {
+	body := bytes.TrimRight(p, "\n")
+	if len(body) > 0 {
+		if _, err := n.w.Write(append(bytes.Repeat([]byte{'\n'}, n.pending), body...)); err != nil {
+			return 0, err
+		}
+		n.pending = 0
+	}
+	n.pending += len(p) - len(body)
+	return len(p), nil
+}
+
+// Close writes the final newline.
+func (n *newlineWriter) Close() error {
+	_, err := n.w.Write([]byte{'\n'})
+	return err
+}
+
+func reportUnformatted(cmd *cobra.Command, name string, data, formatted []byte) {
+	if !formatDiff {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+		return
+	}
+	_, _ = fmt.Fprint(cmd.OutOrStdout(),
+		diff.Unified(name, name+" (formatted)", string(data), string(formatted), 3))
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so readers never see a partially written file. The file
+// mode is kept.
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
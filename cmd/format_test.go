@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func resetFormatFlags() {
+	flagFiles = nil
+	formatWrite = false
+	formatCheck = false
+	formatDiff = false
+}
+
+func TestFormatModes(t *testing.T) {
+	const (
+		formattedYAML   = "name: api\nport: 8080\n"
+		unformattedYAML = "name:   api\nport:     8080\n"
+	)
+
+	tests := []struct {
+		name      string
+		args      []string
+		stdin     string
+		wantOut   []string
+		wantFiles map[string]string
+		wantErr   bool
+	}{
+		{
+			name:    "check lists unformatted files",
+			args:    []string{"yamlfmt", "--check", "-f", "*.yaml"},
+			wantOut: []string{"bad.yaml\n"},
+			wantErr: true,
+		},
+		{
+			name:    "diff shows changes",
+			args:    []string{"yamlfmt", "--diff", "-f", "*.yaml"},
+			wantOut: []string{"--- bad.yaml\n+++ bad.yaml (formatted)\n@@ -1,2 +1,2 @@\n-name:   api\n-port:     8080\n+name: api\n+port: 8080\n"},
+			wantErr: true,
+		},
+		{
+			name:      "write rewrites unformatted files",
+			args:      []string{"yamlfmt", "--write", "-f", "*.yaml"},
+			wantOut:   []string{"bad.yaml\n"},
+			wantFiles: map[string]string{"bad.yaml": formattedYAML, "good.yaml": formattedYAML},
+		},
+		{
+			name:  "check passes formatted stdin",
+			args:  []string{"jsonfmt", "--check"},
+			stdin: "{\n  \"a\": 1\n}\n",
+		},
+		{
+			name:    "check fails unformatted stdin",
+			args:    []string{"jsonfmt", "--check"},
+			stdin:   `{"a":1}`,
+			wantOut: []string{"<stdin>\n"},
+			wantErr: true,
+		},
+		{
+			name:    "write needs files",
+			args:    []string{"jsonfmt", "--write"},
+			stdin:   `{"a":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "write and check conflict",
+			args:    []string{"yamlfmt", "--write", "--check", "-f", "*.yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFormatFlags()
+			t.Cleanup(resetFormatFlags)
+
+			dir := t.TempDir()
+			writeTreeFile(t, dir, "good.yaml", formattedYAML)
+			writeTreeFile(t, dir, "bad.yaml", unformattedYAML)
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = os.Chdir(wd) })
+
+			cmd := GetRootCmd()
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			cmd.SetArgs(tt.args)
+
+			err = cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			if tt.wantOut == nil && !tt.wantErr && out.Len() > 0 {
+				t.Errorf("unexpected output:\n%s", out.String())
+			}
+			for name, want := range tt.wantFiles {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatOutputPassesCheck(t *testing.T) {
+	inputs := map[string]string{
+		"jsonfmt":  `{"a":1,"b":[1,2]}`,
+		"yamlfmt":  "a:   1\nb: [1,   2]\n",
+		"tomlfmt":  "a =   1\n[b]\nc=2\n",
+		"xmlfmt":   "<a><b>1</b></a>",
+		"cssfmt":   "a{color:red}",
+		"htmlfmt":  "<p>hi</p>",
+		"gqlquery": "{ user { id } }",
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			resetFormatFlags()
+			t.Cleanup(resetFormatFlags)
+
+			cmd := GetRootCmd()
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetIn(strings.NewReader(input))
+			cmd.SetArgs([]string{name})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("format: %v", err)
+			}
+			if !strings.HasSuffix(out.String(), "\n") || strings.HasSuffix(out.String(), "\n\n") {
+				t.Errorf("output doesn't end in exactly one newline: %q", out.String())
+			}
+
+			path := writeTreeFile(t, t.TempDir(), "formatted", out.String())
+			resetFormatFlags()
+			checked := new(bytes.Buffer)
+			cmd.SetOut(checked)
+			cmd.SetIn(strings.NewReader(""))
+			cmd.SetArgs([]string{name, "--check", "-f", path})
+			if err := cmd.Execute(); err != nil {
+				t.Errorf("--check on the formatted output: %v\n%s", err, checked.String())
+			}
+		})
+	}
+}
@@ -67,6 +67,7 @@ or piped from stdin.`,
 			common := &ui.CommonModel{
 				Width:  100, // Default width, will be adjusted by the TUI
 				Height: 30,  // Default height, will be adjusted by the TUI
+				Config: appConfig,
 			}
 
 			model := graphqlquery.NewGraphQLQueryModel(common)
@@ -137,6 +138,7 @@ var (
 
 func init() {
 	rootCmd.AddCommand(gqlfmtCmd)
+	addFormatFlags(gqlfmtCmd)
 
 	gqlfmtCmd.Flags().StringVarP(&gqlIndentString, "indent", "i", "  ",
 		"Indent string for nested elements (default is 2 spaces)")
@@ -148,4 +150,5 @@ func init() {
 		"Include descriptions in the formatted output (omitted by default)")
 	gqlfmtCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false,
 		"Open result in TUI")
+	markInteractive(gqlfmtCmd, "tui")
 }
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/hashutil"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+var hashCmd = &cobra.Command{
+	Use:   "hash [string]",
+	Short: "Generate and verify hash digests",
+	Long: `Generate MD5, SHA-1, SHA-2, SHA-3, BLAKE2b and BLAKE3 digests.
+
+Input can be a string argument, piped from stdin, or read from files with --file,
+which is repeatable and expands globs.
+By default every supported algorithm is computed and shown in a table. Select
+algorithms with --algorithm; a single algorithm prints just the digest.
+
+Use --check to verify a checksum file produced by sha256sum, md5sum and friends
+(both "<digest>  <file>" and "SHA256 (<file>) = <digest>" lines are supported).`,
+	Example: `  # Hash a string with every algorithm
+  devtui hash "hello world"
+
+  # SHA-256 of a file
+  devtui hash --algorithm sha256 --file download.tar.gz
+
+  # Write a checksum file for every archive in dist/
+  devtui hash -a sha256 -f 'dist/**/*.tar.gz' > SHA256SUMS
+
+  # Several algorithms from stdin as base64
+  cat file.bin | devtui hash -a sha256 -a blake3 --encoding base64
+
+  # Output as JSON
+  devtui hash --json "hello world"
+
+  # Verify a checksum file
+  devtui hash --check SHA256SUMS`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoding, err := hashutil.ParseEncoding(hashEncoding)
+		if err != nil {
+			return err
+		}
+
+		if hashCheckFile != "" {
+			return runHashCheck(cmd)
+		}
+
+		algorithms, err := selectedHashAlgorithms()
+		if err != nil {
+			return err
+		}
+
+		if len(hashFiles) > 0 {
+			if len(args) > 0 {
+				return errors.New("input can't be given both as an argument and with --file")
+			}
+			return runHashFiles(cmd, algorithms, encoding)
+		}
+
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		if len(args) == 0 && len(data) == 0 {
+			return errors.New("no input provided. pipe input to this command or use --file")
+		}
+		source := "-"
+		if len(args) > 0 {
+			source = "argument"
+		}
+		digests := hashutil.SumString(string(data), algorithms, encoding)
+		return writeHashResult(cmd, source, algorithms, encoding, digests)
+	},
+}
+
+var (
+	hashAlgorithms []string
+	hashEncoding   string
+	hashFiles      []string
+	hashCheckFile  string
+	hashJSONOutput bool
+)
+
+func init() {
+	rootCmd.AddCommand(hashCmd)
+	hashCmd.Flags().StringArrayVarP(&hashAlgorithms, "algorithm", "a", nil,
+		"hash algorithm to use, repeatable (default: all). One of: "+strings.Join(hashutil.Names(), ", "))
+	hashCmd.Flags().StringVarP(&hashEncoding, "encoding", "e", string(hashutil.EncodingHex), "digest encoding (hex, base64)")
+	hashCmd.Flags().StringArrayVarP(&hashFiles, "file", "f", nil,
+		"hash the contents of a file, repeatable; globs such as 'dist/**/*.tar.gz' are expanded")
+	hashCmd.Flags().StringVarP(&hashCheckFile, "check", "c", "", "verify digests listed in a checksum file")
+	_ = hashCmd.MarkFlagFilename("file")
+	_ = hashCmd.MarkFlagFilename("check")
+	hashCmd.Flags().BoolVar(&hashJSONOutput, "json", false, "output digests as JSON")
+}
+
+func selectedHashAlgorithms() ([]hashutil.Algorithm, error) {
+	if len(hashAlgorithms) == 0 {
+		return hashutil.Algorithms, nil
+	}
+
+	algorithms := make([]hashutil.Algorithm, 0, len(hashAlgorithms))
+	for _, name := range hashAlgorithms {
+		algorithm, err := hashutil.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		algorithms = append(algorithms, algorithm)
+	}
+	return algorithms, nil
+}
+
+// writeHashResult prints the digests of one input as a table, a bare digest
+// or JSON.
+func writeHashResult(cmd *cobra.Command, source string, algorithms []hashutil.Algorithm, encoding hashutil.Encoding, digests []hashutil.Digest) error {
+	if hashJSONOutput {
+		bytes, err := json.MarshalIndent(hashutil.Result{
+			Source:   source,
+			Encoding: encoding,
+			Digests:  digests,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+		return err
+	}
+
+	if len(digests) == 1 {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), digests[0].Value)
+		return err
+	}
+
+	output := table.New().Border(lipgloss.NormalBorder())
+	for i, digest := range digests {
+		output.Row(algorithms[i].Label, digest.Value)
+	}
+
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), output.String())
+	return err
+}
+
+// runHashFiles hashes every file matched by --file. A single file prints
+// like any other input; several files print "<digest>  <file>" lines for a
+// single algorithm, so the output can be checked with --check, a JSON array,
+// or a table per file. Unreadable files are reported and skipped.
+func runHashFiles(cmd *cobra.Command, algorithms []hashutil.Algorithm, encoding hashutil.Encoding) error {
+	paths, err := input.ExpandFiles(hashFiles)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 1 {
+		digests, err := hashFileDigests(paths[0], algorithms, encoding)
+		if err != nil {
+			return err
+		}
+		return writeHashResult(cmd, paths[0], algorithms, encoding, digests)
+	}
+
+	var (
+		results []hashutil.Result
+		failed  int
+	)
+	for _, path := range paths {
+		digests, err := hashFileDigests(path, algorithms, encoding)
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", path, err)
+			continue
+		}
+
+		switch {
+		case hashJSONOutput:
+			results = append(results, hashutil.Result{Source: path, Encoding: encoding, Digests: digests})
+		case len(digests) == 1:
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", digests[0].Value, path)
+		default:
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "==> %s <==\n", path)
+			if err == nil {
+				err = writeHashResult(cmd, path, algorithms, encoding, digests)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if hashJSONOutput {
+		bytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bytes)); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed", failed, len(paths))
+	}
+	return nil
+}
+
+func hashFileDigests(path string, algorithms []hashutil.Algorithm, encoding hashutil.Encoding) ([]hashutil.Digest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return hashutil.Sum(f, algorithms, encoding)
+}
+
+func runHashCheck(cmd *cobra.Command) error {
+	if len(hashAlgorithms) > 1 {
+		return errors.New("--check accepts at most one --algorithm")
+	}
+	algorithm := ""
+	if len(hashAlgorithms) == 1 {
+		algorithm = hashAlgorithms[0]
+	}
+
+	f, err := os.Open(hashCheckFile)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	results, err := hashutil.Check(f, algorithm, func(name string) (io.ReadCloser, error) {
+		return os.Open(name)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", hashCheckFile, err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.OK {
+			failed++
+		}
+	}
+
+	if hashJSONOutput {
+		bytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bytes)); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range results {
+			status := "OK"
+			switch {
+			case result.Error != "":
+				status = "FAILED open or read (" + result.Error + ")"
+			case !result.OK:
+				status = "FAILED"
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", result.Name, status); err != nil {
+				return err
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d computed checksums did NOT match", failed, len(results))
+	}
+	return nil
+}
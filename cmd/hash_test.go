@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/hashutil"
+)
+
+func resetHashFlags() {
+	hashAlgorithms = nil
+	hashEncoding = string(hashutil.EncodingHex)
+	hashFiles = nil
+	hashCheckFile = ""
+	hashJSONOutput = false
+}
+
+func TestHashCmdSingleAlgorithm(t *testing.T) {
+	resetHashFlags()
+	defer resetHashFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"hash", "--algorithm", "sha256", "hello world"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("hash command failed: %v", err)
+	}
+
+	want := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestHashCmdJSON(t *testing.T) {
+	resetHashFlags()
+	defer resetHashFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("hello world"))
+	cmd.SetArgs([]string{"hash", "--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("hash --json command failed: %v", err)
+	}
+
+	var result hashutil.Result
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("hash --json output invalid JSON: %v", err)
+	}
+	if len(result.Digests) != len(hashutil.Algorithms) {
+		t.Fatalf("expected %d digests, got %d", len(hashutil.Algorithms), len(result.Digests))
+	}
+}
+
+func TestHashCmdCheck(t *testing.T) {
+	resetHashFlags()
+	defer resetHashFlags()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "download.txt")
+	if err := os.WriteFile(target, []byte("hello world"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sums := filepath.Join(dir, "SHA256SUMS")
+	line := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  " + target + "\n"
+	if err := os.WriteFile(sums, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"hash", "--check", sums})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("hash --check command failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), target+": OK") {
+		t.Fatalf("expected OK status, got %s", buf.String())
+	}
+
+	if err := os.WriteFile(target, []byte("tampered"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resetHashFlags()
+	buf.Reset()
+	cmd.SetArgs([]string{"hash", "--check", sums})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected hash --check to fail for a mismatched digest")
+	}
+}
+
+func TestHashCmdNoInput(t *testing.T) {
+	resetHashFlags()
+	defer resetHashFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetArgs([]string{"hash"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("hash command should return error when no input provided")
+	}
+}
+
+func TestHashCmdFiles(t *testing.T) {
+	resetHashFlags()
+	defer resetHashFlags()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.txt": "hello world", "b.txt": ""} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"hash", "-a", "sha256", "-f", filepath.Join(dir, "*.txt")})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("hash --file command failed: %v", err)
+	}
+
+	want := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  " + filepath.Join(dir, "a.txt") + "\n" +
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  " + filepath.Join(dir, "b.txt") + "\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -46,4 +46,5 @@ Input can be a string argument, piped from stdin, or read from a file.`,
 
 func init() {
 	rootCmd.AddCommand(htmlfmtCmd)
+	addFormatFlags(htmlfmtCmd)
 }
@@ -41,12 +41,11 @@ Use the --formatted flag to output the IBAN in paper format with spaces.`,
 
 		// Format output based on flag
 		if ibanFormatted {
-			fmt.Println(iban.PaperFormat(generatedIban))
-		} else {
-			fmt.Println(generatedIban)
+			generatedIban = iban.PaperFormat(generatedIban)
 		}
 
-		return nil
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), generatedIban)
+		return err
 	},
 }
 
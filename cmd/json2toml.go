@@ -19,7 +19,9 @@ var json2tomlCmd = &cobra.Command{
 
 Input can be a string argument or piped from stdin. JSON numbers are preserved
 as integers when appropriate (not converted to floats). Use --tui flag to view
-results in an interactive terminal interface.`,
+results in an interactive terminal interface. With --lines every line of JSON
+Lines input is converted on its own, producing TOML documents separated by
+blank lines.`,
 	Example: `  # Convert JSON from stdin
   devtui json2toml < config.json
   cat app.json | devtui json2toml
@@ -35,10 +37,17 @@ results in an interactive terminal interface.`,
   devtui json2toml --tui < config.json
   devtui json2toml -t < config.json
 
+  # Convert a JSON Lines log one record at a time
+  devtui json2toml --lines < events.ndjson
+
   # Chain with other commands
   curl -s https://api.example.com/config | devtui json2toml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagLines {
+			return transformLines(cmd, args, "\n", json2toml.Convert)
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return fmt.Errorf("error reading from stdin: %w", err)
@@ -84,5 +93,9 @@ results in an interactive terminal interface.`,
 
 func init() {
 	rootCmd.AddCommand(json2tomlCmd)
+	addFileFlag(json2tomlCmd)
 	json2tomlCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
+	markInteractive(json2tomlCmd, "tui")
+	addLinesFlag(json2tomlCmd)
+	json2tomlCmd.MarkFlagsMutuallyExclusive("tui", "lines")
 }
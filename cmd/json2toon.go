@@ -15,11 +15,14 @@ var json2toonCmd = &cobra.Command{
 	Short: "Convert JSON to TOON",
 	Long: `Convert JSON to TOON - a compact, human-readable
 format designed for passing structured data to Large Language Models with significantly
-reduced token usage (typically 30-60% fewer tokens than JSON).`,
+reduced token usage (typically 30-60% fewer tokens than JSON).
+
+With --lines every line of JSON Lines input is converted on its own.`,
 	Example: `  devtui json2toon < example.json                    # Convert with defaults
   devtui json2toon -i 4 < example.json               # Use 4-space indent
   devtui json2toon -l '#' < example.json             # Add length marker prefix
-  cat example.json | devtui json2toon > output.toon  # Pipe and save to file`,
+  cat example.json | devtui json2toon > output.toon  # Pipe and save to file
+  devtui json2toon --lines < events.ndjson           # Convert one record at a time`,
 	Args: cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Validate indent flag
@@ -29,17 +32,23 @@ reduced token usage (typically 30-60% fewer tokens than JSON).`,
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
-		if err != nil {
-			return err
-		}
-
 		opts := toon.EncodeOptions{
 			Indent:       json2toonIndent,
 			Delimiter:    ",",
 			LengthMarker: json2toonLengthMarker,
 		}
 
+		if flagLines {
+			return transformLines(cmd, args, "\n", func(record string) (string, error) {
+				return json2toon.ConvertWithOptions(record, opts)
+			})
+		}
+
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return err
+		}
+
 		inputStr := string(data)
 		result, err := json2toon.ConvertWithOptions(inputStr, opts)
 		if err != nil {
@@ -62,7 +71,9 @@ var (
 
 func init() {
 	rootCmd.AddCommand(json2toonCmd)
+	addFileFlag(json2toonCmd)
 
 	json2toonCmd.Flags().IntVarP(&json2toonIndent, "indent", "i", 2, "Number of spaces per indentation level")
 	json2toonCmd.Flags().StringVarP(&json2toonLengthMarker, "length-marker", "l", "", "Optional marker to prefix array lengths (e.g., '#')")
+	addLinesFlag(json2toonCmd)
 }
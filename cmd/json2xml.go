@@ -15,7 +15,8 @@ var json2xmlCmd = &cobra.Command{
 	Short: "Convert JSON to XML format",
 	Long: `Convert JSON to XML format.
 
-Input can be a string argument or piped from stdin.`,
+Input can be a string argument or piped from stdin. With --lines every line of
+JSON Lines input is converted on its own, producing XML documents separated by blank lines.`,
 	Example: `  # Convert JSON from stdin
   devtui json2xml < data.json
   cat feed.json | devtui json2xml
@@ -26,10 +27,17 @@ Input can be a string argument or piped from stdin.`,
   # Output to file
   devtui json2xml < input.json > output.xml
 
+  # Convert a JSON Lines log one record at a time
+  devtui json2xml --lines < events.ndjson
+
   # Chain with other commands
   curl -s https://api.example.com/data.json | devtui json2xml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagLines {
+			return transformLines(cmd, args, "\n", converter.JSONToXML)
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return fmt.Errorf("error reading from stdin: %w", err)
@@ -56,4 +64,6 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(json2xmlCmd)
+	addFileFlag(json2xmlCmd)
+	addLinesFlag(json2xmlCmd)
 }
@@ -15,7 +15,8 @@ var json2yamlCmd = &cobra.Command{
 	Short: "Convert JSON to YAML format",
 	Long: `Convert JSON to YAML format.
 
-Input can be a string argument or piped from stdin.`,
+Input can be a string argument or piped from stdin. With --lines every line of
+JSON Lines input is converted on its own, producing YAML documents separated by "---".`,
 	Example: `  # Convert JSON from stdin
   devtui json2yaml < config.json
   cat app.json | devtui json2yaml
@@ -26,10 +27,17 @@ Input can be a string argument or piped from stdin.`,
   # Output to file
   devtui json2yaml < input.json > output.yaml
 
+  # Convert a JSON Lines log one record at a time
+  devtui json2yaml --lines < events.ndjson
+
   # Chain with other commands
   curl -s https://api.example.com/config | devtui json2yaml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagLines {
+			return transformLines(cmd, args, "---\n", converter.JSONToYAML)
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return fmt.Errorf("error reading from stdin: %w", err)
@@ -56,4 +64,6 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(json2yamlCmd)
+	addFileFlag(json2yamlCmd)
+	addLinesFlag(json2yamlCmd)
 }
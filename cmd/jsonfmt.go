@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	encjson "encoding/json"
 	"fmt"
 
 	"github.com/skatkov/devtui/internal/input"
@@ -14,7 +16,12 @@ var jsonfmtCmd = &cobra.Command{
 	Long: `Format and prettify JSON input with proper indentation and syntax highlighting.
 
 Input can be a string argument, piped from stdin, or read from a file.
-The output is always valid, properly indented JSON.`,
+The output is always valid, properly indented JSON. With --lines every line
+of JSON Lines input is checked and printed compactly on its own line, in
+constant memory, so the output is still JSON Lines.
+
+Files given with --file can be rewritten in place with --write, or checked
+with --check and --diff, which exit non-zero when a file is not formatted.`,
 	Example: `  # Format JSON from stdin
   devtui jsonfmt < example.json
   echo '{"name":"John","age":30}' | devtui jsonfmt
@@ -26,11 +33,28 @@ The output is always valid, properly indented JSON.`,
   devtui jsonfmt < input.json > formatted.json
   cat compact.json | devtui jsonfmt > pretty.json
 
+  # Normalize every record of a JSON Lines log
+  devtui jsonfmt --lines < app.ndjson
+
+  # Format files in place, or check them in CI
+  devtui jsonfmt --write -f 'config/*.json'
+  devtui jsonfmt --check -f 'config/*.json'
+
   # Chain with other commands
   curl -s https://api.example.com/data | devtui jsonfmt
   devtui jsonrepair < broken.json | devtui jsonfmt`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagLines {
+			return transformLines(cmd, args, "", func(record string) (string, error) {
+				var out bytes.Buffer
+				if err := encjson.Compact(&out, []byte(record)); err != nil {
+					return "", err
+				}
+				return out.String(), nil
+			})
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return err
@@ -68,5 +92,7 @@ The output is always valid, properly indented JSON.`,
 
 func init() {
 	rootCmd.AddCommand(jsonfmtCmd)
+	addFormatFlags(jsonfmtCmd)
+	addLinesFlag(jsonfmtCmd)
 	// jsonfmtCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
 }
@@ -64,4 +64,5 @@ This tool can fix various JSON issues including:
 
 func init() {
 	rootCmd.AddCommand(jsonrepairCmd)
+	addFileFlag(jsonrepairCmd)
 }
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+var jsonschemaCmd = &cobra.Command{
+	Use:   "jsonschema",
+	Short: "Infer JSON Schemas from samples and validate documents against them",
+	Long: `Infer a JSON Schema (draft 2020-12) from sample documents, or validate
+documents against a schema.
+
+Documents may be JSON, YAML, TOML, XML, CSV, TSV, TOON or JSON Lines; the
+format is detected from the file extension or the content unless --from is
+given. Use "-" or no file at all to read from stdin.`,
+	Example: `  # Infer a schema from two samples
+  devtui jsonschema infer user1.json user2.yaml > user.schema.json
+
+  # Validate config files against the schema
+  devtui jsonschema validate --schema config.schema.json config.yaml`,
+}
+
+var jsonschemaInferCmd = &cobra.Command{
+	Use:   "infer [file...]",
+	Short: "Derive a JSON Schema from sample documents",
+	Long: `Derive a draft 2020-12 JSON Schema that every sample satisfies.
+
+Each file is one sample, except JSON Lines input where every record is a
+sample. Properties present in all samples are marked as required, integers
+and floats at the same place widen to "number", and string formats such as
+date-time, date, uuid and email are recorded when all samples agree.`,
+	Example: `  # Infer a schema from a single document
+  devtui jsonschema infer response.json
+
+  # Infer a schema from every record of a log
+  devtui jsonschema infer events.ndjson
+
+  # Infer a schema from all samples in a directory tree
+  devtui jsonschema infer -f 'samples/**/*.json'
+
+  # Infer a schema from stdin
+  curl -s https://api.example.com/users/1 | devtui jsonschema infer`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+		if err := checkSingleStdin(args); err != nil {
+			return err
+		}
+
+		var samples []any
+		for _, path := range args {
+			content, format, err := readDocument(cmd, path, jsonschemaFrom)
+			if err != nil {
+				return err
+			}
+			value, err := converter.Decode(content, format)
+			if err != nil {
+				return fmt.Errorf("%s: %w", documentName(path), err)
+			}
+			if format == converter.FormatJSONL {
+				records, _ := value.([]any)
+				samples = append(samples, records...)
+			} else {
+				samples = append(samples, value)
+			}
+		}
+
+		return writeJSONDocument(cmd, jsonschema.Infer(samples...))
+	},
+}
+
+var jsonschemaValidateCmd = &cobra.Command{
+	Use:   "validate --schema <file> [file...]",
+	Short: "Validate documents against a JSON Schema",
+	Long: `Validate documents against a JSON Schema file (draft 2020-12).
+
+Every violation is printed on its own line with the document name, the line
+and column of the offending value (for JSON, JSON Lines, YAML and TOML), its
+JSON Pointer and the failed keyword. JSON Lines input is validated record by
+record. Nothing is printed for valid documents.
+
+The schema may be written in JSON or YAML. References ($ref) must point
+within the schema itself. The command exits with status 1 when any document
+is invalid.`,
+	Example: `  # Validate a config file
+  devtui jsonschema validate --schema config.schema.json config.yaml
+
+  # Validate several files at once
+  devtui jsonschema validate --schema event.schema.json a.json b.json
+
+  # Validate every record of a JSON Lines stream
+  cat events.ndjson | devtui jsonschema validate --schema event.schema.json --from jsonl`,
+	// Violations are reported through errSilent, which must not print usage
+	// or an error after the report.
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			args = []string{"-"}
+		}
+		if err := checkSingleStdin(append([]string{jsonschemaSchema}, args...)); err != nil {
+			return err
+		}
+
+		content, format, err := readDocument(cmd, jsonschemaSchema, "")
+		if err != nil {
+			return err
+		}
+		value, err := converter.Decode(content, format)
+		if err != nil {
+			return fmt.Errorf("%s: %w", documentName(jsonschemaSchema), err)
+		}
+		schema, err := jsonschema.Compile(value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid schema: %w", documentName(jsonschemaSchema), err)
+		}
+
+		invalid := false
+		for _, path := range args {
+			content, format, err := readDocument(cmd, path, jsonschemaFrom)
+			if err != nil {
+				return err
+			}
+			value, err := converter.Decode(content, format)
+			if err != nil {
+				return fmt.Errorf("%s: %w", documentName(path), err)
+			}
+			locations := jsonschema.Locate(content, format)
+
+			documents := []any{value}
+			if format == converter.FormatJSONL {
+				documents, _ = value.([]any)
+			}
+			for i, document := range documents {
+				prefix := ""
+				if format == converter.FormatJSONL {
+					prefix = "/" + strconv.Itoa(i)
+				}
+				for _, violation := range schema.Validate(document) {
+					invalid = true
+					location := documentName(path)
+					if position := locations.Find(prefix + violation.Pointer); position.Line > 0 {
+						location += ":" + position.String()
+					}
+					if _, err := fmt.Fprintln(cmd.OutOrStdout(), location+": "+violation.String()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if invalid {
+			return errSilent
+		}
+		return nil
+	},
+}
+
+var (
+	jsonschemaFrom   string
+	jsonschemaSchema string
+)
+
+func init() {
+	rootCmd.AddCommand(jsonschemaCmd)
+	jsonschemaCmd.AddCommand(jsonschemaInferCmd, jsonschemaValidateCmd)
+
+	for _, cmd := range []*cobra.Command{jsonschemaInferCmd, jsonschemaValidateCmd} {
+		cmd.Flags().StringVar(&jsonschemaFrom, "from", "", "format of the documents (detected when omitted)")
+		_ = cmd.RegisterFlagCompletionFunc("from", completeFormats)
+	}
+
+	jsonschemaValidateCmd.Flags().StringVar(&jsonschemaSchema, "schema", "", "schema file (JSON or YAML)")
+	_ = jsonschemaValidateCmd.MarkFlagRequired("schema")
+	_ = jsonschemaValidateCmd.MarkFlagFilename("schema", "json", "yaml", "yml")
+
+	addFileArgsFlag(jsonschemaInferCmd)
+	addFileArgsFlag(jsonschemaValidateCmd)
+}
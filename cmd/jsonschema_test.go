@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func resetJSONSchemaFlags() {
+	jsonschemaFrom = ""
+	jsonschemaSchema = ""
+	// Cobra only enforces required flags that were not set by an earlier
+	// Execute on the same command.
+	jsonschemaValidateCmd.Flags().Lookup("schema").Changed = false
+}
+
+func TestJSONSchemaInferCmd(t *testing.T) {
+	resetJSONSchemaFlags()
+	defer resetJSONSchemaFlags()
+
+	first := writeTestFile(t, "a.json", `{"id":1,"name":"a"}`)
+	second := writeTestFile(t, "b.yaml", "id: 2\n")
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"jsonschema", "infer", first, second})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("infer failed: %v", err)
+	}
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer"
+    },
+    "name": {
+      "type": "string"
+    }
+  },
+  "required": [
+    "id"
+  ]
+}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("infer output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSONSchemaInferCmdJSONLines(t *testing.T) {
+	resetJSONSchemaFlags()
+	defer resetJSONSchemaFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("{\"a\":1}\n{\"a\":1.5,\"b\":true}\n"))
+	cmd.SetArgs([]string{"jsonschema", "infer", "--from", "jsonl"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("infer failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"type": "number"`) || !strings.Contains(buf.String(), "\"required\": [\n    \"a\"\n  ]") {
+		t.Fatalf("unexpected schema:\n%s", buf.String())
+	}
+}
+
+func TestJSONSchemaValidateCmd(t *testing.T) {
+	schema := writeTestFile(t, "config.schema.yaml", `type: object
+required: [name]
+properties:
+  name:
+    type: string
+  port:
+    type: integer
+    maximum: 65535
+`)
+	valid := writeTestFile(t, "valid.json", `{"name":"api","port":80}`)
+	invalid := writeTestFile(t, "invalid.yaml", "port: 70000\n")
+
+	tests := []struct {
+		name        string
+		args        []string
+		input       string
+		want        string
+		wantInvalid bool
+		wantErr     string
+	}{
+		{
+			name: "valid document",
+			args: []string{"jsonschema", "validate", "--schema", schema, valid},
+		},
+		{
+			name:        "violations with positions",
+			args:        []string{"jsonschema", "validate", "--schema", schema, valid, invalid},
+			want:        invalid + ":1:7: /port: must be <= 65535 (maximum)\n" + invalid + ":1:1: /: missing required property \"name\" (required)\n",
+			wantInvalid: true,
+		},
+		{
+			name:        "json lines from stdin",
+			args:        []string{"jsonschema", "validate", "--schema", schema, "--from", "jsonl"},
+			input:       "{\"name\":\"a\"}\n{\"name\":2}\n",
+			want:        "<stdin>:2:9: /name: must be string, got integer (type)\n",
+			wantInvalid: true,
+		},
+		{
+			name:    "missing schema flag",
+			args:    []string{"jsonschema", "validate", valid},
+			wantErr: "schema",
+		},
+		{
+			name:    "invalid schema",
+			args:    []string{"jsonschema", "validate", "--schema", writeTestFile(t, "bad.json", `{"type":"text"}`), valid},
+			wantErr: "invalid schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetJSONSchemaFlags()
+			defer resetJSONSchemaFlags()
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if tt.wantInvalid != errors.Is(err, errSilent) {
+				t.Fatalf("validate error = %v, want invalid: %v", err, tt.wantInvalid)
+			}
+			if !tt.wantInvalid && err != nil {
+				t.Fatalf("validate failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("validate output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,12 +11,20 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var jsonstructLang string
+
 var jsonstructCmd = &cobra.Command{
 	Use:   "jsonstruct [string or file]",
-	Short: "Convert JSON to Go struct",
-	Long: `Convert JSON input into a Go struct definition.
+	Short: "Convert JSON to Go structs or other type definitions",
+	Long: `Convert JSON input into a Go struct definition, or into types for another
+language with --lang.
+
+Input can be a string argument or piped from stdin. Every document in the
+input is treated as a sample: properties missing from some objects become
+optional and properties that were null become nullable.
 
-Input can be a string argument or piped from stdin.`,
+Supported languages: go, typescript (ts), zod, rust (rs), python (py),
+pydantic, kotlin (kt) and jsonschema.`,
 	Example: `  # Convert JSON from stdin
   devtui jsonstruct < data.json
   cat data.json | devtui jsonstruct
@@ -25,9 +33,18 @@ Input can be a string argument or piped from stdin.`,
   devtui jsonstruct '{"name":"Alice","age":30}'
 
   # Output to file
-  devtui jsonstruct < input.json > struct.go`,
+  devtui jsonstruct < input.json > struct.go
+
+  # Generate TypeScript interfaces or Pydantic models
+  devtui jsonstruct --lang ts < data.json
+  devtui jsonstruct --lang pydantic < data.json > models.py`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		lang, err := structgen.ParseLanguage(jsonstructLang)
+		if err != nil {
+			return err
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return fmt.Errorf("error reading from stdin: %w", err)
@@ -37,7 +54,7 @@ Input can be a string argument or piped from stdin.`,
 		}
 
 		inputStr := string(data)
-		result, err := structgen.JSONToGoStruct(strings.NewReader(inputStr))
+		result, err := structgen.JSONToStruct(strings.NewReader(inputStr), lang)
 		if err != nil {
 			return cmderror.FormatParseError("jsonstruct", inputStr, err)
 		}
@@ -53,4 +70,17 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(jsonstructCmd)
+	addFileFlag(jsonstructCmd)
+	jsonstructCmd.Flags().StringVarP(&jsonstructLang, "lang", "l", string(structgen.LanguageGo), "output language (go, typescript, zod, rust, python, pydantic, kotlin, jsonschema)")
+	_ = jsonstructCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
+}
+
+// completeLanguages completes the --lang flag of the struct generators.
+func completeLanguages(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	languages := structgen.Languages()
+	names := make([]string, len(languages))
+	for i, lang := range languages {
+		names[i] = string(lang)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
 }
@@ -43,3 +43,38 @@ func TestJSONStructCmdNoInput(t *testing.T) {
 		t.Fatal("jsonstruct command should return error when no input provided")
 	}
 }
+
+func TestJSONStructCmdLang(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		want    string
+		wantErr bool
+	}{
+		{name: "typescript", lang: "ts", want: "export interface Root {"},
+		{name: "rust", lang: "rust", want: "pub struct Root {"},
+		{name: "pydantic", lang: "pydantic", want: "class Root(BaseModel):"},
+		{name: "unknown", lang: "cobol", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { jsonstructLang = "go" })
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(`{"name":"Alice","age":30}`))
+			cmd.SetArgs([]string{"jsonstruct", "--lang", tt.lang})
+
+			err := cmd.Execute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output missing %q: %s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/jwt"
+	"github.com/skatkov/devtui/internal/ui"
+	js "github.com/skatkov/devtui/tui/json"
+	jwttui "github.com/skatkov/devtui/tui/jwt"
+	"github.com/spf13/cobra"
+)
+
+type jwtJSON struct {
+	Header      map[string]any `json:"header"`
+	Payload     map[string]any `json:"payload"`
+	Signature   string         `json:"signature"`
+	Claims      []jwt.Claim    `json:"claims"`
+	Status      string         `json:"status"`
+	Verified    *bool          `json:"verified,omitempty"`
+	VerifyError string         `json:"verify_error,omitempty"`
+}
+
+var jwtCmd = &cobra.Command{
+	Use:   "jwt [token]",
+	Short: "Decode and verify JSON Web Tokens",
+	Long: `Decode a JSON Web Token and explain its contents.
+
+The header and payload are base64url-decoded and pretty-printed. Registered claims
+are explained, with exp/nbf/iat shown as dates and an expired/not-yet-valid status.
+
+Pass --secret (HS256/384/512) or --key with a PEM public key, certificate or JWKS
+file (RS*, PS*, ES*, EdDSA) to verify the signature. The command fails when
+verification fails. Input can be a token argument or piped from stdin.`,
+	Example: `  # Decode a token
+  devtui jwt eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjMifQ.signature
+
+  # Decode a token from stdin
+  echo "$TOKEN" | devtui jwt
+
+  # Verify an HMAC signature
+  devtui jwt --secret "your-256-bit-secret" "$TOKEN"
+
+  # Verify with a PEM public key or a JWKS file
+  devtui jwt --key public.pem "$TOKEN"
+  devtui jwt --key jwks.json "$TOKEN"
+
+  # Output as JSON
+  devtui jwt --json "$TOKEN"
+
+  # Show results in interactive TUI
+  devtui jwt --tui "$TOKEN"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputStr, err := input.ReadFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		if strings.TrimSpace(inputStr) == "" {
+			return errors.New("no input provided. pipe a JWT to this command")
+		}
+
+		if flagTUI {
+			model := jwttui.NewJWTModel(&ui.CommonModel{})
+			if err := model.SetContent(inputStr); err != nil {
+				return err
+			}
+			p := tea.NewProgram(model)
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		token, err := jwt.Decode(inputStr)
+		if err != nil {
+			return err
+		}
+
+		var verified *bool
+		var verifyErr error
+		if jwtSecret != "" || jwtKeyFile != "" {
+			verifyErr = verifyJWT(token)
+			ok := verifyErr == nil
+			verified = &ok
+		}
+
+		now := time.Now()
+		if jwtJSONOutput {
+			payload := jwtJSON{
+				Header:    token.Header,
+				Payload:   token.Payload,
+				Signature: strings.Split(token.Raw, ".")[2],
+				Claims:    token.Claims(now),
+				Status:    token.Status(now),
+				Verified:  verified,
+			}
+			if verifyErr != nil {
+				payload.VerifyError = verifyErr.Error()
+			}
+			bytes, err := json.MarshalIndent(payload, "", "  ")
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bytes)); err != nil {
+				return err
+			}
+			return verifyErr
+		}
+
+		var b strings.Builder
+		fmt.Fprintln(&b, "Header:")
+		fmt.Fprint(&b, js.FormatJSON(token.HeaderJSON))
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "Payload:")
+		fmt.Fprint(&b, js.FormatJSON(token.PayloadJSON))
+
+		if claims := token.Claims(now); len(claims) > 0 {
+			fmt.Fprintln(&b)
+			fmt.Fprintln(&b, "Claims:")
+			claimsTable := table.New().Border(lipgloss.NormalBorder())
+			for _, claim := range claims {
+				claimsTable.Row(claim.Name, claim.Description, claim.Value, claim.Status)
+			}
+			fmt.Fprintln(&b, claimsTable.String())
+		}
+
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "Status: %s\n", token.Status(now))
+		switch {
+		case verified == nil:
+			fmt.Fprintf(&b, "Signature: %s, not verified (use --secret or --key)\n", token.Algorithm())
+		case *verified:
+			fmt.Fprintf(&b, "Signature: %s, verified\n", token.Algorithm())
+		default:
+			fmt.Fprintf(&b, "Signature: %s, INVALID\n", token.Algorithm())
+		}
+
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), b.String()); err != nil {
+			return err
+		}
+		return verifyErr
+	},
+}
+
+var (
+	jwtSecret     string
+	jwtKeyFile    string
+	jwtJSONOutput bool
+)
+
+func init() {
+	rootCmd.AddCommand(jwtCmd)
+	addFileFlag(jwtCmd)
+	jwtCmd.Flags().StringVarP(&jwtSecret, "secret", "s", "", "shared secret for HS256/HS384/HS512 verification")
+	jwtCmd.Flags().StringVarP(&jwtKeyFile, "key", "k", "", "PEM public key, certificate or JWKS file for signature verification")
+	jwtCmd.Flags().BoolVar(&jwtJSONOutput, "json", false, "output decoded token as JSON")
+	jwtCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
+	_ = jwtCmd.MarkFlagFilename("key")
+	markInteractive(jwtCmd, "tui")
+}
+
+func verifyJWT(token *jwt.Token) error {
+	if jwtSecret != "" {
+		return jwt.Verify(token, []byte(jwtSecret))
+	}
+
+	data, err := os.ReadFile(jwtKeyFile)
+	if err != nil {
+		return err
+	}
+	key, err := jwt.ParseKey(data, token)
+	if err != nil {
+		return err
+	}
+	return jwt.Verify(token, key)
+}
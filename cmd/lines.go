@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/ndjson"
+	"github.com/spf13/cobra"
+)
+
+// flagLines switches formatters and converters to JSON Lines mode, where
+// every line of input is a separate document processed on its own.
+var flagLines bool
+
+func addLinesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&flagLines, "lines", false, "treat input as JSON Lines and process one record at a time")
+}
+
+// transformLines streams the input record by record through fn, writing
+// separator between the results.
+func transformLines(cmd *cobra.Command, args []string, separator string, fn func(record string) (string, error)) error {
+	count, err := ndjson.Transform(input.ReaderFromArgsOrStdin(cmd, args), cmd.OutOrStdout(), separator, fn)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("no input provided. Pipe JSON Lines input to this command")
+	}
+	return nil
+}
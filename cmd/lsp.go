@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/lsp"
+	"github.com/skatkov/devtui/internal/uuidutil"
+	"github.com/spf13/cobra"
+)
+
+// lspFormatters maps LSP language IDs to the commands formatting them.
+// GraphQL comments and descriptions are kept, since a formatter in an
+// editor must not drop parts of the document.
+var lspFormatters = map[string][]string{
+	"json":    {"jsonfmt"},
+	"yaml":    {"yamlfmt"},
+	"toml":    {"tomlfmt"},
+	"xml":     {"xmlfmt"},
+	"css":     {"cssfmt"},
+	"html":    {"htmlfmt"},
+	"graphql": {"gqlquery", "--with-comments", "--with-descriptions"},
+}
+
+// lspConvertible lists the formats of selections that can be converted.
+var lspConvertible = []converter.Format{converter.FormatJSON, converter.FormatYAML, converter.FormatTOML, converter.FormatXML}
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server for formatting and conversions",
+	Long: `Run a Language Server Protocol server over stdin and stdout, for editor
+integration without per-editor plugins.
+
+The server provides:
+  - document formatting for JSON, YAML, TOML, XML, CSS, HTML and GraphQL,
+    using the same formatters and configuration as the formatting commands
+  - diagnostics for JSON, YAML, TOML, XML and GraphQL parse errors
+  - code actions on the selection: convert it to JSON, YAML or TOML, decode
+    Base64 and decode a UUID`,
+	Example: `  # Neovim
+  vim.lsp.start({ name = "devtui", cmd = { "devtui", "lsp" } })
+
+  # Helix, in languages.toml
+  [language-server.devtui]
+  command = "devtui"
+  args = ["lsp"]`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		formatters := map[string]lsp.FormatFunc{}
+		for language, command := range lspFormatters {
+			formatters[language] = func(text string) (string, error) {
+				return runLSPCommand(command, text)
+			}
+		}
+		server := lsp.NewServer(formatters, lspActions())
+		return server.Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+// lspActions returns the code actions offered for selections.
+func lspActions() []lsp.Action {
+	var actions []lsp.Action
+	for _, to := range []converter.Format{converter.FormatJSON, converter.FormatYAML, converter.FormatTOML} {
+		actions = append(actions, lsp.Action{
+			Title: "Convert selection to " + strings.ToUpper(string(to)),
+			Run: func(selection string) (string, error) {
+				from, err := converter.Detect(selection)
+				if err != nil {
+					return "", err
+				}
+				// CSV is detected from a comma on the first line, which
+				// plenty of prose has; only structured selections convert.
+				if !slices.Contains(lspConvertible, from) || from == to {
+					return "", fmt.Errorf("can't convert %s to %s", from, to)
+				}
+				return runLSPCommand([]string{"convert", "--from", string(from), "--to", string(to)}, selection)
+			},
+		})
+	}
+	actions = append(actions,
+		lsp.Action{
+			Title: "Decode base64 selection",
+			Run: func(selection string) (string, error) {
+				decoded, err := runLSPCommand([]string{"base64", "--decode"}, strings.TrimSpace(selection))
+				if err != nil {
+					return "", err
+				}
+				if decoded == "" || !utf8.ValidString(decoded) {
+					return "", errors.New("selection doesn't decode to text")
+				}
+				return decoded, nil
+			},
+		},
+		lsp.Action{
+			Title: "Decode UUID",
+			Show:  true,
+			Run: func(selection string) (string, error) {
+				id, err := uuid.Parse(strings.TrimSpace(selection))
+				if err != nil {
+					return "", err
+				}
+				var lines []string
+				for _, row := range uuidutil.FieldsToRows(uuidutil.Decode(id)) {
+					lines = append(lines, strings.Join(row, ": "))
+				}
+				return strings.Join(lines, "\n"), nil
+			},
+		},
+	)
+	return actions
+}
+
+// runLSPCommand runs a devtui command on text, keeping text's trailing
+// newline, or its absence, so that edits don't add or remove lines at the
+// end of a selection.
+func runLSPCommand(args []string, text string) (string, error) {
+	output, err := execCommand(args, []byte(text))
+	if err != nil {
+		return "", err
+	}
+	output = bytes.TrimRight(output, "\n")
+	if strings.HasSuffix(text, "\n") {
+		output = append(output, '\n')
+	}
+	return string(output), nil
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+	// Editors commonly start language servers with --stdio, the only
+	// transport there is.
+	lspCmd.Flags().Bool("stdio", true, "communicate over stdin and stdout")
+	_ = lspCmd.Flags().MarkHidden("stdio")
+}
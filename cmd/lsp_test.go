@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func lspRequest(t *testing.T, w io.Writer, msg map[string]any) {
+	t.Helper()
+
+	msg["jsonrpc"] = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLSP(t *testing.T) {
+	useConfig(t, "")
+
+	var in bytes.Buffer
+	lspRequest(t, &in, map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}})
+	lspRequest(t, &in, map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+		"textDocument": map[string]any{"uri": "file:///a.yaml", "languageId": "yaml", "version": 1, "text": "a:   1\nb: [x,   y]\n"},
+	}})
+	lspRequest(t, &in, map[string]any{"id": 2, "method": "textDocument/formatting", "params": map[string]any{
+		"textDocument": map[string]any{"uri": "file:///a.yaml"},
+	}})
+	lspRequest(t, &in, map[string]any{"id": 3, "method": "textDocument/codeAction", "params": map[string]any{
+		"textDocument": map[string]any{"uri": "file:///a.yaml"},
+		"range":        map[string]any{"start": map[string]any{"line": 0, "character": 0}, "end": map[string]any{"line": 2, "character": 0}},
+		"context":      map[string]any{"diagnostics": []any{}},
+	}})
+	lspRequest(t, &in, map[string]any{"id": 4, "method": "shutdown"})
+	lspRequest(t, &in, map[string]any{"method": "exit"})
+
+	cmd := GetRootCmd()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(&in)
+	cmd.SetArgs([]string{"lsp", "--stdio"})
+	t.Cleanup(func() {
+		cmd.SetIn(nil)
+		cmd.SetOut(nil)
+		cmd.SetErr(nil)
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	responses := map[float64]map[string]any{}
+	reader := bufio.NewReader(out)
+	for {
+		header, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err != nil {
+			break
+		}
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			t.Fatal(err)
+		}
+		var msg map[string]any
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if id, ok := msg["id"].(float64); ok {
+			responses[id] = msg
+		}
+	}
+
+	edits, _ := responses[2]["result"].([]any)
+	if len(edits) != 1 {
+		t.Fatalf("formatting response = %v", responses[2])
+	}
+	if got := edits[0].(map[string]any)["newText"]; got != "a: 1\nb:\n    - x\n    - \"y\"\n" {
+		t.Errorf("formatted = %q", got)
+	}
+
+	actions, _ := responses[3]["result"].([]any)
+	var titles []string
+	for _, action := range actions {
+		titles = append(titles, action.(map[string]any)["title"].(string))
+	}
+	if got := strings.Join(titles, ", "); got != "Convert selection to JSON, Convert selection to TOML" {
+		t.Errorf("code actions = %s", got)
+	}
+}
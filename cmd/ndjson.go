@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/ndjson"
+	"github.com/skatkov/devtui/internal/ui"
+	ndjsontui "github.com/skatkov/devtui/tui/ndjson"
+	"github.com/spf13/cobra"
+)
+
+var ndjsonCmd = &cobra.Command{
+	Use:   "ndjson [file]",
+	Short: "Browse an NDJSON (JSON Lines) file record by record",
+	Long: `Open an NDJSON (JSON Lines) file in a pager that shows one formatted record
+at a time. Records are read on demand, so files of hundreds of megabytes open
+quickly and use little memory.
+
+When no file is given, piped stdin is buffered to a temporary file first.`,
+	Example: `  # Browse a log file
+  devtui ndjson app.ndjson
+
+  # Browse the output of another command
+  kubectl logs deploy/api | devtui ndjson`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		} else {
+			tmp, err := spoolStdin(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = os.Remove(tmp) }()
+			path = tmp
+		}
+
+		index, err := ndjson.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = index.Close() }()
+		if index.Len() == 0 {
+			return errors.New("no records found")
+		}
+
+		model := ndjsontui.NewNDJSONModel(&ui.CommonModel{})
+		if err := model.SetIndex(index); err != nil {
+			return err
+		}
+		p := tea.NewProgram(model)
+		if _, err := p.Run(); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+// spoolStdin copies piped input to a temporary file so it can be indexed and
+// read at random offsets.
+func spoolStdin(r io.Reader) (string, error) {
+	if f, ok := r.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return "", errors.New("no input provided. Pass a file or pipe JSON Lines to this command")
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "devtui-*.ndjson")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("error reading from stdin: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+func init() {
+	rootCmd.AddCommand(ndjsonCmd)
+	markInteractive(ndjsonCmd)
+	addFileArgsFlag(ndjsonCmd)
+}
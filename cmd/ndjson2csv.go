@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/spf13/cobra"
+)
+
+var ndjson2csvCmd = &cobra.Command{
+	Use:   "ndjson2csv [string or file]",
+	Short: "Convert NDJSON (JSON Lines) to CSV",
+	Long: `Convert NDJSON (JSON Lines) records to CSV rows, one record at a time.
+
+Nested values are flattened into "user.name" and "tags[0]" style columns.
+The header is taken from the first record unless --columns is given; since
+records are streamed, later records may not introduce new columns.
+
+Input can be a string argument or piped from stdin.`,
+	Example: `  # Convert a JSON Lines log to CSV
+  devtui ndjson2csv < events.ndjson > events.csv
+
+  # Choose and order the columns explicitly
+  devtui ndjson2csv --columns time,level,msg < app.ndjson`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return converter.JSONLinesToDelimited(input.ReaderFromArgsOrStdin(cmd, args), cmd.OutOrStdout(), ',', ndjson2csvColumns)
+	},
+}
+
+var ndjson2csvColumns []string
+
+func init() {
+	rootCmd.AddCommand(ndjson2csvCmd)
+	addFileFlag(ndjson2csvCmd)
+
+	ndjson2csvCmd.Flags().StringSliceVar(&ndjson2csvColumns, "columns", nil, "comma-separated list of columns to write")
+}
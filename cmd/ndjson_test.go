@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resetNDJSONFlags() {
+	flagLines = false
+	flagTUI = false
+	ndjson2csvColumns = nil
+}
+
+func TestJSONLinesCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "jsonfmt lines",
+			args:  []string{"jsonfmt", "--lines"},
+			input: "{\"a\": 1}\n\n{ \"b\":[1, 2], \"c\": null }\n",
+			want:  "{\"a\":1}\n{\"b\":[1,2],\"c\":null}\n",
+		},
+		{
+			name:    "jsonfmt lines reports the failing line",
+			args:    []string{"jsonfmt", "--lines"},
+			input:   "{\"a\":1}\n{\"b\":\n",
+			wantErr: "line 2:",
+		},
+		{
+			name:  "json2yaml lines",
+			args:  []string{"json2yaml", "--lines"},
+			input: "{\"z\":1,\"a\":2}\n{\"z\":3}\n",
+			want:  "z: 1\na: 2\n---\nz: 3\n",
+		},
+		{
+			name:  "json2toml lines",
+			args:  []string{"json2toml", "--lines"},
+			input: "{\"name\":\"a\"}\n{\"name\":\"b\"}\n",
+			want:  "name = \"a\"\n\nname = \"b\"\n",
+		},
+		{
+			name:    "json2yaml lines reports the failing line",
+			args:    []string{"json2yaml", "--lines"},
+			input:   "{\"a\":1}\n{broken\n",
+			wantErr: "line 2:",
+		},
+		{
+			name:    "lines without input",
+			args:    []string{"json2xml", "--lines"},
+			input:   "",
+			wantErr: "no input provided",
+		},
+		{
+			name:  "ndjson2csv",
+			args:  []string{"ndjson2csv"},
+			input: "{\"id\":1,\"user\":{\"name\":\"Alice\"}}\n{\"id\":2,\"user\":{\"name\":\"Bob\"}}\n",
+			want:  "id,user.name\n1,Alice\n2,Bob\n",
+		},
+		{
+			name:  "ndjson2csv columns",
+			args:  []string{"ndjson2csv", "--columns", "user.name,id"},
+			input: "{\"id\":1,\"user\":{\"name\":\"Alice\"}}\n",
+			want:  "user.name,id\nAlice,1\n",
+		},
+		{
+			name:  "csv2ndjson",
+			args:  []string{"csv2ndjson"},
+			input: "id,name\n1,Alice\n2,Bob\n",
+			want:  "{\"id\":\"1\",\"name\":\"Alice\"}\n{\"id\":\"2\",\"name\":\"Bob\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetNDJSONFlags()
+			defer resetNDJSONFlags()
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("command failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
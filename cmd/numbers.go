@@ -72,6 +72,7 @@ var (
 
 func init() {
 	rootCmd.AddCommand(numbersCmd)
+	addFileFlag(numbersCmd)
 	numbersCmd.Flags().IntVarP(&numbersBase, "base", "b", 10, "input number base (2, 8, 10, 16)")
 	numbersCmd.Flags().BoolVar(&numbersJSONOutput, "json", false, "output conversions as JSON")
 }
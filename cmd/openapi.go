@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// localOnlyAnnotation marks flags that the server doesn't accept, since
+// they write local files. Flags naming files to read are refused too; they
+// are marked with cobra.MarkFlagFilename, and flags starting a TUI with
+// markInteractive.
+const localOnlyAnnotation = "devtui_local_only"
+
+// markLocalOnly keeps the named flags of cmd from being served.
+func markLocalOnly(cmd *cobra.Command, names ...string) {
+	for _, name := range names {
+		_ = cmd.Flags().SetAnnotation(name, localOnlyAnnotation, []string{"true"})
+	}
+}
+
+// servable reports whether the server may accept flag.
+func servable(flag *pflag.Flag) bool {
+	_, local := flag.Annotations[localOnlyAnnotation]
+	_, path := flag.Annotations[cobra.BashCompFilenameExt]
+	return !flag.Hidden && !local && !path && !interactiveFlag(flag) && flag.Name != "help"
+}
+
+// argPlaceholder matches the required arguments in a command's usage line,
+// such as <expression> in "query <expression> [string or file]".
+var argPlaceholder = regexp.MustCompile(`<([a-z][a-z0-9-]*)>`)
+
+type openAPIDocument struct {
+	OpenAPI    string                            `json:"openapi"`
+	Info       openAPIInfo                       `json:"info"`
+	Servers    []openAPIServer                   `json:"servers,omitempty"`
+	Paths      map[string]map[string]openAPIOp   `json:"paths"`
+	Components map[string]map[string]openAPISpec `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOp struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description,omitempty"`
+	Parameters  []openAPIParam             `json:"parameters,omitempty"`
+	RequestBody *openAPIBody               `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParam struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      openAPISpec `json:"schema"`
+}
+
+type openAPIBody struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema openAPISpec `json:"schema"`
+}
+
+// openAPISpec is a JSON schema, or a reference to one with Ref.
+type openAPISpec struct {
+	Ref        string                 `json:"$ref,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Items      *openAPISpec           `json:"items,omitempty"`
+	Default    any                    `json:"default,omitempty"`
+	Properties map[string]openAPISpec `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+}
+
+// servedCommands returns the commands the server exposes, in the order of
+// the command tree. Commands that need local files, such as the documents
+// of diff or the schema of jsonschema validate, are left out.
+func servedCommands() []*cobra.Command {
+	var commands []*cobra.Command
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Hidden {
+			return
+		}
+		if embeddable(cmd) && !needsFiles(cmd) {
+			commands = append(commands, cmd)
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(rootCmd)
+	return commands
+}
+
+// servedPath returns the URL path of cmd, such as "/jsonschema/validate".
+func servedPath(cmd *cobra.Command) string {
+	return "/" + strings.ReplaceAll(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "), " ", "/")
+}
+
+// servedArgs returns the names of the required arguments of cmd, which are
+// given as query parameters.
+func servedArgs(cmd *cobra.Command) []string {
+	var names []string
+	for _, match := range argPlaceholder.FindAllStringSubmatch(cmd.Use, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// needsFiles reports whether cmd requires a file argument or a required
+// flag that requests can't give.
+func needsFiles(cmd *cobra.Command) bool {
+	if slices.Contains(servedArgs(cmd), "file") {
+		return true
+	}
+	needs := false
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if _, required := flag.Annotations[cobra.BashCompOneRequiredFlag]; required && !servable(flag) {
+			needs = true
+		}
+	})
+	return needs
+}
+
+// servedFlags returns the flags of cmd given as query parameters.
+func servedFlags(cmd *cobra.Command) []*pflag.Flag {
+	var flags []*pflag.Flag
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if servable(flag) {
+			flags = append(flags, flag)
+		}
+	})
+	return flags
+}
+
+// openAPI describes the served commands as an OpenAPI document.
+func openAPI(serverURL string) openAPIDocument {
+	doc := openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title: "devtui",
+			Description: "Every devtui command as an endpoint: POST the input as the request body, " +
+				"give flags and arguments as query parameters and get the output back.",
+			Version: version,
+		},
+		Paths: map[string]map[string]openAPIOp{},
+		Components: map[string]map[string]openAPISpec{
+			"schemas": {
+				"Output": {
+					Type:       "object",
+					Properties: map[string]openAPISpec{"output": {Type: "string"}},
+					Required:   []string{"output"},
+				},
+				"Error": {
+					Type: "object",
+					Properties: map[string]openAPISpec{
+						"error":  {Type: "string"},
+						"output": {Type: "string"},
+					},
+					Required: []string{"error"},
+				},
+			},
+		},
+	}
+	if serverURL != "" {
+		doc.Servers = []openAPIServer{{URL: serverURL}}
+	}
+
+	errorResponse := func(description string) openAPIResponse {
+		return openAPIResponse{
+			Description: description,
+			Content: map[string]openAPIMediaType{
+				"application/json": {Schema: openAPISpec{Ref: "#/components/schemas/Error"}},
+			},
+		}
+	}
+
+	for _, cmd := range servedCommands() {
+		op := openAPIOp{
+			OperationID: strings.ReplaceAll(strings.TrimPrefix(servedPath(cmd), "/"), "/", "-"),
+			Summary:     cmd.Short,
+			Description: cmd.Long,
+			RequestBody: &openAPIBody{
+				Description: "The input of the command, as it would be piped to it.",
+				Content: map[string]openAPIMediaType{
+					"text/plain": {Schema: openAPISpec{Type: "string"}},
+				},
+			},
+			Responses: map[string]openAPIResponse{
+				"200": {
+					Description: "The output of the command. Ask for application/json to get it wrapped in an object.",
+					Content: map[string]openAPIMediaType{
+						"text/plain":       {Schema: openAPISpec{Type: "string"}},
+						"application/json": {Schema: openAPISpec{Ref: "#/components/schemas/Output"}},
+					},
+				},
+				"400": errorResponse("The command failed or the parameters are invalid."),
+				"413": errorResponse("The request body is too large."),
+			},
+		}
+		for _, name := range servedArgs(cmd) {
+			op.Parameters = append(op.Parameters, openAPIParam{
+				Name:     name,
+				In:       "query",
+				Required: true,
+				Schema:   openAPISpec{Type: "string"},
+			})
+		}
+		for _, flag := range servedFlags(cmd) {
+			op.Parameters = append(op.Parameters, openAPIParam{
+				Name:        flag.Name,
+				In:          "query",
+				Description: flag.Usage,
+				Schema:      flagSchema(flag),
+			})
+		}
+		doc.Paths[servedPath(cmd)] = map[string]openAPIOp{"post": op}
+	}
+	return doc
+}
+
+// flagSchema returns the schema of a flag's values, with its default.
+func flagSchema(flag *pflag.Flag) openAPISpec {
+	switch typ := flag.Value.Type(); {
+	case typ == "bool":
+		value, _ := strconv.ParseBool(flag.DefValue)
+		return openAPISpec{Type: "boolean", Default: value}
+	case strings.HasPrefix(typ, "int") || strings.HasPrefix(typ, "uint"):
+		value, err := strconv.ParseInt(flag.DefValue, 10, 64)
+		if err != nil {
+			return openAPISpec{Type: "integer"}
+		}
+		return openAPISpec{Type: "integer", Default: value}
+	case strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array"):
+		return openAPISpec{Type: "array", Items: &openAPISpec{Type: "string"}}
+	default:
+		spec := openAPISpec{Type: "string"}
+		if flag.DefValue != "" {
+			spec.Default = flag.DefValue
+		}
+		return spec
+	}
+}
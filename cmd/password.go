@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/password"
+	"github.com/spf13/cobra"
+)
+
+type passwordJSON struct {
+	Password string            `json:"password"`
+	Entropy  float64           `json:"entropy_bits"`
+	Strength password.Strength `json:"strength"`
+}
+
+var passwordCmd = &cobra.Command{
+	Use:     "password",
+	Aliases: []string{"pwgen"},
+	Short:   "Generate passwords and passphrases",
+	Long: `Generate random passwords, or diceware passphrases from the bundled EFF large
+wordlist.
+
+Passwords include at least one character of every selected set: lowercase,
+uppercase, numbers and special. Passphrases join random words, each adding about
+12.9 bits of entropy.
+
+Passwords are printed one per line; the entropy and strength estimate they share
+is printed to stderr, or included with every result with --json. Use
+"devtui password check" to estimate the strength of an existing password.`,
+	Example: `  # Generate a 16 character password
+  devtui password
+
+  # Five 24 character passwords of letters and numbers, without ambiguous characters
+  devtui password --length 24 --sets lowercase,uppercase,numbers --exclude-ambiguous --count 5
+
+  # Generate a passphrase of 6 words
+  devtui password --passphrase
+
+  # Capitalized passphrase of 5 words separated by dots, as JSON
+  devtui password --passphrase --words 5 --separator . --capitalize --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if passwordCount < 1 {
+			return errors.New("--count must be at least 1")
+		}
+
+		opts := password.Options{
+			Length:           passwordLength,
+			Sets:             passwordSets,
+			ExcludeAmbiguous: passwordExcludeAmbiguous,
+		}
+		phraseOpts := password.PassphraseOptions{
+			Words:      passwordWords,
+			Separator:  passwordSeparator,
+			Capitalize: passwordCapitalize,
+		}
+		generate := func() (string, error) { return password.Generate(opts) }
+		entropy := opts.Entropy()
+		if passwordPassphrase {
+			generate = func() (string, error) { return password.GeneratePassphrase(phraseOpts) }
+			entropy = phraseOpts.Entropy()
+		}
+
+		results := make([]string, passwordCount)
+		for i := range results {
+			result, err := generate()
+			if err != nil {
+				return err
+			}
+			results[i] = result
+		}
+
+		entropy = math.Round(entropy*10) / 10
+		strength := password.StrengthOf(entropy)
+		if passwordJSONOutput {
+			payload := make([]passwordJSON, len(results))
+			for i, result := range results {
+				payload[i] = passwordJSON{Password: result, Entropy: entropy, Strength: strength}
+			}
+			bytes, err := json.MarshalIndent(payload, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+			return err
+		}
+
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%.1f bits of entropy (%s)\n", entropy, strength)
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), strings.Join(results, "\n"))
+		return err
+	},
+}
+
+var passwordCheckCmd = &cobra.Command{
+	Use:   "check [password]",
+	Short: "Estimate how hard a password is to guess",
+	Long: `Estimate how hard a password is to guess, offline and in the manner of
+zxcvbn.
+
+The password is split into the patterns that are cheapest to guess: common
+passwords and dictionary words (also reversed, capitalized or with l33t
+substitutions), keyboard walks, repeats, sequences, dates and random
+characters. The report lists them with the resulting guesses, strength, crack
+times in four attack scenarios and suggestions.
+
+The password is read from the argument or stdin; only a trailing newline is
+removed. With --min-strength, the command fails when the password is weaker,
+which suits scripts and hooks.`,
+	Example: `  # Check a password
+  devtui password check 'Tr0ub4dour&3'
+
+  # Check a password from stdin, as JSON
+  echo -n "$SECRET" | devtui password check --json
+
+  # Fail unless the password is at least strong
+  devtui password check --min-strength strong "$NEW_PASSWORD"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var minStrength password.Strength
+		if err := minStrength.UnmarshalText([]byte(passwordCheckMinStrength)); err != nil {
+			return err
+		}
+
+		inputStr, err := input.ReadFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		// Leading and trailing spaces are part of the password.
+		secret := strings.TrimSuffix(strings.TrimSuffix(inputStr, "\n"), "\r")
+
+		report := password.Check(secret)
+		if passwordJSONOutput {
+			bytes, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), string(bytes)); err != nil {
+				return err
+			}
+		} else if _, err := fmt.Fprintln(cmd.OutOrStdout(), checkReportTables(report)); err != nil {
+			return err
+		}
+
+		if report.Strength < minStrength {
+			return fmt.Errorf("password is %s, at least %s is required", report.Strength, minStrength)
+		}
+		return nil
+	},
+}
+
+// checkReportTables renders the summary, crack times and matched patterns
+// of a report, followed by its feedback.
+func checkReportTables(report password.Report) string {
+	summary := table.New().Border(lipgloss.NormalBorder()).Rows(
+		[]string{"Strength", report.Strength.String()},
+		[]string{"Length", strconv.Itoa(report.Length)},
+		[]string{"Character sets", strings.Join(report.CharacterSets, ", ")},
+		[]string{"Charset entropy", fmt.Sprintf("%.1f bits", report.CharsetEntropy)},
+		[]string{"Guesses", fmt.Sprintf("10^%.1f (%.1f bits)", report.Guesses, report.Entropy)},
+	)
+	crackTimes := table.New().Border(lipgloss.NormalBorder()).Headers("Attack", "Crack time")
+	for _, ct := range report.CrackTimes {
+		crackTimes.Row(ct.Scenario, ct.Display)
+	}
+	sections := []string{summary.String(), crackTimes.String()}
+
+	if len(report.Matches) > 0 {
+		matches := table.New().Border(lipgloss.NormalBorder()).Headers("Pattern", "Token", "Detail", "Guesses")
+		for _, m := range report.Matches {
+			matches.Row(m.Pattern, m.Token, m.Detail, fmt.Sprintf("10^%.1f", m.Guesses))
+		}
+		sections = append(sections, matches.String())
+	}
+	if len(report.Feedback) > 0 {
+		sections = append(sections, "- "+strings.Join(report.Feedback, "\n- "))
+	}
+	return strings.Join(sections, "\n")
+}
+
+var (
+	passwordCheckMinStrength string
+
+	passwordLength           int
+	passwordSets             []string
+	passwordExcludeAmbiguous bool
+	passwordCount            int
+	passwordPassphrase       bool
+	passwordWords            int
+	passwordSeparator        string
+	passwordCapitalize       bool
+	passwordJSONOutput       bool
+)
+
+func init() {
+	rootCmd.AddCommand(passwordCmd)
+	passwordCmd.AddCommand(passwordCheckCmd)
+	passwordCmd.Flags().IntVarP(&passwordLength, "length", "l", 16, "password length")
+	passwordCmd.Flags().StringSliceVarP(&passwordSets, "sets", "s", password.DefaultSets, "character sets to use (lowercase, uppercase, numbers, special)")
+	passwordCmd.Flags().BoolVar(&passwordExcludeAmbiguous, "exclude-ambiguous", false, "leave out characters that look alike ("+password.Ambiguous+")")
+	passwordCmd.Flags().IntVarP(&passwordCount, "count", "n", 1, "number of passwords to generate")
+	passwordCmd.Flags().BoolVarP(&passwordPassphrase, "passphrase", "p", false, "generate diceware passphrases instead of passwords")
+	passwordCmd.Flags().IntVarP(&passwordWords, "words", "w", 6, "number of words in a passphrase")
+	passwordCmd.Flags().StringVar(&passwordSeparator, "separator", "-", "separator between passphrase words")
+	passwordCmd.Flags().BoolVar(&passwordCapitalize, "capitalize", false, "capitalize passphrase words")
+	passwordCmd.Flags().BoolVar(&passwordJSONOutput, "json", false, "output results with their strength as JSON")
+	passwordCheckCmd.Flags().BoolVar(&passwordJSONOutput, "json", false, "output the report as JSON")
+	passwordCheckCmd.Flags().StringVar(&passwordCheckMinStrength, "min-strength", "very weak", "fail unless the password is at least this strong (very weak, weak, fair, strong, very strong)")
+}
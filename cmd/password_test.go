@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/password"
+)
+
+func resetPasswordFlags() {
+	passwordLength = 16
+	passwordSets = password.DefaultSets
+	passwordExcludeAmbiguous = false
+	passwordCount = 1
+	passwordPassphrase = false
+	passwordWords = 6
+	passwordSeparator = "-"
+	passwordCapitalize = false
+	passwordJSONOutput = false
+	passwordCheckMinStrength = "very weak"
+}
+
+func TestPasswordCmd(t *testing.T) {
+	resetPasswordFlags()
+	defer resetPasswordFlags()
+
+	cmd := GetRootCmd()
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.SetArgs([]string{"password", "--length", "20", "--sets", "lowercase,numbers", "--count", "3"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("password command failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 passwords, got %q", out.String())
+	}
+	for _, line := range lines {
+		if len(line) != 20 || strings.Trim(line, "abcdefghijklmnopqrstuvwxyz0123456789") != "" {
+			t.Errorf("unexpected password %q", line)
+		}
+	}
+	if got := errOut.String(); got != "103.4 bits of entropy (very strong)\n" {
+		t.Errorf("estimate = %q", got)
+	}
+}
+
+func TestPasswordCmdPassphraseJSON(t *testing.T) {
+	resetPasswordFlags()
+	defer resetPasswordFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"password", "--passphrase", "--words", "4", "--separator", " ", "--count", "2", "--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("password --passphrase command failed: %v", err)
+	}
+
+	var results []passwordJSON
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		t.Fatalf("password --json output invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 passphrases, got %+v", results)
+	}
+	for _, result := range results {
+		if len(strings.Fields(result.Password)) != 4 || result.Entropy != 51.7 || result.Strength != password.VeryStrong {
+			t.Errorf("unexpected result %+v", result)
+		}
+	}
+}
+
+func TestPasswordCmdInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"password", "--sets", "emoji"},
+		{"password", "--length", "2"},
+		{"password", "--count", "0"},
+		{"password", "--passphrase", "--words", "0"},
+	} {
+		resetPasswordFlags()
+
+		cmd := GetRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+	resetPasswordFlags()
+}
+
+func TestPasswordCheckCmd(t *testing.T) {
+	resetPasswordFlags()
+	defer resetPasswordFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("P@ssw0rd\n"))
+	cmd.SetArgs([]string{"password", "check", "--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("password check command failed: %v", err)
+	}
+
+	var report password.Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("password check --json output invalid JSON: %v\n%s", err, buf.String())
+	}
+	if report.Length != 8 || report.Strength != password.VeryWeak || len(report.Matches) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestPasswordCheckCmdMinStrength(t *testing.T) {
+	for _, tt := range []struct {
+		password string
+		wantErr  bool
+	}{
+		{password: "qwerty", wantErr: true},
+		{password: "x7$Kp!2mQz#9", wantErr: false},
+	} {
+		resetPasswordFlags()
+
+		cmd := GetRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs([]string{"password", "check", "--min-strength", "strong", tt.password})
+		err := cmd.Execute()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+		if !strings.Contains(buf.String(), "Crack time") {
+			t.Errorf("%q: report missing from output:\n%s", tt.password, buf.String())
+		}
+	}
+	resetPasswordFlags()
+}
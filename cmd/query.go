@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/cmderror"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/query"
+	"github.com/skatkov/devtui/internal/ui"
+	querytui "github.com/skatkov/devtui/tui/query"
+	"github.com/spf13/cobra"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query <expression> [string or file]",
+	Short: "Query JSON, YAML, TOML, XML and more with jq or JSONPath expressions",
+	Long: `Evaluate a jq-style or JSONPath expression against structured data.
+
+Expressions starting with "$" are JSONPath, for example
+"$.store.book[?(@.price < 10)].title". Anything else is a subset of jq:
+paths (.a.b, .[0], .[], .[1:3]), pipes, comma, comparison and arithmetic
+operators, "//", if/then/elif/else/end, array and object construction and the
+builtins map, select, keys, keys_unsorted, length, has, type, sort, sort_by,
+unique, reverse, first, last, min, max, add, any, all, flatten, to_entries,
+from_entries, with_entries, tostring, tonumber, join, split, startswith,
+endswith, contains, test, ascii_downcase, ascii_upcase, values, recurse, not
+and empty.
+
+The input may be in any format supported by convert and is detected
+automatically unless --from is given. Results are written as JSON, one
+document per result, or in the format chosen with --to.`,
+	Example: `  # Extract a field
+  devtui query '.name' < package.json
+
+  # Filter and project with jq syntax
+  devtui query '.items[] | select(.price > 10) | {name, price}' < items.yaml
+
+  # Use JSONPath and print plain strings
+  devtui query -r '$.servers[*].host' < config.toml
+
+  # Emit the result as YAML
+  devtui query '.dependencies' --to yaml < package.json
+
+  # Explore interactively
+  devtui query --tui '.' < data.json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		expr := args[0]
+		q, err := query.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args[1:])
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		if len(data) == 0 {
+			return errors.New("no input provided. pipe input to this command")
+		}
+		inputStr := string(data)
+
+		if flagTUI {
+			model := querytui.NewQueryModel(&ui.CommonModel{})
+			model.SetExpression(expr)
+			if err := model.SetContent(inputStr); err != nil {
+				return err
+			}
+			p := tea.NewProgram(model)
+			if _, err := p.Run(); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		var from converter.Format
+		if queryFrom == "" {
+			from, err = converter.Detect(inputStr)
+			if err != nil {
+				return fmt.Errorf("%w; specify it with --from", err)
+			}
+		} else {
+			codec, err := converter.Lookup(queryFrom)
+			if err != nil {
+				return err
+			}
+			from = codec.Format
+		}
+		to, err := converter.Lookup(queryTo)
+		if err != nil {
+			return err
+		}
+
+		value, err := converter.Decode(inputStr, from)
+		if err != nil {
+			return cmderror.FormatParseError("query", inputStr, err)
+		}
+		results, err := q.Run(value)
+		if err != nil {
+			return err
+		}
+
+		out, err := query.Format(results, to.Format, query.OutputOptions{Raw: queryRaw, Compact: queryCompact})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+		return err
+	},
+}
+
+var (
+	queryFrom    string
+	queryTo      string
+	queryRaw     bool
+	queryCompact bool
+)
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	addFileFlagAfterArgs(queryCmd, 1)
+
+	queryCmd.Flags().StringVar(&queryFrom, "from", "", "input format (detected from content when omitted)")
+	queryCmd.Flags().StringVar(&queryTo, "to", "json", "output format")
+	queryCmd.Flags().BoolVarP(&queryRaw, "raw", "r", false, "write string results without quotes")
+	queryCmd.Flags().BoolVarP(&queryCompact, "compact", "c", false, "write each JSON result on a single line")
+	queryCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Edit the expression live in a TUI")
+	markInteractive(queryCmd, "tui")
+
+	_ = queryCmd.RegisterFlagCompletionFunc("from", completeFormats)
+	_ = queryCmd.RegisterFlagCompletionFunc("to", completeFormats)
+}
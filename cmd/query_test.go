@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resetQueryFlags() {
+	queryFrom = ""
+	queryTo = "json"
+	queryRaw = false
+	queryCompact = false
+	flagTUI = false
+}
+
+func TestQueryCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "jq path on json",
+			args:  []string{"query", ".user.name"},
+			input: `{"user":{"name":"Alice"}}`,
+			want:  "\"Alice\"\n",
+		},
+		{
+			name:  "raw strings from yaml",
+			args:  []string{"query", "-r", ".items[] | select(.price > 10) | .name"},
+			input: "items:\n  - name: cheap\n    price: 5\n  - name: pricey\n    price: 20\n",
+			want:  "pricey\n",
+		},
+		{
+			name:  "jsonpath on toml",
+			args:  []string{"query", "-c", "$.servers[*]"},
+			input: "[[servers]]\nhost = \"a\"\nport = 1\n\n[[servers]]\nhost = \"b\"\nport = 2\n",
+			want:  "{\"host\":\"a\",\"port\":1}\n{\"host\":\"b\",\"port\":2}\n",
+		},
+		{
+			name:  "yaml output",
+			args:  []string{"query", "{name, tags}", "--to", "yaml"},
+			input: `{"name":"devtui","tags":["cli","tui"],"other":1}`,
+			want:  "name: devtui\ntags:\n    - cli\n    - tui\n",
+		},
+		{
+			name: "input as argument",
+			args: []string{"query", "keys", `{"b":1,"a":2}`, "-c"},
+			want: "[\"a\",\"b\"]\n",
+		},
+		{
+			name:    "invalid expression",
+			args:    []string{"query", ".a |"},
+			input:   `{}`,
+			wantErr: "invalid expression",
+		},
+		{
+			name:    "runtime error",
+			args:    []string{"query", ".a.b"},
+			input:   `{"a":[1]}`,
+			wantErr: "cannot index array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetQueryFlags()
+			defer resetQueryFlags()
+
+			cmd := GetRootCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Fatalf("query output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/recipe"
+	"github.com/skatkov/devtui/tui/root"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	recipeDescription string
+	recipeForce       bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <recipe> [string or file]",
+	Short: "Run a saved recipe on the input",
+	Long: `Run a recipe: the output of every step is the input of the next one.
+
+Recipes are TOML files in $XDG_CONFIG_HOME/devtui/recipes, named after the
+recipe, that list devtui commands with their arguments:
+
+  description = "Repair JSON and convert it to YAML"
+
+  [[steps]]
+  command = "jsonrepair"
+
+  [[steps]]
+  command = "query"
+  args = [".items[]"]
+
+  [[steps]]
+  command = "json2yaml"
+
+Record one from the TUI with "devtui recipe record".`,
+	Example: `  # Run the "tidy" recipe on a file
+  devtui run tidy < broken.json
+
+  # Run it on every file matching a glob
+  devtui run tidy -f 'fixtures/*.json'`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := recipe.Load(args[0])
+		if err != nil {
+			return err
+		}
+		for i, step := range r.Steps {
+			if _, err := recipeStepCommand(step); err != nil {
+				return fmt.Errorf("step %d: %w", i+1, err)
+			}
+		}
+
+		data, err := input.ReadBytesFromArgsOrStdin(cmd, args[1:])
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		if len(data) == 0 {
+			return errors.New("no input provided. Pipe input to this command")
+		}
+
+		out := cmd.OutOrStdout()
+		output, err := r.Run(data, runRecipeStep)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(output)
+		return err
+	},
+}
+
+var recipeCmd = &cobra.Command{
+	Use:   "recipe",
+	Short: "Manage saved recipes",
+	Long: `Manage recipes, named pipelines of devtui commands run with "devtui run".
+
+Recipes are stored in $XDG_CONFIG_HOME/devtui/recipes and also appear in the
+TUI tool list.`,
+}
+
+var recipeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved recipes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		recipes, err := recipe.List()
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(recipes) == 0 {
+			_, err := fmt.Fprintf(out, "# no recipes in %s\n", recipe.Dir())
+			return err
+		}
+		for _, r := range recipes {
+			if _, err := fmt.Fprintf(out, "%s\t%s\n", r.Name, r.Summary()); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var recipeRecordCmd = &cobra.Command{
+	Use:   "record <name>",
+	Short: "Record a recipe by chaining tools in the TUI",
+	Long: `Open the TUI and record a recipe from the tools you chain together.
+
+Open a tool, load some input and press '|' to pipe its output into the next
+tool. When you quit, the longest chain of the session is saved as the
+recipe. Settings such as the query expression become step arguments.`,
+	Example: `  devtui recipe record tidy --description "Repair and format JSON"
+  devtui run tidy < broken.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := recipe.ValidateName(name); err != nil {
+			return err
+		}
+		if _, err := os.Stat(recipe.Path(name)); err == nil && !recipeForce {
+			return fmt.Errorf("recipe %q already exists, use --force to replace it", name)
+		}
+
+		p := tea.NewProgram(root.RootScreenWithOptions(root.Options{RunStep: runRecipeStep}))
+		final, err := p.Run()
+		if err != nil {
+			return err
+		}
+		model, ok := final.(root.RootModel)
+		if !ok {
+			return errors.New("nothing was recorded")
+		}
+		steps, err := model.Recorded()
+		if err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return errors.New("no tools were chained. Press '|' in a tool to pipe its output into the next one")
+		}
+
+		r := recipe.Recipe{Name: name, Description: recipeDescription, Steps: steps}
+		if err := recipe.Save(r); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Saved recipe %q (%s) to %s\n", name, r.Summary(), recipe.Path(name))
+		return err
+	},
+}
+
+// runRecipeStep is execRecipeStep, assigned in init because the root
+// command both runs steps and hands this function to the TUI.
+var runRecipeStep recipe.StepFunc
+
+// execRecipeStep runs a devtui command in this process with input as its
+// stdin and returns what it writes to stdout.
+func execRecipeStep(step recipe.Step, input []byte) ([]byte, error) {
+	if _, err := recipeStepCommand(step); err != nil {
+		return nil, err
+	}
+	output, err := execCommand(append(strings.Fields(step.Command), step.Args...), input)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// recipeStepCommand finds the command a step runs, rejecting commands that
+// can't run inside a recipe.
+func recipeStepCommand(step recipe.Step) (*cobra.Command, error) {
+	args := append(strings.Fields(step.Command), step.Args...)
+	target, _, err := rootCmd.Find(args)
+	if err != nil || target == rootCmd || !target.Runnable() {
+		return nil, fmt.Errorf("unknown command %q", step.Command)
+	}
+	if interactive(target) {
+		return nil, fmt.Errorf("%q starts a TUI and can't be a recipe step", step.Command)
+	}
+	if !embeddable(target) {
+		return nil, fmt.Errorf("%q can't be a recipe step", step.Command)
+	}
+	if flag := stepInteractiveFlag(target, step.Args); flag != nil {
+		return nil, fmt.Errorf("%q: --%s can't be used in a recipe", step.Command, flag.Name)
+	}
+	return target, nil
+}
+
+// stepInteractiveFlag returns the first flag in args that starts a TUI, or
+// nil.
+func stepInteractiveFlag(cmd *cobra.Command, args []string) *pflag.Flag {
+	flags := cmd.Flags()
+	for _, arg := range args {
+		switch {
+		case arg == "--":
+			return nil
+		case strings.HasPrefix(arg, "--"):
+			name, _, _ := strings.Cut(arg[2:], "=")
+			if flag := flags.Lookup(name); flag != nil && interactiveFlag(flag) {
+				return flag
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Shorthands can be combined, as in -ct, until one takes a value.
+			for i := 1; i < len(arg); i++ {
+				flag := flags.ShorthandLookup(arg[i : i+1])
+				if flag == nil {
+					break
+				}
+				if interactiveFlag(flag) {
+					return flag
+				}
+				if flag.NoOptDefVal == "" {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	runRecipeStep = execRecipeStep
+
+	rootCmd.AddCommand(runCmd)
+	addFileFlagAfterArgs(runCmd, 1)
+
+	rootCmd.AddCommand(recipeCmd)
+	recipeCmd.AddCommand(recipeListCmd)
+	recipeCmd.AddCommand(recipeRecordCmd)
+	recipeRecordCmd.Flags().StringVarP(&recipeDescription, "description", "d", "", "describe what the recipe does")
+	recipeRecordCmd.Flags().BoolVar(&recipeForce, "force", false, "replace an existing recipe")
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/recipe"
+)
+
+func TestRunRecipe(t *testing.T) {
+	dir := useConfig(t, "")
+	steps := []recipe.Step{
+		{Command: "jsonrepair"},
+		{Command: "query", Args: []string{"-c", "[.items[] | .name]"}},
+		{Command: "query", Args: []string{".[]"}},
+	}
+	if err := recipe.Save(recipe.Recipe{Name: "names", Steps: steps}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recipe.Save(recipe.Recipe{Name: "loop", Steps: []recipe.Step{{Command: "run", Args: []string{"loop"}}}}); err != nil {
+		t.Fatal(err)
+	}
+	file := writeTreeFile(t, filepath.Dir(dir), "items.json", `{"items": [{"name": "a"}, {"name": "b"}]}`)
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr string
+	}{
+		{name: "stdin", args: []string{"run", "names"}, stdin: `{"items": [{"name": "a", "id": 1}, {"name": "b"},`, want: "\"a\"\n\"b\""},
+		{name: "file", args: []string{"run", "names", "-f", file}, want: "\"a\"\n\"b\""},
+		{name: "list", args: []string{"recipe", "list"}, want: "loop\trun loop\nnames\tjsonrepair → query -c [.items[] | .name] → query .[]"},
+		{name: "missing recipe", args: []string{"run", "nope"}, stdin: "{}", wantErr: "not found"},
+		{name: "recursive step", args: []string{"run", "loop"}, stdin: "{}", wantErr: "can't be a recipe step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagFiles = nil
+			t.Cleanup(func() { flagFiles = nil })
+
+			cmd := GetRootCmd()
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := strings.TrimSpace(out.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecipeStepsDontLeakFlags(t *testing.T) {
+	useConfig(t, "")
+
+	first, err := runRecipeStep(recipe.Step{Command: "query", Args: []string{"-c", "."}}, []byte(`{"a": [1, 2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(first)); got != `{"a":[1,2]}` {
+		t.Errorf("compact step = %q", got)
+	}
+
+	second, err := runRecipeStep(recipe.Step{Command: "query", Args: []string{"."}}, []byte(`{"a": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(second), "\n") || strings.Contains(string(second), `{"a":1}`) {
+		t.Errorf("second step kept --compact: %q", second)
+	}
+}
+
+func TestRecipeStepCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    recipe.Step
+		wantErr string
+	}{
+		{name: "tool", step: recipe.Step{Command: "query", Args: []string{"-c", "."}}},
+		{name: "subcommand", step: recipe.Step{Command: "jsonschema infer"}},
+		{name: "unknown", step: recipe.Step{Command: "nope"}, wantErr: "unknown command"},
+		{name: "ndjson", step: recipe.Step{Command: "ndjson"}, wantErr: "starts a TUI"},
+		{name: "tui flag", step: recipe.Step{Command: "query", Args: []string{"--tui", "."}}, wantErr: "--tui can't be used"},
+		{name: "tui shorthand", step: recipe.Step{Command: "query", Args: []string{"-ct", "."}}, wantErr: "--tui can't be used"},
+		{name: "tui after --", step: recipe.Step{Command: "query", Args: []string{"--", "-t"}}},
+		{name: "run", step: recipe.Step{Command: "run", Args: []string{"names"}}, wantErr: "can't be a recipe step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := recipeStepCommand(tt.step)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("recipeStepCommand() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("recipeStepCommand() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/fang/v2"
 	"github.com/charmbracelet/x/term"
+	"github.com/skatkov/devtui/internal/input"
 	"github.com/skatkov/devtui/tui/root"
 	"github.com/spf13/cobra"
 )
@@ -18,9 +20,25 @@ var rootCmd = &cobra.Command{
 	Use:   "devtui",
 	Short: "A Swiss Army knife for developers",
 	Long: `devtui is a collection of small developer apps that help with day to day work.
-It includes tools like hash generator, unix timestamp converter, and number base converter and multiple others.`,
+It includes tools like hash generator, unix timestamp converter, and number base converter and multiple others.
+
+Piped input opens the tool that fits it best, and when started on its own
+devtui suggests tools for the clipboard contents.`,
+	Example: `  devtui
+  pbpaste | devtui
+  devtui < token.jwt
+  devtui --no-clipboard`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		p := tea.NewProgram(root.RootScreen())
+		content, err := input.ReadFromArgsOrStdin(cmd, nil)
+		if err != nil {
+			return err
+		}
+
+		p := tea.NewProgram(root.RootScreenWithOptions(root.Options{
+			Input:           content,
+			DetectClipboard: !flagNoClipboard,
+			RunStep:         runRecipeStep,
+		}))
 		if _, err := p.Run(); err != nil {
 			return err
 		}
@@ -28,13 +46,24 @@ It includes tools like hash generator, unix timestamp converter, and number base
 	},
 }
 
-var flagTUI bool
+var (
+	flagTUI         bool
+	flagNoClipboard bool
+)
+
+// errSilent makes the process exit with status 1 without printing anything,
+// for commands whose output already reports the failure.
+var errSilent = errors.New("silent failure")
 
 // customErrorHandler handles errors while preserving newlines for multiline messages.
 // The default fang error handler applies a fixed width that collapses newlines,
 // and transforms text (capitalizing first word). We want to preserve the structure
 // of multiline error messages, especially hints.
 func customErrorHandler(w io.Writer, styles fang.Styles, err error) {
+	if errors.Is(err, errSilent) {
+		return
+	}
+
 	if f, ok := w.(term.File); ok {
 		if !term.IsTerminal(f.Fd()) {
 			_, _ = fmt.Fprintln(w, err.Error())
@@ -95,4 +124,5 @@ func GetRootCmd() *cobra.Command {
 func init() {
 	// fang.Execute automatically adds --version flag
 	// We configure it via fang.WithVersion() in Execute()
+	rootCmd.Flags().BoolVar(&flagNoClipboard, "no-clipboard", false, "Don't suggest tools for the clipboard contents")
 }
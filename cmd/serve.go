@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const serveShutdownTimeout = 10 * time.Second
+
+var (
+	serveAddr    string
+	serveMaxBody int64
+	serveOpenAPI bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve every command as a local HTTP API",
+	Long: `Serve every devtui command as an HTTP endpoint, so that editor extensions and
+scripts can use it without starting a process per call.
+
+POST the input to the command's path, such as /jsonfmt or /jsonschema/infer,
+and give flags and required arguments as query parameters. The response is
+the command's output as plain text, or {"output": ...} when the request
+accepts application/json. Failures are reported as {"error": ...} with
+status 400.
+
+The OpenAPI document of all endpoints is served at /openapi.json.
+
+Flags reading or writing local files, such as --file, and --tui are not
+accepted, and commands that need local files, such as diff, are not served. The server listens on localhost by default and stops gracefully
+on Ctrl+C or SIGTERM.`,
+	Example: `  # Start the server
+  devtui serve --addr 127.0.0.1:8080
+
+  # Format JSON
+  curl -d '{"a":1}' 'http://127.0.0.1:8080/jsonfmt'
+
+  # Query with an expression and flags
+  curl -d '{"items":[1,2]}' 'http://127.0.0.1:8080/query?expression=.items&compact'
+
+  # Print the OpenAPI document
+  devtui serve --openapi`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveOpenAPI {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(openAPI(""))
+		}
+
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		url := "http://" + listener.Addr().String()
+		srv := &http.Server{
+			Handler:           newServeHandler(url, serveMaxBody),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		done := make(chan error, 1)
+		go func() { done <- srv.Serve(listener) }()
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Serving devtui on %s (OpenAPI document at %s/openapi.json)\n", url, url)
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+		}
+
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-done; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// newServeHandler routes requests to the served commands. serverURL is
+// announced in the OpenAPI document, and request bodies larger than
+// maxBody bytes are rejected.
+func newServeHandler(serverURL string, maxBody int64) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, openAPI(serverURL))
+	})
+	for _, cmd := range servedCommands() {
+		mux.HandleFunc("POST "+servedPath(cmd), func(w http.ResponseWriter, r *http.Request) {
+			serveCommand(w, r, cmd, maxBody)
+		})
+	}
+	return mux
+}
+
+// serveCommand runs cmd with the request body as its input.
+func serveCommand(w http.ResponseWriter, r *http.Request, cmd *cobra.Command, maxBody int64) {
+	args, err := servedCommandArgs(cmd, r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{
+				"error": fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit),
+			})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	output, err := execCommand(args, input)
+	if err != nil {
+		message := err.Error()
+		if errors.Is(err, errSilent) {
+			message = "command failed"
+		}
+		body := map[string]string{"error": message}
+		if len(output) > 0 {
+			body["output"] = string(output)
+		}
+		writeJSON(w, http.StatusBadRequest, body)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, http.StatusOK, map[string]string{"output": string(output)})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(output)
+}
+
+// servedCommandArgs turns the query parameters of r into the command line
+// of cmd: flags first, then the required arguments in the order of the
+// usage line.
+func servedCommandArgs(cmd *cobra.Command, r *http.Request) ([]string, error) {
+	query := r.URL.Query()
+	args := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "))
+
+	names := servedArgs(cmd)
+	flags := servedFlags(cmd)
+	var keys []string
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if slices.Contains(names, key) {
+			continue
+		}
+		i := slices.IndexFunc(flags, func(f *pflag.Flag) bool { return f.Name == key })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown query parameter %q", key)
+		}
+		for _, value := range query[key] {
+			// A bare ?compact turns a boolean flag on.
+			if value == "" && flags[i].NoOptDefVal != "" {
+				args = append(args, "--"+key)
+			} else {
+				args = append(args, "--"+key+"="+value)
+			}
+		}
+	}
+
+	// Arguments such as a query expression starting with '-' must not be
+	// taken for flags.
+	args = append(args, "--")
+	for _, name := range names {
+		values := query[name]
+		if len(values) != 1 {
+			return nil, fmt.Errorf("query parameter %q is required once", name)
+		}
+		args = append(args, values[0])
+	}
+	return args, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7777", "address to listen on")
+	serveCmd.Flags().Int64Var(&serveMaxBody, "max-body", 10<<20, "largest request body accepted, in bytes")
+	serveCmd.Flags().BoolVar(&serveOpenAPI, "openapi", false, "print the OpenAPI document and exit")
+}
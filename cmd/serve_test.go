@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestServe(t *testing.T) {
+	useConfig(t, "")
+	srv := httptest.NewServer(newServeHandler("", 64))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		accept     string
+		wantStatus int
+		want       string
+	}{
+		{name: "format", path: "/jsonfmt", body: `{"a":1}`, wantStatus: http.StatusOK, want: "{\n  \"a\": 1\n}"},
+		{name: "argument and flag", path: "/query?expression=.items&compact", body: `{"items": [1, 2]}`, wantStatus: http.StatusOK, want: "[1,2]"},
+		{name: "argument like a flag", path: "/query?expression=-1", body: `{}`, wantStatus: http.StatusOK, want: "-1"},
+		{name: "json output", path: "/base64", body: "hi", accept: "application/json", wantStatus: http.StatusOK, want: `{"output":"aGk="}`},
+		{name: "nested command", path: "/jsonschema/infer", body: `{"a":1}`, wantStatus: http.StatusOK, want: `"type": "object"`},
+		{name: "command error", path: "/toml2json", body: "a = ", wantStatus: http.StatusBadRequest, want: `"error"`},
+		{name: "missing argument", path: "/query", body: `{}`, wantStatus: http.StatusBadRequest, want: `"expression\" is required`},
+		{name: "unknown parameter", path: "/jsonfmt?nope=1", body: `{}`, wantStatus: http.StatusBadRequest, want: `unknown query parameter`},
+		{name: "file flag", path: "/jsonfmt?file=/etc/passwd", body: `{}`, wantStatus: http.StatusBadRequest, want: `unknown query parameter`},
+		{name: "checksum file flag", path: "/hash?check=/etc/hostname", body: "hi", wantStatus: http.StatusBadRequest, want: `unknown query parameter \"check\"`},
+		{name: "schema file flag", path: "/jsonschema/validate?schema=/etc/passwd", body: `{}`, wantStatus: http.StatusNotFound},
+		{name: "key file flag", path: "/jwt?key=/etc/passwd", body: "a.b.c", wantStatus: http.StatusBadRequest, want: `unknown query parameter \"key\"`},
+		{name: "tui flag", path: "/query?expression=.&tui", body: `{}`, wantStatus: http.StatusBadRequest, want: `unknown query parameter \"tui\"`},
+		{name: "too large", path: "/jsonfmt", body: strings.Repeat(" ", 65), wantStatus: http.StatusRequestEntityTooLarge, want: "larger than 64 bytes"},
+		{name: "not served", path: "/serve", wantStatus: http.StatusNotFound},
+		{name: "file arguments", path: "/diff", body: `{}`, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", resp.StatusCode, tt.wantStatus, body)
+			}
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("body = %q, want it to contain %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	srv := httptest.NewServer(newServeHandler("http://127.0.0.1:7777", 1024))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string `json:"name"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// diff and jsonschema validate need local files.
+	for _, path := range []string{"/serve", "/run", "/recipe/list", "/config/show", "/diff", "/jsonschema/validate"} {
+		if _, ok := doc.Paths[path]; ok {
+			t.Errorf("%s should not be served", path)
+		}
+	}
+	query, ok := doc.Paths["/query"]["post"]
+	if !ok {
+		t.Fatal("/query is missing")
+	}
+	var names []string
+	for _, p := range query.Parameters {
+		names = append(names, p.Name)
+		if p.Name == "expression" && !p.Required {
+			t.Error("expression should be required")
+		}
+		if p.Name == "file" {
+			t.Error("--file should not be a parameter")
+		}
+	}
+	if !strings.Contains(strings.Join(names, " "), "compact") {
+		t.Errorf("/query parameters = %v, want compact", names)
+	}
+	if _, ok := doc.Paths["/jsonschema/infer"]; !ok {
+		t.Error("/jsonschema/infer is missing")
+	}
+}
+
+func TestServedCommandsDontStartTUI(t *testing.T) {
+	var names []string
+	for _, cmd := range servedCommands() {
+		names = append(names, cmd.CommandPath())
+		if interactive(cmd) {
+			t.Errorf("%s starts a TUI but is served", cmd.CommandPath())
+		}
+		if flag := cmd.Flags().Lookup("tui"); flag != nil && servable(flag) {
+			t.Errorf("%s --tui is served", cmd.CommandPath())
+		}
+	}
+	if slices.Contains(names, "devtui ndjson") {
+		t.Error("ndjson should not be served")
+	}
+}
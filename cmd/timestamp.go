@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/input"
+	"github.com/skatkov/devtui/internal/timestamp"
+	"github.com/spf13/cobra"
+)
+
+var timestampCmd = &cobra.Command{
+	Use:     "timestamp [value]",
+	Aliases: []string{"epoch"},
+	Short:   "Convert unix timestamps and dates",
+	Long: `Convert unix timestamps and dates between representations.
+
+Accepts epoch values in seconds, milliseconds, microseconds or nanoseconds (the unit
+is detected from the magnitude unless --unit is given), as well as RFC3339, RFC1123,
+ISO-8601 and common human-readable dates. Dates without a zone are interpreted in
+local time. Without input, the current time is shown.
+
+The instant is printed in UTC, local time, each --zone, and relative to now.`,
+	Example: `  # Show the current time
+  devtui timestamp
+
+  # Convert an epoch value (unit auto-detected)
+  devtui timestamp 1700000000
+  devtui timestamp 1700000000123
+
+  # Force the unit
+  devtui timestamp --unit ms 1700000000
+
+  # Parse a date
+  devtui timestamp "2024-02-29T12:00:00Z"
+  devtui timestamp "Mon, 02 Jan 2006 15:04:05 MST"
+
+  # Show specific time zones
+  devtui timestamp -z Europe/Paris -z Asia/Singapore 1700000000
+
+  # Output as JSON
+  devtui timestamp --json 1700000000`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputStr, err := input.ReadFromArgsOrStdin(cmd, args)
+		if err != nil {
+			return fmt.Errorf("error reading from stdin: %w", err)
+		}
+		value := strings.TrimSpace(inputStr)
+		if value == "" {
+			value = "now"
+		}
+
+		unit, err := timestamp.ParseUnit(timestampUnit)
+		if err != nil {
+			return err
+		}
+
+		zones := timestampZones
+		if len(zones) == 0 {
+			zones = timestamp.DefaultZones
+		}
+
+		result, err := timestamp.Convert(value, unit, zones, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if timestampJSONOutput {
+			bytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+			return err
+		}
+
+		output := table.New().Border(lipgloss.NormalBorder())
+		for _, row := range timestamp.ResultToRows(result) {
+			output.Row(row...)
+		}
+
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), output.String())
+		return err
+	},
+}
+
+var (
+	timestampUnit       string
+	timestampZones      []string
+	timestampJSONOutput bool
+)
+
+func init() {
+	rootCmd.AddCommand(timestampCmd)
+	addFileFlag(timestampCmd)
+	timestampCmd.Flags().StringVarP(&timestampUnit, "unit", "u", string(timestamp.UnitAuto), "epoch unit (auto, s, ms, us, ns)")
+	timestampCmd.Flags().StringArrayVarP(&timestampZones, "zone", "z", nil, "IANA time zone to show, repeatable (default: a list of common zones)")
+	timestampCmd.Flags().BoolVar(&timestampJSONOutput, "json", false, "output conversions as JSON")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/timestamp"
+)
+
+func resetTimestampFlags() {
+	timestampUnit = string(timestamp.UnitAuto)
+	timestampZones = nil
+	timestampJSONOutput = false
+}
+
+func TestTimestampCmd(t *testing.T) {
+	resetTimestampFlags()
+	defer resetTimestampFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"timestamp", "--zone", "Asia/Tokyo", "1700000000"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("timestamp command failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "2023-11-14 22:13:20.000 UTC") {
+		t.Fatalf("timestamp output missing UTC time: %s", output)
+	}
+	if !strings.Contains(output, "Asia/Tokyo") {
+		t.Fatalf("timestamp output missing requested zone: %s", output)
+	}
+}
+
+func TestTimestampCmdJSON(t *testing.T) {
+	resetTimestampFlags()
+	defer resetTimestampFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("2024-02-29T12:00:00Z"))
+	cmd.SetArgs([]string{"timestamp", "--json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("timestamp --json command failed: %v", err)
+	}
+
+	var result timestamp.Result
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("timestamp --json output invalid JSON: %v", err)
+	}
+	if result.Unix != 1709208000 {
+		t.Fatalf("expected unix 1709208000, got %d", result.Unix)
+	}
+	if len(result.Zones) != len(timestamp.DefaultZones) {
+		t.Fatalf("expected default zones, got %d", len(result.Zones))
+	}
+}
+
+func TestTimestampCmdInvalidInput(t *testing.T) {
+	resetTimestampFlags()
+	defer resetTimestampFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"timestamp", "yesterday-ish"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("timestamp command should fail for unparseable input")
+	}
+}
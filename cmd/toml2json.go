@@ -78,5 +78,7 @@ in an interactive terminal interface.`,
 
 func init() {
 	rootCmd.AddCommand(toml2jsonCmd)
+	addFileFlag(toml2jsonCmd)
 	toml2jsonCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
+	markInteractive(toml2jsonCmd, "tui")
 }
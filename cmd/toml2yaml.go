@@ -15,6 +15,8 @@ var toml2yamlCmd = &cobra.Command{
 	Short: "Convert TOML to YAML format",
 	Long: `Convert TOML to YAML format.
 
+Key order and comments are preserved; use --no-comments to drop comments.
+
 Input can be a string argument or piped from stdin.`,
 	Example: `  # Convert TOML from stdin
   devtui toml2yaml < config.toml
@@ -40,7 +42,9 @@ Input can be a string argument or piped from stdin.`,
 		}
 
 		inputStr := string(data)
-		result, err := converter.TOMLToYAML(inputStr)
+		result, err := converter.ConvertWithOptions(inputStr, converter.FormatTOML, converter.FormatYAML, converter.Options{
+			NoComments: toml2yamlNoComments,
+		})
 		if err != nil {
 			return cmderror.FormatParseError("toml2yaml", inputStr, err)
 		}
@@ -54,6 +58,11 @@ Input can be a string argument or piped from stdin.`,
 	},
 }
 
+var toml2yamlNoComments bool
+
 func init() {
 	rootCmd.AddCommand(toml2yamlCmd)
+	addFileFlag(toml2yamlCmd)
+
+	toml2yamlCmd.Flags().BoolVar(&toml2yamlNoComments, "no-comments", false, "drop comments instead of copying them to the output")
 }
@@ -78,5 +78,7 @@ in an interactive terminal interface.`,
 
 func init() {
 	rootCmd.AddCommand(tomlfmtCmd)
+	addFormatFlags(tomlfmtCmd)
 	tomlfmtCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
+	markInteractive(tomlfmtCmd, "tui")
 }
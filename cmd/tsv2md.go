@@ -69,6 +69,7 @@ var (
 
 func init() {
 	rootCmd.AddCommand(tsv2mdCmd)
+	addFileFlag(tsv2mdCmd)
 
 	tsv2mdCmd.Flags().BoolVarP(&tsv2mdAlignColumns, "align", "a", false, "align columns width")
 	tsv2mdCmd.Flags().StringVarP(&tsv2mdHeader, "header", "t", "", "add main header (h1) to result")
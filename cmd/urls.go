@@ -57,8 +57,8 @@ Input can be a string argument or piped from stdin.`,
 
 		// Output results
 		if len(uniqueURLs) > 0 {
-			fmt.Print(strings.Join(uniqueURLs, "\n"))
-			fmt.Print("\n")
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), strings.Join(uniqueURLs, "\n"))
+			return err
 		}
 
 		return nil
@@ -69,6 +69,7 @@ var urlsStrict bool
 
 func init() {
 	rootCmd.AddCommand(urlsCmd)
+	addFileFlag(urlsCmd)
 
 	urlsCmd.Flags().BoolVarP(&urlsStrict, "strict", "s", false, "use strict mode (require valid URL schemes)")
 }
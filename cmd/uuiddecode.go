@@ -77,5 +77,6 @@ var uuiddecodeJSONOutput bool
 
 func init() {
 	rootCmd.AddCommand(uuiddecodeCmd)
+	addFileFlag(uuiddecodeCmd)
 	uuiddecodeCmd.Flags().BoolVar(&uuiddecodeJSONOutput, "json", false, "output decoded fields as JSON")
 }
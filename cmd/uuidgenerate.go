@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/skatkov/devtui/internal/uuidutil"
 	"github.com/spf13/cobra"
@@ -9,10 +10,24 @@ import (
 
 var uuidgenerateCmd = &cobra.Command{
 	Use:   "uuidgenerate",
-	Short: "Generate a UUID",
-	Long: `Generate a UUID of a specified version.
+	Short: "Generate UUIDs, ULIDs, KSUIDs, NanoIDs, CUID2s and Snowflake IDs",
+	Long: `Generate one or many identifiers.
 
-By default, generates a version 4 UUID. Versions 3 and 5 accept a namespace value.`,
+By default, generates a version 4 UUID. Versions 3 and 5 accept a namespace value
+and always give the same UUID for it, so they generate one at a time.
+UUIDs can be written in upper case, without hyphens, in braces or as URNs; styles
+combine, except braces with urn.
+
+Other kinds of identifiers are selected with --kind:
+  ulid       26 characters of Crockford base32, sortable by time
+  ksuid      27 characters of base62, sortable by time
+  nanoid     21 URL-safe characters, or --length characters of --alphabet
+  cuid2      24 lowercase characters, or --length (2-32)
+  snowflake  Twitter-style 64 bit integers of time, --node and a sequence
+
+ULIDs and Snowflake IDs generated in the same millisecond still sort in the order
+they were generated. Many identifiers can be written one per line, as a JSON
+array, as CSV with an "id" header, or as SQL VALUES rows.`,
 	Example: `  # Generate a default UUID (v4)
   devtui uuidgenerate
 
@@ -20,15 +35,39 @@ By default, generates a version 4 UUID. Versions 3 and 5 accept a namespace valu
   devtui uuidgenerate --uuid-version 7
 
   # Generate a UUID v3 with namespace
-  devtui uuidgenerate --uuid-version 3 --namespace example.com`,
+  devtui uuidgenerate --uuid-version 3 --namespace example.com
+
+  # Generate 100 upper-case UUIDs in braces as a JSON array
+  devtui uuidgenerate --count 100 --style upper,braces --format json
+
+  # Generate ULIDs as SQL VALUES rows for fixtures
+  devtui uuidgenerate --kind ulid --count 10 --format sql
+
+  # Generate NanoIDs of 10 digits
+  devtui uuidgenerate --kind nanoid --alphabet 0123456789 --length 10 --count 5
+
+  # Generate Snowflake IDs for worker 42
+  devtui uuidgenerate --kind snowflake --node 42 --count 3`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		generated, err := uuidutil.Generate(uuidgenerateVersion, uuidgenerateNamespace)
+		ids, err := uuidutil.GenerateN(uuidutil.Options{
+			Kind:      uuidgenerateKind,
+			Version:   uuidgenerateVersion,
+			Namespace: uuidgenerateNamespace,
+			Styles:    uuidgenerateStyles,
+			Alphabet:  uuidgenerateAlphabet,
+			Length:    uuidgenerateLength,
+			Node:      uuidgenerateNode,
+		}, uuidgenerateCount)
 		if err != nil {
 			return err
 		}
 
-		_, err = fmt.Fprintln(cmd.OutOrStdout(), generated.String())
+		output, err := uuidutil.FormatIDs(ids, uuidgenerateFormat)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), output)
 		return err
 	},
 }
@@ -36,10 +75,28 @@ By default, generates a version 4 UUID. Versions 3 and 5 accept a namespace valu
 var (
 	uuidgenerateVersion   int
 	uuidgenerateNamespace string
+	uuidgenerateKind      string
+	uuidgenerateCount     int
+	uuidgenerateFormat    string
+	uuidgenerateStyles    []string
+	uuidgenerateAlphabet  string
+	uuidgenerateLength    int
+	uuidgenerateNode      int64
 )
 
 func init() {
 	rootCmd.AddCommand(uuidgenerateCmd)
 	uuidgenerateCmd.Flags().IntVarP(&uuidgenerateVersion, "uuid-version", "v", 4, "UUID version to generate (1-7)")
 	uuidgenerateCmd.Flags().StringVarP(&uuidgenerateNamespace, "namespace", "n", "", "namespace for UUID v3/v5 generation")
+	uuidgenerateCmd.Flags().StringVarP(&uuidgenerateKind, "kind", "k", uuidutil.KindUUID, "kind of identifier: "+strings.Join(uuidutil.Kinds, ", "))
+	uuidgenerateCmd.Flags().IntVarP(&uuidgenerateCount, "count", "c", 1, "number of identifiers to generate")
+	uuidgenerateCmd.Flags().StringVarP(&uuidgenerateFormat, "format", "f", "plain", "output format: "+strings.Join(uuidutil.Formats, ", "))
+	uuidgenerateCmd.Flags().StringSliceVarP(&uuidgenerateStyles, "style", "s", nil, "UUID styles: "+strings.Join(uuidutil.Styles, ", "))
+	uuidgenerateCmd.Flags().StringVar(&uuidgenerateAlphabet, "alphabet", "", "NanoID alphabet (default URL-safe characters)")
+	uuidgenerateCmd.Flags().IntVarP(&uuidgenerateLength, "length", "l", 0, "NanoID or CUID2 length (default 21 and 24)")
+	uuidgenerateCmd.Flags().Int64Var(&uuidgenerateNode, "node", 0, "Snowflake node (worker) ID, 0-1023")
+
+	_ = uuidgenerateCmd.RegisterFlagCompletionFunc("kind", cobra.FixedCompletions(uuidutil.Kinds, cobra.ShellCompDirectiveNoFileComp))
+	_ = uuidgenerateCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(uuidutil.Formats, cobra.ShellCompDirectiveNoFileComp))
+	_ = uuidgenerateCmd.RegisterFlagCompletionFunc("style", cobra.FixedCompletions(uuidutil.Styles, cobra.ShellCompDirectiveNoFileComp))
 }
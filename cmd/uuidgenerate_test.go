@@ -2,15 +2,29 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/skatkov/devtui/internal/uuidutil"
 )
 
-func TestUUIDGenerateCmdDefault(t *testing.T) {
+func resetUUIDGenerateFlags() {
 	uuidgenerateVersion = 4
 	uuidgenerateNamespace = ""
+	uuidgenerateKind = uuidutil.KindUUID
+	uuidgenerateCount = 1
+	uuidgenerateFormat = "plain"
+	uuidgenerateStyles = nil
+	uuidgenerateAlphabet = ""
+	uuidgenerateLength = 0
+	uuidgenerateNode = 0
+}
+
+func TestUUIDGenerateCmdDefault(t *testing.T) {
+	resetUUIDGenerateFlags()
+	defer resetUUIDGenerateFlags()
 
 	cmd := GetRootCmd()
 	buf := new(bytes.Buffer)
@@ -34,8 +48,8 @@ func TestUUIDGenerateCmdDefault(t *testing.T) {
 }
 
 func TestUUIDGenerateCmdVersion7(t *testing.T) {
-	uuidgenerateVersion = 4
-	uuidgenerateNamespace = ""
+	resetUUIDGenerateFlags()
+	defer resetUUIDGenerateFlags()
 
 	cmd := GetRootCmd()
 	buf := new(bytes.Buffer)
@@ -57,3 +71,84 @@ func TestUUIDGenerateCmdVersion7(t *testing.T) {
 		t.Fatalf("expected version 7 uuid, got version %d", parsed.Version())
 	}
 }
+
+func TestUUIDGenerateCmdBulkJSON(t *testing.T) {
+	resetUUIDGenerateFlags()
+	defer resetUUIDGenerateFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"uuidgenerate", "--count", "25", "--style", "upper,urn", "--format", "json"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("uuidgenerate --count 25 failed: %v", err)
+	}
+
+	var ids []string
+	if err := json.Unmarshal(buf.Bytes(), &ids); err != nil {
+		t.Fatalf("uuidgenerate --format json output invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(ids) != 25 {
+		t.Fatalf("expected 25 ids, got %d", len(ids))
+	}
+	for _, id := range ids {
+		if !strings.HasPrefix(id, "urn:uuid:") || strings.ToUpper(id[9:]) != id[9:] {
+			t.Errorf("unexpected styled uuid %q", id)
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			t.Errorf("%q is not a uuid: %v", id, err)
+		}
+	}
+}
+
+func TestUUIDGenerateCmdNanoIDSQL(t *testing.T) {
+	resetUUIDGenerateFlags()
+	defer resetUUIDGenerateFlags()
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"uuidgenerate", "--kind", "nanoid", "--alphabet", "01", "--length", "8", "--count", "3", "--format", "sql"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("uuidgenerate --kind nanoid failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "VALUES" || !strings.HasSuffix(lines[3], ");") {
+		t.Fatalf("unexpected SQL output:\n%s", buf.String())
+	}
+	for _, line := range lines[1:] {
+		id := strings.Trim(line, " ()',;")
+		if len(id) != 8 || strings.Trim(id, "01") != "" {
+			t.Errorf("unexpected nanoid in %q", line)
+		}
+	}
+}
+
+func TestUUIDGenerateCmdInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"uuidgenerate", "--kind", "guid"},
+		{"uuidgenerate", "--count", "0"},
+		{"uuidgenerate", "--format", "xml"},
+		{"uuidgenerate", "--kind", "ulid", "--style", "upper"},
+		{"uuidgenerate", "--kind", "snowflake", "--node", "2000"},
+		{"uuidgenerate", "--kind", "nanoid", "--namespace", "example.com"},
+		{"uuidgenerate", "--uuid-version", "5", "--namespace", "example.com", "--count", "3"},
+	} {
+		resetUUIDGenerateFlags()
+
+		cmd := GetRootCmd()
+		buf := new(bytes.Buffer)
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+	resetUUIDGenerateFlags()
+}
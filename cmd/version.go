@@ -22,9 +22,9 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
 	Long:  "Print version, commit and date of release for this software",
-	RunE: func(_ *cobra.Command, _ []string) error {
-		fmt.Print("devtui version " + GetVersionShort())
-		return nil
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), "devtui version "+GetVersionShort())
+		return err
 	},
 }
 
@@ -56,4 +56,5 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(xml2jsonCmd)
+	addFileFlag(xml2jsonCmd)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/go-xmlfmt/xmlfmt"
@@ -58,6 +59,7 @@ Input can be a string argument or piped from stdin.`,
 			common := &ui.CommonModel{
 				Width:  80,
 				Height: 80,
+				Config: appConfig,
 			}
 
 			model := xml.NewXMLFormatterModel(common)
@@ -72,8 +74,9 @@ Input can be a string argument or piped from stdin.`,
 			return nil
 		}
 
-		result := xmlfmt.FormatXML(string(data),
-			xmlPrefix, xmlIndent, xmlNested)
+		// FormatXML starts every element, the first included, on a new line.
+		result := strings.TrimLeft(xmlfmt.FormatXML(string(data),
+			xmlPrefix, xmlIndent, xmlNested), "\r\n")
 
 		_, err = fmt.Fprintln(cmd.OutOrStdout(), result)
 		if err != nil {
@@ -92,8 +95,10 @@ var (
 
 func init() {
 	rootCmd.AddCommand(xmlfmtCmd)
+	addFormatFlags(xmlfmtCmd)
 	xmlfmtCmd.Flags().StringVarP(&xmlPrefix, "prefix", "p", "", "Each element begins on a new line and this prefix")
 	xmlfmtCmd.Flags().StringVarP(&xmlIndent, "indent", "i", "  ", "Indent string for nested elements")
 	xmlfmtCmd.Flags().BoolVarP(&xmlNested, "nested", "n", false, "Nested tags in comments")
 	xmlfmtCmd.Flags().BoolVarP(&flagTUI, "tui", "t", false, "Show output in TUI")
+	markInteractive(xmlfmtCmd, "tui")
 }
@@ -56,4 +56,5 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(yaml2jsonCmd)
+	addFileFlag(yaml2jsonCmd)
 }
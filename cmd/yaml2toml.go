@@ -15,6 +15,8 @@ var yaml2tomlCmd = &cobra.Command{
 	Short: "Convert YAML to TOML format",
 	Long: `Convert YAML to TOML format.
 
+Key order and comments are preserved; use --no-comments to drop comments.
+
 Input can be a string argument or piped from stdin.`,
 	Example: `  # Convert YAML from stdin
   devtui yaml2toml < config.yaml
@@ -40,7 +42,9 @@ Input can be a string argument or piped from stdin.`,
 		}
 
 		inputStr := string(data)
-		result, err := converter.YAMLToTOML(inputStr)
+		result, err := converter.ConvertWithOptions(inputStr, converter.FormatYAML, converter.FormatTOML, converter.Options{
+			NoComments: yaml2tomlNoComments,
+		})
 		if err != nil {
 			return cmderror.FormatParseError("yaml2toml", inputStr, err)
 		}
@@ -54,6 +58,11 @@ Input can be a string argument or piped from stdin.`,
 	},
 }
 
+var yaml2tomlNoComments bool
+
 func init() {
 	rootCmd.AddCommand(yaml2tomlCmd)
+	addFileFlag(yaml2tomlCmd)
+
+	yaml2tomlCmd.Flags().BoolVar(&yaml2tomlNoComments, "no-comments", false, "drop comments instead of copying them to the output")
 }
@@ -15,7 +15,10 @@ var yamlfmtCmd = &cobra.Command{
 	Short: "Format and prettify YAML",
 	Long: `Format and prettify YAML input with proper indentation.
 
-Input can be a string argument, piped from stdin, or read from a file.`,
+Input can be a string argument, piped from stdin, or read from files with
+--file, which is repeatable and expands globs. Files can be rewritten in place
+with --write, or checked with --check and --diff, which exit non-zero when a
+file is not formatted.`,
 	Example: `  # Format YAML from stdin
   devtui yamlfmt < config.yaml
   cat config.yaml | devtui yamlfmt
@@ -24,7 +27,13 @@ Input can be a string argument, piped from stdin, or read from a file.`,
   devtui yamlfmt 'name: myapp\nversion: 1.0.0'
 
   # Output to file
-  devtui yamlfmt < input.yaml > formatted.yaml`,
+  devtui yamlfmt < input.yaml > formatted.yaml
+
+  # Format every manifest below k8s/ in place
+  devtui yamlfmt --write -f 'k8s/**/*.yaml'
+
+  # Fail when a manifest is not formatted, showing what would change
+  devtui yamlfmt --diff -f 'k8s/**/*.yaml'`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
@@ -52,4 +61,5 @@ Input can be a string argument, piped from stdin, or read from a file.`,
 
 func init() {
 	rootCmd.AddCommand(yamlfmtCmd)
+	addFormatFlags(yamlfmtCmd)
 }
@@ -11,12 +11,20 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var yamlstructLang string
+
 var yamlstructCmd = &cobra.Command{
 	Use:   "yamlstruct [string or file]",
-	Short: "Convert YAML to Go struct",
-	Long: `Convert YAML input into a Go struct definition.
+	Short: "Convert YAML to Go structs or other type definitions",
+	Long: `Convert YAML input into a Go struct definition, or into types for another
+language with --lang.
+
+Input can be a string argument or piped from stdin. Every document in the
+input is treated as a sample: properties missing from some objects become
+optional and properties that were null become nullable.
 
-Input can be a string argument or piped from stdin.`,
+Supported languages: go, typescript (ts), zod, rust (rs), python (py),
+pydantic, kotlin (kt) and jsonschema.`,
 	Example: `  # Convert YAML from stdin
   devtui yamlstruct < config.yaml
   cat config.yaml | devtui yamlstruct
@@ -25,9 +33,18 @@ Input can be a string argument or piped from stdin.`,
   devtui yamlstruct 'name: Alice\nage: 30'
 
   # Output to file
-  devtui yamlstruct < input.yaml > struct.go`,
+  devtui yamlstruct < input.yaml > struct.go
+
+  # Generate TypeScript interfaces or Pydantic models
+  devtui yamlstruct --lang ts < data.yaml
+  devtui yamlstruct --lang pydantic < data.yaml > models.py`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		lang, err := structgen.ParseLanguage(yamlstructLang)
+		if err != nil {
+			return err
+		}
+
 		data, err := input.ReadBytesFromArgsOrStdin(cmd, args)
 		if err != nil {
 			return fmt.Errorf("error reading from stdin: %w", err)
@@ -37,7 +54,7 @@ Input can be a string argument or piped from stdin.`,
 		}
 
 		inputStr := string(data)
-		result, err := structgen.YAMLToGoStruct(strings.NewReader(inputStr))
+		result, err := structgen.YAMLToStruct(strings.NewReader(inputStr), lang)
 		if err != nil {
 			return cmderror.FormatParseError("yamlstruct", inputStr, err)
 		}
@@ -53,4 +70,7 @@ Input can be a string argument or piped from stdin.`,
 
 func init() {
 	rootCmd.AddCommand(yamlstructCmd)
+	addFileFlag(yamlstructCmd)
+	yamlstructCmd.Flags().StringVarP(&yamlstructLang, "lang", "l", string(structgen.LanguageGo), "output language (go, typescript, zod, rust, python, pydantic, kotlin, jsonschema)")
+	_ = yamlstructCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
 }
@@ -43,3 +43,23 @@ func TestYAMLStructCmdNoInput(t *testing.T) {
 		t.Fatal("yamlstruct command should return error when no input provided")
 	}
 }
+
+func TestYAMLStructCmdLang(t *testing.T) {
+	t.Cleanup(func() { yamlstructLang = "go" })
+
+	cmd := GetRootCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("name: Alice\n---\nname: Bob\nage: 30\n"))
+	cmd.SetArgs([]string{"yamlstruct", "--lang", "kotlin"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("yamlstruct command failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "val age: Long? = null,") {
+		t.Fatalf("yamlstruct output missing optional age field: %s", output)
+	}
+}
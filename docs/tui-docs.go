@@ -48,7 +48,9 @@ func extractTUIModules() ([]TUIModule, error) {
 			continue
 		}
 
-		if module != nil {
+		// Packages without a Title, such as the recipe runner, aren't tools
+		// of their own.
+		if module != nil && module.Title != "" {
 			modules = append(modules, *module)
 		}
 	}
@@ -241,6 +243,42 @@ To access the TUI interface, simply run:
 
 This will open the main menu where you can select any of the available tools using arrow keys and Enter.
 
+## Suggestions
+
+On startup devtui looks at the clipboard and highlights the tools that fit
+what you copied, such as the JWT Decoder for a token or the Cron Job Parser
+for a cron expression. The best match is selected, so Enter opens it with the
+clipboard contents already loaded. Pass ` + "`--no-clipboard`" + ` to skip this.
+
+Piping input into devtui opens the best matching tool with it right away:
+
+` + "```bash\npbpaste | devtui\ndevtui < response.json\n```" + `
+
+## Chaining Tools
+
+Press ` + "`|`" + ` in a tool to send its output into another one. Pick the next tool
+from the list and it opens with that output as its content, for example to
+turn a CSV file into JSON and the JSON into TOON. The status bar shows the
+trail, such as ` + "`CSV to JSON → JSON to TOON`" + `, and ` + "`esc`" + ` walks back through it
+before returning to the main menu.
+
+## Recipes
+
+Saved recipes are listed with the tools as "Recipe: <name>"; opening one runs
+its steps on the pasted or edited input. Run
+` + "`devtui recipe record <name>`" + ` to record the tools you chain with ` + "`|`" + ` as a
+new recipe, and ` + "`devtui run <name>`" + ` to run it from the command line.
+
+## History
+
+Tools that show their result in a pager remember their recent inputs in
+` + "`$XDG_DATA_HOME/devtui/history`" + ` and reopen with the last one. Press ` + "`H`" + ` to
+browse older inputs, Enter to load one and ` + "`d`" + ` to delete it. The history of
+the JWT Decoder is encrypted. The form tools keep no history: the Cron Job
+Parser, Unix Timestamp Converter, UUID Decoder, UUID Generator, Number Base
+Converter, IBAN Generator and Password Generator. Use the ` + "`[history]`" + ` settings shown by ` + "`devtui config --help`" + ` to
+turn history off, bound its size or encrypt other tools.
+
 ## Common Key Bindings
 
 Most TUI tools share these common key bindings:
@@ -250,6 +288,8 @@ Most TUI tools share these common key bindings:
 - **c** - Copy output to clipboard
 - **v** - Paste content from clipboard
 - **e** - Edit content in external editor
+- **|** - Pipe output into another tool
+- **H** - Browse the history of inputs
 - **↑/k** - Navigate up
 - **↓/j** - Navigate down
 `
@@ -26,6 +26,16 @@ func Decode(encoded string) ([]byte, error) {
 	return decoded, nil
 }
 
+// DecodeURL decodes base64url input as used by JWTs and JWKs. Padding is
+// optional.
+func DecodeURL(encoded string) ([]byte, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(encoded), "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url input: %v", err)
+	}
+	return decoded, nil
+}
+
 // DecodeToString decodes the given base64 string to string
 func DecodeToString(encoded string) (string, error) {
 	decoded, err := Decode(encoded)
@@ -267,3 +267,29 @@ func TestBase64WithInvalidTestData(t *testing.T) {
 		t.Errorf("Expected decode error for invalid base64 data, but got none")
 	}
 }
+
+func TestBase64DecodeURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "unpadded", input: "eyJhbGciOiJIUzI1NiJ9", want: `{"alg":"HS256"}`},
+		{name: "padded", input: "aGk=", want: "hi"},
+		{name: "url alphabet", input: "-_8", want: "\xfb\xff"},
+		{name: "standard alphabet rejected", input: "+/8", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeURL(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("DecodeURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
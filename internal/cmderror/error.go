@@ -8,7 +8,7 @@ import (
 )
 
 var knownExtensions = []string{
-	".json", ".toml", ".yaml", ".yml", ".xml", ".csv", ".tsv",
+	".json", ".toml", ".yaml", ".yml", ".xml", ".csv", ".tsv", ".toon", ".jsonl", ".ndjson",
 	".gql", ".graphql", ".css", ".html", ".htm", ".md", ".txt",
 }
 
@@ -38,6 +38,6 @@ func FormatParseError(command, input string, err error) error {
 		return err
 	}
 
-	return fmt.Errorf("%w\n\nHint: '%s' looks like a file path. \n\n To read from a file, use:\n  devtui %s < %s",
+	return fmt.Errorf("%w\n\nHint: '%s' looks like a file path. \n\n To read from a file, use:\n  devtui %s -f %s",
 		err, input, command, input)
 }
@@ -72,7 +72,7 @@ func TestFormatParseError(t *testing.T) {
 			wantContain: []string{
 				"invalid character",
 				"looks like a file path",
-				"devtui json2toml < config.json",
+				"devtui json2toml -f config.json",
 			},
 			wantExclude: []string{},
 		},
@@ -93,7 +93,7 @@ func TestFormatParseError(t *testing.T) {
 			command: "toml2json",
 			input:   "config.toml",
 			wantContain: []string{
-				"devtui toml2json < config.toml",
+				"devtui toml2json -f config.toml",
 			},
 			wantExclude: []string{},
 		},
@@ -102,7 +102,7 @@ func TestFormatParseError(t *testing.T) {
 			command: "gqlquery",
 			input:   "query.gql",
 			wantContain: []string{
-				"devtui gqlquery < query.gql",
+				"devtui gqlquery -f query.gql",
 			},
 			wantExclude: []string{},
 		},
@@ -111,7 +111,7 @@ func TestFormatParseError(t *testing.T) {
 			command: "cssfmt",
 			input:   "style.css",
 			wantContain: []string{
-				"devtui cssfmt < style.css",
+				"devtui cssfmt -f style.css",
 			},
 			wantExclude: []string{},
 		},
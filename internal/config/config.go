@@ -0,0 +1,209 @@
+// Package config loads devtui settings. Settings are TOML tables named after
+// commands, holding the defaults of their flags:
+//
+//	[cssfmt]
+//	indent = 4
+//
+//	[jsonschema.validate]
+//	from = "yaml"
+//
+// A global file under the XDG config directory is merged with the nearest
+// .devtui.toml found by walking up from the working directory, and with
+// environment variables such as DEVTUI_CSSFMT_INDENT. Later sources win.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ProjectFile is the name of the per-project configuration file.
+const ProjectFile = ".devtui.toml"
+
+// EnvPrefix starts the name of every environment variable holding a setting.
+const EnvPrefix = "DEVTUI_"
+
+// Setting is one configured value and where it came from: a file path or
+// an environment variable name.
+type Setting struct {
+	Key    string
+	Value  any
+	Source string
+}
+
+// Config holds the merged settings. A nil *Config has no settings, so
+// callers can use the accessors without checking whether loading succeeded.
+type Config struct {
+	settings map[string]Setting
+	environ  map[string]string
+	files    []string
+}
+
+// GlobalPath returns the path of the global configuration file.
+func GlobalPath() string {
+	return filepath.Join(xdg.ConfigHome, "devtui", "config.toml")
+}
+
+// FindProject returns the nearest ProjectFile in dir or its parents, or ""
+// when there is none.
+func FindProject(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load reads the global file, the project file for the working directory
+// and the process environment.
+func Load() (*Config, error) {
+	project := ""
+	if wd, err := os.Getwd(); err == nil {
+		project = FindProject(wd)
+	}
+	return LoadFiles(os.Environ(), GlobalPath(), project)
+}
+
+// LoadFiles merges the given files in order, skipping empty paths and files
+// that don't exist, and takes environment variables from environ.
+func LoadFiles(environ []string, paths ...string) (*Config, error) {
+	c := &Config{settings: map[string]Setting{}, environ: map[string]string{}}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var tables map[string]any
+		if err := toml.Unmarshal(data, &tables); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		c.files = append(c.files, path)
+		c.merge("", tables, path)
+	}
+
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && strings.HasPrefix(name, EnvPrefix) {
+			c.environ[name] = value
+		}
+	}
+	return c, nil
+}
+
+func (c *Config) merge(prefix string, table map[string]any, source string) {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			c.merge(key, nested, source)
+			continue
+		}
+		c.settings[key] = Setting{Key: key, Value: value, Source: source}
+	}
+}
+
+// EnvName returns the environment variable that overrides key, for example
+// DEVTUI_UUIDGENERATE_UUID_VERSION for "uuidgenerate.uuid-version".
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// Lookup returns the setting for a dotted key such as "cssfmt.indent".
+// Environment variables take precedence over files.
+func (c *Config) Lookup(key string) (Setting, bool) {
+	if c == nil {
+		return Setting{}, false
+	}
+	name := EnvName(key)
+	if value, ok := c.environ[name]; ok {
+		return Setting{Key: key, Value: value, Source: name}, true
+	}
+	setting, ok := c.settings[key]
+	return setting, ok
+}
+
+// Settings returns the settings read from files, sorted by key.
+func (c *Config) Settings() []Setting {
+	if c == nil {
+		return nil
+	}
+	settings := make([]Setting, 0, len(c.settings))
+	for _, setting := range c.settings {
+		settings = append(settings, setting)
+	}
+	slices.SortFunc(settings, func(a, b Setting) int { return strings.Compare(a.Key, b.Key) })
+	return settings
+}
+
+// Files returns the configuration files that were read, in merge order.
+func (c *Config) Files() []string {
+	if c == nil {
+		return nil
+	}
+	return slices.Clone(c.files)
+}
+
+// String returns the setting for key as a string, or def when unset.
+func (c *Config) String(key, def string) string {
+	if setting, ok := c.Lookup(key); ok {
+		return FormatValue(setting.Value)
+	}
+	return def
+}
+
+// Int returns the setting for key as an integer, or def when it is unset or
+// not an integer.
+func (c *Config) Int(key string, def int) int {
+	if setting, ok := c.Lookup(key); ok {
+		if n, err := strconv.Atoi(FormatValue(setting.Value)); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// Bool returns the setting for key as a boolean, or def when it is unset or
+// not a boolean.
+func (c *Config) Bool(key string, def bool) bool {
+	if setting, ok := c.Lookup(key); ok {
+		if b, err := strconv.ParseBool(FormatValue(setting.Value)); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// FormatValue renders a setting value the way it would be given as a flag.
+// Arrays are joined with commas.
+func FormatValue(value any) string {
+	if values, ok := value.([]any); ok {
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = FormatValue(v)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFilesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	global := writeFile(t, filepath.Join(dir, "global.toml"), `
+[cssfmt]
+indent = 4
+tab = true
+
+[jsonschema.validate]
+from = "yaml"
+`)
+	project := writeFile(t, filepath.Join(dir, "project.toml"), `
+[cssfmt]
+indent = 8
+
+[hash]
+algorithm = ["sha256", "blake3"]
+`)
+
+	cfg, err := LoadFiles([]string{"DEVTUI_CSSFMT_TAB=false", "HOME=/root"}, global, project, filepath.Join(dir, "missing.toml"))
+	if err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+
+	if got := cfg.Int("cssfmt.indent", 2); got != 8 {
+		t.Errorf("cssfmt.indent = %d, want 8 from the project file", got)
+	}
+	if got := cfg.Bool("cssfmt.tab", true); got {
+		t.Errorf("cssfmt.tab = %v, want false from the environment", got)
+	}
+	if setting, _ := cfg.Lookup("cssfmt.tab"); setting.Source != "DEVTUI_CSSFMT_TAB" {
+		t.Errorf("cssfmt.tab source = %q, want DEVTUI_CSSFMT_TAB", setting.Source)
+	}
+	if got := cfg.String("jsonschema.validate.from", ""); got != "yaml" {
+		t.Errorf("jsonschema.validate.from = %q, want yaml", got)
+	}
+	if got := cfg.String("hash.algorithm", ""); got != "sha256,blake3" {
+		t.Errorf("hash.algorithm = %q, want sha256,blake3", got)
+	}
+	if got := cfg.Int("xmlfmt.indent", 3); got != 3 {
+		t.Errorf("unset xmlfmt.indent = %d, want default 3", got)
+	}
+	if files := cfg.Files(); len(files) != 2 {
+		t.Errorf("Files() = %q, want the two existing files", files)
+	}
+}
+
+func TestLoadFilesInvalid(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "config.toml"), "[cssfmt\n")
+	if _, err := LoadFiles(nil, path); err == nil {
+		t.Fatal("LoadFiles() should fail on invalid TOML")
+	}
+}
+
+func TestNilConfig(t *testing.T) {
+	var cfg *Config
+	if got := cfg.Int("cssfmt.indent", 2); got != 2 {
+		t.Errorf("nil config Int() = %d, want default", got)
+	}
+	if _, ok := cfg.Lookup("cssfmt.indent"); ok {
+		t.Error("nil config should have no settings")
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	path := writeFile(t, filepath.Join(root, ProjectFile), "")
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindProject(nested); got != path {
+		t.Errorf("FindProject() = %q, want %q", got, path)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got := EnvName("uuidgenerate.uuid-version"); got != "DEVTUI_UUIDGENERATE_UUID_VERSION" {
+		t.Errorf("EnvName() = %q", got)
+	}
+}
@@ -0,0 +1,198 @@
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Format identifies a structured data format known to the converter.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatTOML  Format = "toml"
+	FormatXML   Format = "xml"
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
+	FormatTOON  Format = "toon"
+	FormatJSONL Format = "jsonl"
+)
+
+// Codec decodes a format into the common tree and encodes it back.
+type Codec struct {
+	Format     Format
+	Name       string
+	Aliases    []string
+	Extensions []string
+	Decode     func(content string) (any, error)
+	Encode     func(value any) (string, error)
+}
+
+var codecs = map[Format]Codec{}
+
+// Register adds a codec to the registry, replacing any codec of the same format.
+func Register(codec Codec) {
+	codecs[codec.Format] = codec
+}
+
+func init() {
+	Register(Codec{
+		Format:     FormatJSON,
+		Name:       "JSON",
+		Extensions: []string{".json"},
+		Decode:     decodeJSON,
+		Encode:     encodeJSON,
+	})
+	Register(Codec{
+		Format:     FormatYAML,
+		Name:       "YAML",
+		Aliases:    []string{"yml"},
+		Extensions: []string{".yaml", ".yml"},
+		Decode:     decodeYAML,
+		Encode:     encodeYAML,
+	})
+	Register(Codec{
+		Format:     FormatTOML,
+		Name:       "TOML",
+		Extensions: []string{".toml"},
+		Decode:     decodeTOML,
+		Encode:     encodeTOML,
+	})
+	Register(Codec{
+		Format:     FormatXML,
+		Name:       "XML",
+		Extensions: []string{".xml"},
+		Decode:     decodeXML,
+		Encode:     encodeXML,
+	})
+	Register(Codec{
+		Format:     FormatCSV,
+		Name:       "CSV",
+		Extensions: []string{".csv"},
+		Decode:     func(content string) (any, error) { return decodeDelimited(content, ',') },
+		Encode:     func(value any) (string, error) { return encodeDelimited(value, ',') },
+	})
+	Register(Codec{
+		Format:     FormatTSV,
+		Name:       "TSV",
+		Extensions: []string{".tsv", ".tab"},
+		Decode:     func(content string) (any, error) { return decodeDelimited(content, '\t') },
+		Encode:     func(value any) (string, error) { return encodeDelimited(value, '\t') },
+	})
+	Register(Codec{
+		Format:     FormatTOON,
+		Name:       "TOON",
+		Extensions: []string{".toon"},
+		Decode:     decodeTOON,
+		Encode:     encodeTOON,
+	})
+	Register(Codec{
+		Format:     FormatJSONL,
+		Name:       "JSON Lines",
+		Aliases:    []string{"ndjson", "jsonlines"},
+		Extensions: []string{".jsonl", ".ndjson"},
+		Decode:     decodeJSONL,
+		Encode:     encodeJSONL,
+	})
+}
+
+// Formats returns all registered formats sorted by name.
+func Formats() []Format {
+	formats := make([]Format, 0, len(codecs))
+	for format := range codecs {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+// Lookup returns the codec for a format name or alias (case-insensitive).
+func Lookup(name string) (Codec, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if codec, ok := codecs[Format(name)]; ok {
+		return codec, nil
+	}
+	for _, codec := range codecs {
+		for _, alias := range codec.Aliases {
+			if alias == name {
+				return codec, nil
+			}
+		}
+	}
+
+	names := make([]string, 0, len(codecs))
+	for _, format := range Formats() {
+		names = append(names, string(format))
+	}
+	return Codec{}, fmt.Errorf("unknown format %q (supported: %s)", name, strings.Join(names, ", "))
+}
+
+// FormatFromFilename returns the format registered for a file extension.
+func FormatFromFilename(name string) (Format, bool) {
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" {
+		return "", false
+	}
+	for _, codec := range codecs {
+		for _, candidate := range codec.Extensions {
+			if candidate == ext {
+				return codec.Format, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Decode parses content in the given format into the common tree.
+func Decode(content string, from Format) (any, error) {
+	codec, err := Lookup(string(from))
+	if err != nil {
+		return nil, err
+	}
+	value, err := codec.Decode(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s parsing error: %w", codec.Name, err)
+	}
+	return value, nil
+}
+
+// Encode renders a tree in the given format.
+func Encode(value any, to Format) (string, error) {
+	codec, err := Lookup(string(to))
+	if err != nil {
+		return "", err
+	}
+	content, err := codec.Encode(value)
+	if err != nil {
+		return "", fmt.Errorf("%s encoding error: %w", codec.Name, err)
+	}
+	return content, nil
+}
+
+// Options tunes a conversion.
+type Options struct {
+	// NoComments drops YAML and TOML comments instead of carrying them over
+	// to the output.
+	NoComments bool
+}
+
+// Convert converts content between any two registered formats, keeping key
+// order and, where the target format supports them, comments.
+func Convert(content string, from, to Format) (string, error) {
+	return ConvertWithOptions(content, from, to, Options{})
+}
+
+// ConvertWithOptions is Convert with explicit options.
+func ConvertWithOptions(content string, from, to Format, opts Options) (string, error) {
+	value, err := Decode(content, from)
+	if err != nil {
+		return "", err
+	}
+	if opts.NoComments {
+		StripComments(value)
+	}
+	return Encode(value, to)
+}
@@ -0,0 +1,676 @@
+package converter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleJSON = `{
+  "name": "devtui",
+  "version": 2,
+  "ratio": 0.5,
+  "enabled": true,
+  "tags": ["cli", "tui"],
+  "owner": {"login": "skatkov", "id": 42},
+  "releases": [
+    {"tag": "v1", "stable": true},
+    {"tag": "v2", "stable": false}
+  ]
+}`
+
+func decodeSample(t *testing.T) any {
+	t.Helper()
+	value, err := Decode(sampleJSON, FormatJSON)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return value
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Format{
+		"json":   FormatJSON,
+		"YAML":   FormatYAML,
+		"yml":    FormatYAML,
+		"ndjson": FormatJSONL,
+		"jsonl":  FormatJSONL,
+		" toon ": FormatTOON,
+	}
+	for name, want := range tests {
+		codec, err := Lookup(name)
+		if err != nil {
+			t.Errorf("Lookup(%q) error = %v", name, err)
+			continue
+		}
+		if codec.Format != want {
+			t.Errorf("Lookup(%q) = %s, want %s", name, codec.Format, want)
+		}
+	}
+
+	if _, err := Lookup("ini"); err == nil || !strings.Contains(err.Error(), "supported:") {
+		t.Errorf("Lookup(ini) error = %v, want list of supported formats", err)
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Format{
+		"config.yml":         FormatYAML,
+		"data/events.ndjson": FormatJSONL,
+		"Cargo.TOML":         FormatTOML,
+		"report.tsv":         FormatTSV,
+	}
+	for name, want := range tests {
+		got, ok := FormatFromFilename(name)
+		if !ok || got != want {
+			t.Errorf("FormatFromFilename(%q) = %s, %v; want %s", name, got, ok, want)
+		}
+	}
+	if _, ok := FormatFromFilename("README"); ok {
+		t.Error("FormatFromFilename(README) should not match")
+	}
+}
+
+// TestRoundTrip converts the sample through every tree-preserving format and
+// back to JSON, expecting the original document.
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := decodeSample(t)
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML, FormatTOON, FormatJSONL} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := Encode(want, format)
+			if err != nil {
+				t.Fatalf("Encode(%s) error = %v", format, err)
+			}
+			got, err := Decode(encoded, format)
+			if err != nil {
+				t.Fatalf("Decode(%s) error = %v\n%s", format, err, encoded)
+			}
+			if format == FormatJSONL {
+				got = got.([]any)[0]
+			}
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(want)
+				t.Fatalf("round trip through %s changed the document\n got: %s\nwant: %s\n%s", format, gotJSON, wantJSON, encoded)
+			}
+		})
+	}
+}
+
+func TestConvertPreservesKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	input := `{"zeta": 1, "alpha": {"yankee": true, "bravo": false}, "mike": "m"}`
+
+	yamlOut, err := Convert(input, FormatJSON, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inOrder(yamlOut, "zeta", "alpha", "yankee", "bravo", "mike") {
+		t.Errorf("YAML output reordered keys:\n%s", yamlOut)
+	}
+
+	tomlOut, err := Convert(yamlOut, FormatYAML, FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inOrder(tomlOut, "zeta", "mike", "[alpha]", "yankee", "bravo") {
+		t.Errorf("TOML output reordered keys:\n%s", tomlOut)
+	}
+
+	jsonOut, err := Convert(tomlOut, FormatTOML, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inOrder(jsonOut, "zeta", "mike", "alpha", "yankee", "bravo") {
+		t.Errorf("JSON output reordered keys:\n%s", jsonOut)
+	}
+}
+
+func inOrder(s string, parts ...string) bool {
+	offset := 0
+	for _, part := range parts {
+		idx := strings.Index(s[offset:], part)
+		if idx < 0 {
+			return false
+		}
+		offset += idx + len(part)
+	}
+	return true
+}
+
+func TestEncodeTOML(t *testing.T) {
+	t.Parallel()
+
+	got, err := Encode(decodeSample(t), FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `name = "devtui"
+version = 2
+ratio = 0.5
+enabled = true
+tags = ["cli", "tui"]
+
+[owner]
+login = "skatkov"
+id = 42
+
+[[releases]]
+tag = "v1"
+stable = true
+
+[[releases]]
+tag = "v2"
+stable = false
+`
+	if got != want {
+		t.Fatalf("Encode(TOML) =\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := Encode([]any{int64(1)}, FormatTOML); err == nil {
+		t.Error("expected error for top-level array")
+	}
+}
+
+func TestDecodeTOMLFeatures(t *testing.T) {
+	t.Parallel()
+
+	input := `
+title = "t"
+a.b.c = 1_000
+hex = 0xff
+when = 1979-05-27T07:32:00Z
+day = 1979-05-27
+
+[server]
+ports = [8000, 8001]
+inline = { x = 1, y = "two" }
+
+[[fruit]]
+name = "apple"
+[fruit.physical]
+color = "red"
+
+[[fruit]]
+name = "banana"
+`
+	got, err := Convert(input, FormatTOML, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"c": 1000`,
+		`"hex": 255`,
+		`"when": "1979-05-27T07:32:00Z"`,
+		`"day": "1979-05-27"`,
+		`"y": "two"`,
+		`"color": "red"`,
+		`"name": "banana"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("TOML→JSON output missing %s:\n%s", want, got)
+		}
+	}
+	if !inOrder(got, "title", `"a"`, "hex", "when", "day", "server", "fruit") {
+		t.Errorf("TOML→JSON reordered keys:\n%s", got)
+	}
+}
+
+func TestYAMLAliasesAndMerge(t *testing.T) {
+	t.Parallel()
+
+	input := `
+defaults: &defaults
+  adapter: postgres
+  host: localhost
+development:
+  <<: *defaults
+  host: dev.local
+`
+	got, err := Convert(input, FormatYAML, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := Decode(got, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev, _ := value.(*Object).Get("development")
+	adapter, _ := dev.(*Object).Get("adapter")
+	host, _ := dev.(*Object).Get("host")
+	if adapter != "postgres" || host != "dev.local" {
+		t.Fatalf("merge key not resolved: %s", got)
+	}
+}
+
+func TestXMLCodec(t *testing.T) {
+	t.Parallel()
+
+	input := `<?xml version="1.0"?>
+<catalog id="c1">
+  <book lang="en">Go</book>
+  <book>Rust</book>
+  <empty/>
+</catalog>`
+
+	value, err := Decode(input, FormatXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Encode(value, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"-id": "c1"`, `"-lang": "en"`, `"#text": "Go"`, `"Rust"`, `"empty": ""`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("XML→JSON missing %s:\n%s", want, got)
+		}
+	}
+
+	xmlOut, err := Encode(value, FormatXML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<catalog id="c1">
+  <book lang="en">Go</book>
+  <book>Rust</book>
+  <empty/>
+</catalog>
+`
+	if xmlOut != want {
+		t.Errorf("Encode(XML) =\n%s\nwant:\n%s", xmlOut, want)
+	}
+
+	for _, input := range []string{`<a><b></a>`, `<a/><b/>`, ``} {
+		if _, err := Decode(input, FormatXML); err == nil {
+			t.Errorf("Decode(%q) expected error", input)
+		}
+	}
+}
+
+func TestEncodeXMLWrapsDocuments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`{}`, "<doc/>\n"},
+		{`{"a": 1, "b": 2}`, "<doc>\n  <a>1</a>\n  <b>2</b>\n</doc>\n"},
+		{`[1, 2]`, "<doc>\n  <item>1</item>\n  <item>2</item>\n</doc>\n"},
+		{`{"items": ["x", "y"]}`, "<doc>\n  <items>x</items>\n  <items>y</items>\n</doc>\n"},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.input, FormatJSON, FormatXML)
+		if err != nil {
+			t.Errorf("Convert(%s) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Convert(%s) =\n%s\nwant:\n%s", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := Convert(`{"0":""}`, FormatJSON, FormatXML); err == nil {
+		t.Error("expected error for invalid element name")
+	}
+}
+
+func TestDelimitedCodec(t *testing.T) {
+	t.Parallel()
+
+	input := "id,user.name,tags[0],tags[1]\n1,alice,a,b\n2,bob,c,d\n"
+	value, err := Decode(input, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Encode(value, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"name": "alice"`) || !strings.Contains(got, `"tags": [`) {
+		t.Fatalf("CSV→JSON nesting not applied:\n%s", got)
+	}
+
+	csvOut, err := Encode(value, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if csvOut != input {
+		t.Errorf("CSV round trip =\n%s\nwant:\n%s", csvOut, input)
+	}
+
+	tsvOut, err := Convert(`[{"a": 1, "b": "x y"}, {"b": "z", "c": true}]`, FormatJSON, FormatTSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a\tb\tc\n1\tx y\t\n\tz\ttrue\n"; tsvOut != want {
+		t.Errorf("JSON→TSV = %q, want %q", tsvOut, want)
+	}
+
+	if _, err := Convert(`"scalar"`, FormatJSON, FormatCSV); err == nil {
+		t.Error("expected error for scalar CSV encoding")
+	}
+}
+
+func TestDecodeTOON(t *testing.T) {
+	t.Parallel()
+
+	input := `users[2]{id,name}:
+  1,Alice
+  2,"Bob, Jr."
+tags[3|]: a|b|"c|d"
+meta:
+  count: 2
+  empty[0]:
+items[2]:
+  - id: 1
+    extra: x
+  - [2]: 1,2`
+
+	got, err := Convert(input, FormatTOON, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "users": [
+    {
+      "id": 1,
+      "name": "Alice"
+    },
+    {
+      "id": 2,
+      "name": "Bob, Jr."
+    }
+  ],
+  "tags": [
+    "a",
+    "b",
+    "c|d"
+  ],
+  "meta": {
+    "count": 2,
+    "empty": []
+  },
+  "items": [
+    {
+      "id": 1,
+      "extra": "x"
+    },
+    [
+      1,
+      2
+    ]
+  ]
+}
+`
+	if got != want {
+		t.Fatalf("TOON→JSON =\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := Decode("items[3]: a,b", FormatTOON); err == nil {
+		t.Error("expected length mismatch error")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  Format
+	}{
+		{"json object", `{"a": 1}`, FormatJSON},
+		{"json array", `[1, 2]`, FormatJSON},
+		{"json lines", "{\"a\": 1}\n{\"a\": 2}\n", FormatJSONL},
+		{"xml", `<root/>`, FormatXML},
+		{"toml", "title = \"x\"\n[server]\nport = 80\n", FormatTOML},
+		{"toml table first", "[server]\nport = 80\n", FormatTOML},
+		{"yaml", "name: devtui\nitems:\n  - a\n", FormatYAML},
+		{"toon", "users[1]{id,name}:\n  1,Alice\n", FormatTOON},
+		{"csv", "name,age\nAlice,30\n", FormatCSV},
+		{"tsv", "name\tage\nAlice\t30\n", FormatTSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Detect(tt.input)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Detect() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Detect("   "); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
+
+// TestConvertAllPairs checks that every registered pair converts a tabular
+// document, which all formats can represent.
+func TestConvertAllPairs(t *testing.T) {
+	t.Parallel()
+
+	input := `[{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}]`
+	for _, from := range Formats() {
+		source, err := Convert(input, FormatJSON, from)
+		if from == FormatTOML {
+			source, err = Convert(`{"rows": `+input+`}`, FormatJSON, from)
+		}
+		if err != nil {
+			t.Fatalf("Convert(json→%s) error = %v", from, err)
+		}
+		for _, to := range Formats() {
+			if to == FormatTOML && from != FormatTOML && from != FormatXML {
+				continue
+			}
+			if _, err := Convert(source, from, to); err != nil {
+				t.Errorf("Convert(%s→%s) error = %v\n%s", from, to, err, source)
+			}
+		}
+	}
+}
+
+func TestConvertKeepsBigIntegers(t *testing.T) {
+	t.Parallel()
+
+	const id = "12345678901234567890"
+	input := `{"id": ` + id + `, "ids": [-` + id + `]}`
+	for _, to := range Formats() {
+		output, err := Convert(input, FormatJSON, to)
+		if err != nil {
+			t.Fatalf("Convert(json→%s) error = %v", to, err)
+		}
+		if !strings.Contains(output, id) || strings.Contains(output, "e+19") {
+			t.Errorf("Convert(json→%s) lost the digits of %s:\n%s", to, id, output)
+		}
+	}
+
+	output, err := Convert("id: "+id+"\n", FormatYAML, FormatJSON)
+	if err != nil {
+		t.Fatalf("Convert(yaml→json) error = %v", err)
+	}
+	if !strings.Contains(output, `"id": `+id) {
+		t.Errorf("Convert(yaml→json) = %s, want id %s", output, id)
+	}
+}
+
+func TestConvertKeepsComments(t *testing.T) {
+	t.Parallel()
+
+	yamlDoc := `# Application settings
+
+# display name
+name: devtui # short
+server: # listener
+  # bind address
+  host: localhost
+  port: 8080
+  # trailing note
+debug: false
+`
+	got, err := Convert(yamlDoc, FormatYAML, FormatTOML)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := `# Application settings
+# display name
+name = "devtui" # short
+# trailing note
+debug = false
+
+[server] # listener
+# bind address
+host = "localhost"
+port = 8080
+`
+	if got != want {
+		t.Fatalf("Convert() =\n%s\nwant:\n%s", got, want)
+	}
+
+	back, err := Convert(got, FormatTOML, FormatYAML)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	for _, comment := range []string{"# display name\nname: devtui # short", "server: # listener", "    # bind address\n    host: localhost"} {
+		if !strings.Contains(back, comment) {
+			t.Errorf("TOML to YAML lost %q:\n%s", comment, back)
+		}
+	}
+
+	empty, err := Convert("[user] # none\n", FormatTOML, FormatYAML)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if want := "user: {} # none\n"; empty != want {
+		t.Errorf("empty table = %q, want %q", empty, want)
+	}
+}
+
+func TestConvertKeepsItemComments(t *testing.T) {
+	t.Parallel()
+
+	yamlDoc := `servers:
+    # primary
+    - alpha # main
+    # backup
+    # second line
+    - beta
+items:
+    - name: x
+    # second
+    - name: "y"
+`
+	got, err := Convert(yamlDoc, FormatYAML, FormatTOML)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := `servers = [
+  # primary
+  "alpha", # main
+  # backup
+  # second line
+  "beta",
+]
+
+[[items]]
+name = "x"
+
+# second
+[[items]]
+name = "y"
+`
+	if got != want {
+		t.Fatalf("Convert() =\n%s\nwant:\n%s", got, want)
+	}
+
+	back, err := Convert(got, FormatTOML, FormatYAML)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if back != yamlDoc {
+		t.Fatalf("TOML to YAML =\n%s\nwant:\n%s", back, yamlDoc)
+	}
+
+	// Comments may also sit after the opening bracket, and strings may hold
+	// "#" and span lines.
+	value, err := Decode("a = [ # open\n  \"b#c\", # one\n  \"\"\"d\n# e\"\"\", [1, 2] # two\n  # foot\n]\n", FormatTOML)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	wantItems := []ItemComment{{Comment: "open", LineComment: "one"}, {}, {LineComment: "two"}}
+	if got := value.(*Object).Members[0].ItemComments; !reflect.DeepEqual(got, wantItems) {
+		t.Fatalf("ItemComments = %+v, want %+v", got, wantItems)
+	}
+}
+
+func TestTOMLComments(t *testing.T) {
+	t.Parallel()
+
+	doc := "# owner\nowner = \"a # b\" # quoted hash\n\n# tables\n[[items]] # first item\nid = 1\n\n[[items]]\nid = 2\n"
+	value, err := Decode(doc, FormatTOML)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	root := value.(*Object)
+	if got := root.Members[0]; got.Comment != "owner" || got.LineComment != "quoted hash" {
+		t.Fatalf("owner comments = %q, %q", got.Comment, got.LineComment)
+	}
+	if got := root.Members[1]; got.Comment != "tables" || got.LineComment != "first item" {
+		t.Fatalf("items comments = %q, %q", got.Comment, got.LineComment)
+	}
+
+	got, err := Encode(value, FormatTOML)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if got != doc {
+		t.Fatalf("Encode() =\n%s\nwant:\n%s", got, doc)
+	}
+}
+
+func TestConvertWithOptionsNoComments(t *testing.T) {
+	t.Parallel()
+
+	got, err := ConvertWithOptions("# note\na: 1 # one\nb:\n  # nested\n  c: 2\n", FormatYAML, FormatYAML, Options{NoComments: true})
+	if err != nil {
+		t.Fatalf("ConvertWithOptions() error = %v", err)
+	}
+	if want := "a: 1\nb:\n    c: 2\n"; got != want {
+		t.Fatalf("ConvertWithOptions() = %q, want %q", got, want)
+	}
+}
+
+func TestLegacyConvertersKeepKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	got, err := JSONToYAML(`{"zeta": 1, "alpha": {"b": 2, "a": 1}}`)
+	if err != nil {
+		t.Fatalf("JSONToYAML() error = %v", err)
+	}
+	if want := "zeta: 1\nalpha:\n    b: 2\n    a: 1\n"; got != want {
+		t.Fatalf("JSONToYAML() = %q, want %q", got, want)
+	}
+
+	got, err = TOMLToJSON("zeta = 1\nalpha = 2\n")
+	if err != nil {
+		t.Fatalf("TOMLToJSON() error = %v", err)
+	}
+	if want := "{\n  \"zeta\": 1,\n  \"alpha\": 2\n}\n"; got != want {
+		t.Fatalf("TOMLToJSON() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package converter
+
+import "strings"
+
+// StripComments removes every comment from a tree in place.
+func StripComments(value any) {
+	switch v := value.(type) {
+	case *Object:
+		for i := range v.Members {
+			v.Members[i].Comment = ""
+			v.Members[i].LineComment = ""
+			v.Members[i].ItemComments = nil
+			StripComments(v.Members[i].Value)
+		}
+	case []any:
+		for _, item := range v {
+			StripComments(item)
+		}
+	}
+}
+
+// commentText turns raw "# ..." comment lines into plain text, one line per
+// comment line. Blank lines are dropped.
+func commentText(raw string) string {
+	var lines []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		line = strings.TrimPrefix(line, "#")
+		line = strings.TrimPrefix(line, " ")
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentMarkup renders comment text as "# ..." lines without a trailing
+// newline.
+func commentMarkup(text string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "#"
+		} else {
+			lines[i] = "# " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func joinComments(comments ...string) string {
+	var parts []string
+	for _, comment := range comments {
+		if comment != "" {
+			parts = append(parts, comment)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
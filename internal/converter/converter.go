@@ -1,179 +1,37 @@
 package converter
 
-import (
-	"bytes"
-	"encoding/json"
-	"encoding/xml"
-	"errors"
-	"fmt"
-	"io"
-
-	"github.com/clbanning/mxj/v2"
-	"github.com/pelletier/go-toml/v2"
-	"gopkg.in/yaml.v3"
-)
+// The pairwise helpers below predate the codec registry and are kept for the
+// single-purpose commands and TUIs. They share its order and comment
+// preserving tree.
 
 func YAMLToJSON(yamlContent string) (string, error) {
-	var data any
-
-	if err := yaml.Unmarshal([]byte(yamlContent), &data); err != nil {
-		return "", fmt.Errorf("YAML parsing error: %w", err)
-	}
-
-	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(data); err != nil {
-		return "", fmt.Errorf("JSON encoding error: %w", err)
-	}
-
-	return buf.String(), nil
+	return Convert(yamlContent, FormatYAML, FormatJSON)
 }
 
 func JSONToYAML(jsonContent string) (string, error) {
-	var data any
-
-	if err := json.Unmarshal([]byte(jsonContent), &data); err != nil {
-		return "", fmt.Errorf("JSON parsing error: %w", err)
-	}
-
-	var buf bytes.Buffer
-	encoder := yaml.NewEncoder(&buf)
-	if err := encoder.Encode(data); err != nil {
-		return "", fmt.Errorf("YAML encoding error: %w", err)
-	}
-
-	return buf.String(), nil
+	return Convert(jsonContent, FormatJSON, FormatYAML)
 }
 
 func TOMLToJSON(tomlContent string) (string, error) {
-	var v any
-
-	err := toml.Unmarshal([]byte(tomlContent), &v)
-	if err != nil {
-		return "", fmt.Errorf("TOML parsing error: %w", err)
-	}
-
-	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
-	encoder.SetIndent("", "  ")
-	err = encoder.Encode(v)
-	if err != nil {
-		return "", fmt.Errorf("JSON encoding error: %w", err)
-	}
-
-	return buf.String(), nil
+	return Convert(tomlContent, FormatTOML, FormatJSON)
 }
 
 func JSONToTOML(jsonContent string) (string, error) {
-	var v any
-
-	err := json.Unmarshal([]byte(jsonContent), &v)
-	if err != nil {
-		return "", fmt.Errorf("JSON parsing error: %w", err)
-	}
-
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	err = encoder.Encode(v)
-	if err != nil {
-		return "", fmt.Errorf("TOML encoding error: %w", err)
-	}
-
-	tomlContent := buf.String()
-	var validation any
-	if err := toml.Unmarshal([]byte(tomlContent), &validation); err != nil {
-		return "", fmt.Errorf("TOML encoding error: %w", err)
-	}
-
-	return tomlContent, nil
+	return Convert(jsonContent, FormatJSON, FormatTOML)
 }
 
 func XMLToJSON(xmlContent string) (string, error) {
-	mv, err := mxj.NewMapXml([]byte(xmlContent))
-	if err != nil {
-		return "", fmt.Errorf("XML parsing error: %w", err)
-	}
-
-	jsonBytes, err := json.MarshalIndent(mv, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("JSON encoding error: %w", err)
-	}
-
-	return string(jsonBytes), nil
+	return Convert(xmlContent, FormatXML, FormatJSON)
 }
 
 func JSONToXML(jsonContent string) (string, error) {
-	mv, err := mxj.NewMapJson([]byte(jsonContent))
-	if err != nil {
-		return "", fmt.Errorf("JSON parsing error: %w", err)
-	}
-
-	xmlBytes, err := mv.XmlIndent("", "  ")
-	if err != nil {
-		return "", fmt.Errorf("XML encoding error: %w", err)
-	}
-
-	if len(bytes.TrimSpace(xmlBytes)) == 0 {
-		return "", errors.New("XML encoding error: empty XML output")
-	}
-
-	decoder := xml.NewDecoder(bytes.NewReader(xmlBytes))
-	seenStart := false
-	for {
-		tok, err := decoder.Token()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return "", fmt.Errorf("XML encoding error: %w", err)
-		}
-
-		if _, ok := tok.(xml.StartElement); ok {
-			seenStart = true
-		}
-	}
-
-	if !seenStart {
-		return "", errors.New("XML encoding error: empty XML output")
-	}
-
-	var buf bytes.Buffer
-	buf.Write(xmlBytes)
-	return buf.String(), nil
+	return Convert(jsonContent, FormatJSON, FormatXML)
 }
 
 func YAMLToTOML(yamlContent string) (string, error) {
-	jsonStr, err := YAMLToJSON(yamlContent)
-	if err != nil {
-		return "", err
-	}
-
-	var data any
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
-		return "", fmt.Errorf("JSON parsing error: %w", err)
-	}
-
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(data); err != nil {
-		return "", fmt.Errorf("TOML encoding error: %w", err)
-	}
-
-	tomlContent := buf.String()
-	var validation any
-	if err := toml.Unmarshal([]byte(tomlContent), &validation); err != nil {
-		return "", fmt.Errorf("TOML encoding error: %w", err)
-	}
-
-	return tomlContent, nil
+	return Convert(yamlContent, FormatYAML, FormatTOML)
 }
 
 func TOMLToYAML(tomlContent string) (string, error) {
-	jsonStr, err := TOMLToJSON(tomlContent)
-	if err != nil {
-		return "", err
-	}
-
-	return JSONToYAML(jsonStr)
+	return Convert(tomlContent, FormatTOML, FormatYAML)
 }
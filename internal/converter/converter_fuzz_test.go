@@ -211,3 +211,33 @@ func FuzzTOMLToYAML(f *testing.F) {
 		}
 	})
 }
+
+func FuzzDetectAndConvertToJSON(f *testing.F) {
+	f.Add("name: Alice\nage: 30\n")
+	f.Add("name = \"Alice\"\n")
+	f.Add("users[1]{id,name}:\n  1,Alice\n")
+	f.Add("a,b\n1,2\n")
+	f.Add("<root><a>1</a></root>")
+	f.Add("")
+	addStringSeedFiles(f, "example.json", "example.yaml", "example.toml")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		if len(input) > 4096 {
+			t.Skip()
+		}
+
+		format, err := Detect(input)
+		if err != nil {
+			return
+		}
+
+		output, err := Convert(input, format, FormatJSON)
+		if err != nil {
+			return
+		}
+
+		if !json.Valid([]byte(output)) {
+			t.Fatalf("Convert(%s→json) returned invalid JSON: %q", format, output)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Delimited data uses the header conventions of csv2json: "a.b" addresses a
+// nested object and "a[0]" an array element, in both directions.
+
+func decodeDelimited(content string, delimiter rune) (any, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	if delimiter == '\t' {
+		reader.LazyQuotes = true
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty input")
+	}
+
+	header := rows[0]
+	records := make([]any, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := NewObject()
+		for i, value := range row {
+			if i >= len(header) {
+				continue
+			}
+			if err := setDelimitedPath(record, header[i], value); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func setDelimitedPath(record *Object, column, value string) error {
+	segments := strings.Split(column, ".")
+	current := record
+	for index, segment := range segments {
+		key, arrayIndex := splitIndex(segment)
+		last := index == len(segments)-1
+
+		if arrayIndex < 0 {
+			if last {
+				if existing, ok := current.Get(key); ok && !isScalar(existing) {
+					return fmt.Errorf("invalid header %q: key %q is used as both scalar and nested value", column, key)
+				}
+				current.Set(key, value)
+				return nil
+			}
+			existing, ok := current.Get(key)
+			if !ok {
+				existing = NewObject()
+				current.Set(key, existing)
+			}
+			next, ok := existing.(*Object)
+			if !ok {
+				return fmt.Errorf("invalid header %q: key %q is used as both scalar and object", column, key)
+			}
+			current = next
+			continue
+		}
+
+		existing, ok := current.Get(key)
+		if !ok {
+			existing = []any{}
+		}
+		items, ok := existing.([]any)
+		if !ok {
+			return fmt.Errorf("invalid header %q: key %q is used as both object and array", column, key)
+		}
+		if last {
+			current.Set(key, append(items, value))
+			return nil
+		}
+		for arrayIndex >= len(items) {
+			items = append(items, NewObject())
+		}
+		current.Set(key, items)
+		next, ok := items[arrayIndex].(*Object)
+		if !ok {
+			return fmt.Errorf("invalid header %q: key %q[%d] is used as both scalar and object", column, key, arrayIndex)
+		}
+		current = next
+	}
+	return nil
+}
+
+func splitIndex(segment string) (string, int) {
+	open := strings.Index(segment, "[")
+	if open < 0 || !strings.HasSuffix(segment, "]") {
+		return segment, -1
+	}
+	index, err := strconv.Atoi(segment[open+1 : len(segment)-1])
+	if err != nil || index < 0 {
+		return segment, -1
+	}
+	return segment[:open], index
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case *Object, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+func encodeDelimited(value any, delimiter rune) (string, error) {
+	var records []any
+	switch v := value.(type) {
+	case []any:
+		records = v
+	case *Object:
+		records = []any{v}
+	default:
+		return "", errors.New("expected an array of records or an object")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = delimiter
+
+	if len(records) > 0 && allArrays(records) {
+		for _, record := range records {
+			row := make([]string, 0, len(record.([]any)))
+			for _, cell := range record.([]any) {
+				if !isScalar(cell) {
+					return "", errors.New("nested arrays cannot be written as rows")
+				}
+				row = append(row, scalarString(cell))
+			}
+			if err := writer.Write(row); err != nil {
+				return "", err
+			}
+		}
+		writer.Flush()
+		return buf.String(), writer.Error()
+	}
+
+	var header []string
+	seen := map[string]bool{}
+	flattened := make([]map[string]string, 0, len(records))
+	for _, record := range records {
+		row := map[string]string{}
+		var columns []string
+		if object, ok := record.(*Object); ok {
+			flattenRecord(object, "", row, &columns)
+		} else if isScalar(record) {
+			row["value"] = scalarString(record)
+			columns = []string{"value"}
+		} else {
+			return "", errors.New("records must be objects or scalars")
+		}
+		for _, column := range columns {
+			if !seen[column] {
+				seen[column] = true
+				header = append(header, column)
+			}
+		}
+		flattened = append(flattened, row)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, row := range flattened {
+		values := make([]string, len(header))
+		for i, column := range header {
+			values[i] = row[column]
+		}
+		if err := writer.Write(values); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+func allArrays(records []any) bool {
+	for _, record := range records {
+		if _, ok := record.([]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func flattenRecord(value any, prefix string, row map[string]string, columns *[]string) {
+	switch v := value.(type) {
+	case *Object:
+		for _, member := range v.Members {
+			key := member.Key
+			if prefix != "" {
+				key = prefix + "." + member.Key
+			}
+			flattenRecord(member.Value, key, row, columns)
+		}
+	case []any:
+		for i, item := range v {
+			flattenRecord(item, fmt.Sprintf("%s[%d]", prefix, i), row, columns)
+		}
+	default:
+		if _, exists := row[prefix]; !exists {
+			*columns = append(*columns, prefix)
+		}
+		row[prefix] = scalarString(v)
+	}
+}
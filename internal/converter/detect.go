@@ -0,0 +1,80 @@
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	toonHeaderLine = regexp.MustCompile(`(?m)^\s*(?:- )?(?:"[^"]*"|[A-Za-z_][\w.]*)?\[#?\d+[\t|]?\](?:\{[^}]*\})?:`)
+	tomlAssignment = regexp.MustCompile(`(?m)^\s*[A-Za-z0-9_."'-]+\s*=`)
+	tomlTableLine  = regexp.MustCompile(`(?m)^\s*\[\[?[A-Za-z0-9_."' -]+\]\]?\s*$`)
+)
+
+// Detect guesses the format of content. Formats are tried from the most to
+// the least strict, so JSON wins over YAML and YAML over CSV.
+func Detect(content string) (Format, error) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return "", errors.New("cannot detect format of empty input")
+	}
+
+	if strings.HasPrefix(trimmed, "<") {
+		return FormatXML, nil
+	}
+	if json.Valid([]byte(trimmed)) {
+		return FormatJSON, nil
+	}
+	if isJSONLines(trimmed) {
+		return FormatJSONL, nil
+	}
+	if toonHeaderLine.MatchString(trimmed) {
+		if _, err := decodeTOON(trimmed); err == nil {
+			return FormatTOON, nil
+		}
+	}
+	if tomlAssignment.MatchString(trimmed) || tomlTableLine.MatchString(trimmed) {
+		var v any
+		if toml.Unmarshal([]byte(trimmed), &v) == nil {
+			return FormatTOML, nil
+		}
+	}
+
+	var node yaml.Node
+	if yaml.Unmarshal([]byte(trimmed), &node) == nil && len(node.Content) > 0 {
+		kind := node.Content[0].Kind
+		if kind == yaml.MappingNode || kind == yaml.SequenceNode {
+			return FormatYAML, nil
+		}
+	}
+
+	firstLine, _, _ := strings.Cut(trimmed, "\n")
+	switch {
+	case strings.Contains(firstLine, "\t"):
+		return FormatTSV, nil
+	case strings.Contains(firstLine, ","):
+		return FormatCSV, nil
+	}
+
+	return "", errors.New("cannot detect input format")
+}
+
+func isJSONLines(content string) bool {
+	lines := 0
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !json.Valid([]byte(line)) {
+			return false
+		}
+		lines++
+	}
+	return lines > 1
+}
@@ -0,0 +1,149 @@
+package converter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func decodeJSON(content string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return value, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("unexpected end of input")
+		}
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			object := NewObject()
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected object key, got %v", keyToken)
+				}
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object.Set(key, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return object, nil
+		case '[':
+			items := []any{}
+			for decoder.More() {
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return items, nil
+		default:
+			return nil, fmt.Errorf("unexpected delimiter %q", t)
+		}
+	case json.Number:
+		return parseNumber(t.String())
+	default:
+		return t, nil
+	}
+}
+
+// parseNumber keeps integers as int64 and falls back to float64. Integers
+// beyond int64 stay json.Number, so their digits survive every codec.
+func parseNumber(s string) (any, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return json.Number(s), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return f, nil
+}
+
+func encodeJSON(value any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(jsonValue(value)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func decodeJSONL(content string) (any, error) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	records := []any{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		value, err := decodeJSON(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func encodeJSONL(value any) (string, error) {
+	records, ok := value.([]any)
+	if !ok {
+		records = []any{value}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(jsonValue(record)); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/skatkov/devtui/internal/ndjson"
+)
+
+// JSONLinesToDelimited streams JSON Lines records from r to w as CSV or TSV
+// rows using the header conventions of the delimited codec. The header is
+// taken from columns or, when empty, from the first record; only one record
+// is held in memory at a time, so later records must not introduce new
+// columns.
+func JSONLinesToDelimited(r io.Reader, w io.Writer, delimiter rune, columns []string) error {
+	scanner := ndjson.NewScanner(r)
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	var header []string
+	var known map[string]bool
+	if len(columns) > 0 {
+		header = columns
+	}
+
+	for scanner.Scan() {
+		value, err := decodeJSON(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", scanner.Line(), err)
+		}
+
+		row := map[string]string{}
+		var recordColumns []string
+		switch v := value.(type) {
+		case *Object:
+			flattenRecord(v, "", row, &recordColumns)
+		default:
+			if !isScalar(v) {
+				return fmt.Errorf("line %d: records must be objects or scalars", scanner.Line())
+			}
+			row["value"] = scalarString(v)
+			recordColumns = []string{"value"}
+		}
+
+		if header == nil {
+			header = recordColumns
+		}
+		if known == nil {
+			known = make(map[string]bool, len(header))
+			for _, column := range header {
+				known[column] = true
+			}
+			if err := writer.Write(header); err != nil {
+				return err
+			}
+		}
+		if len(columns) == 0 {
+			for _, column := range recordColumns {
+				if !known[column] {
+					return fmt.Errorf("line %d: column %q is not in the header taken from the first record; list all columns explicitly", scanner.Line(), column)
+				}
+			}
+		}
+
+		values := make([]string, len(header))
+		for i, column := range header {
+			values[i] = row[column]
+		}
+		if err := writer.Write(values); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if known == nil {
+		return errors.New("no records in input")
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// DelimitedToJSONLines streams CSV or TSV rows from r to w as one JSON
+// object per line. Values stay strings, as in the delimited codec.
+func DelimitedToJSONLines(r io.Reader, w io.Writer, delimiter rune) error {
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	if delimiter == '\t' {
+		reader.LazyQuotes = true
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return errors.New("empty input")
+	}
+	if err != nil {
+		return err
+	}
+	header = append([]string(nil), header...)
+
+	out := bufio.NewWriter(w)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		record := NewObject()
+		for i, value := range row {
+			if i >= len(header) {
+				continue
+			}
+			if err := setDelimitedPath(record, header[i], value); err != nil {
+				return err
+			}
+		}
+		line, err := record.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
@@ -0,0 +1,73 @@
+package converter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONLinesToDelimited(t *testing.T) {
+	t.Parallel()
+
+	input := `{"id":1,"user":{"name":"Alice"},"tags":["a","b"]}
+
+{"id":2,"user":{"name":"Bob"}}
+`
+	var out bytes.Buffer
+	if err := JSONLinesToDelimited(strings.NewReader(input), &out, ',', nil); err != nil {
+		t.Fatalf("JSONLinesToDelimited() error = %v", err)
+	}
+	want := "id,user.name,tags[0],tags[1]\n1,Alice,a,b\n2,Bob,,\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestJSONLinesToDelimitedColumns(t *testing.T) {
+	t.Parallel()
+
+	input := "{\"a\":1,\"b\":2}\n{\"a\":3,\"c\":4}\n"
+
+	var out bytes.Buffer
+	if err := JSONLinesToDelimited(strings.NewReader(input), &out, '\t', []string{"c", "a"}); err != nil {
+		t.Fatalf("JSONLinesToDelimited() error = %v", err)
+	}
+	if want := "c\ta\n\t1\n4\t3\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	err := JSONLinesToDelimited(strings.NewReader(input), &out, ',', nil)
+	if err == nil || !strings.Contains(err.Error(), `line 2: column "c"`) {
+		t.Fatalf("expected an error about the new column, got %v", err)
+	}
+}
+
+func TestJSONLinesToDelimitedInvalidRecord(t *testing.T) {
+	t.Parallel()
+
+	err := JSONLinesToDelimited(strings.NewReader("{\"a\":1}\n{oops\n"), &bytes.Buffer{}, ',', nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Fatalf("expected a line 2 error, got %v", err)
+	}
+}
+
+func TestDelimitedToJSONLines(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	err := DelimitedToJSONLines(strings.NewReader("id,user.name,tags[0]\n1,Alice,x\n2,Bob,y\n"), &out, ',')
+	if err != nil {
+		t.Fatalf("DelimitedToJSONLines() error = %v", err)
+	}
+	want := `{"id":"1","user":{"name":"Alice"},"tags":["x"]}
+{"id":"2","user":{"name":"Bob"},"tags":["y"]}
+`
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	if err := DelimitedToJSONLines(strings.NewReader(""), &out, ','); err == nil {
+		t.Fatal("expected an error for empty input")
+	}
+}
@@ -0,0 +1,618 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// decodeTOML validates the document with go-toml and then walks its AST, so
+// tables and keys keep their document order. Comment lines are attached to
+// the key or table header that follows them.
+func decodeTOML(content string) (any, error) {
+	var validation any
+	if err := toml.Unmarshal([]byte(content), &validation); err != nil {
+		return nil, err
+	}
+
+	data := []byte(content)
+	root := NewObject()
+	current := root
+	var pending []string
+
+	parser := unstable.Parser{KeepComments: true}
+	parser.Reset(data)
+	for parser.NextExpression() {
+		expr := parser.Expression()
+		switch expr.Kind {
+		case unstable.Comment:
+			pending = append(pending, commentText(string(expr.Data)))
+		case unstable.KeyValue:
+			if err := tomlSetKeyValue(current, expr); err != nil {
+				return nil, err
+			}
+			parent, key := tomlCommentTarget(current, tomlKeyPath(expr.Key()))
+			parent.setComments(key, strings.Join(pending, "\n"), tomlTrailingComment(data, int(expr.Raw.Offset+expr.Raw.Length)))
+			if expr.Value().Kind == unstable.Array {
+				parent.setItemComments(key, tomlItemComments(data[tomlKeyEnd(expr):expr.Raw.Offset+expr.Raw.Length]))
+			}
+			pending = nil
+		case unstable.Table:
+			path := tomlKeyPath(expr.Key())
+			table, err := tomlDescend(root, path)
+			if err != nil {
+				return nil, err
+			}
+			current = table
+			parent, key := tomlCommentTarget(root, path)
+			parent.setComments(key, strings.Join(pending, "\n"), tomlTrailingComment(data, tomlKeyEnd(expr)))
+			pending = nil
+		case unstable.ArrayTable:
+			path := tomlKeyPath(expr.Key())
+			table, err := tomlArrayTable(root, path)
+			if err != nil {
+				return nil, err
+			}
+			current = table
+			// The first header of an array of tables carries the comments of
+			// the member, later headers those of their element.
+			parent, key := tomlCommentTarget(root, path)
+			if items, _ := parent.Get(key); len(items.([]any)) == 1 {
+				parent.setComments(key, strings.Join(pending, "\n"), tomlTrailingComment(data, tomlKeyEnd(expr)))
+				pending = nil
+			} else {
+				parent.setItemComment(key, len(items.([]any))-1, strings.Join(pending, "\n"), tomlTrailingComment(data, tomlKeyEnd(expr)))
+				pending = nil
+			}
+		}
+	}
+	if err := parser.Error(); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// tomlCommentTarget returns the table holding the last key of path.
+func tomlCommentTarget(object *Object, path []string) (*Object, string) {
+	parent, err := tomlDescend(object, path[:len(path)-1])
+	if err != nil {
+		return NewObject(), ""
+	}
+	return parent, path[len(path)-1]
+}
+
+// tomlKeyEnd returns the offset just past the last key of a table header.
+func tomlKeyEnd(expr *unstable.Node) int {
+	end := 0
+	it := expr.Key()
+	for it.Next() {
+		raw := it.Node().Raw
+		end = int(raw.Offset + raw.Length)
+	}
+	return end
+}
+
+// tomlTrailingComment returns the comment following an expression on the
+// same line. The parser does not report these, so the rest of the line is
+// inspected directly, skipping the closing brackets and quotes that may lie
+// outside the expression range.
+func tomlTrailingComment(data []byte, offset int) string {
+	if offset <= 0 || offset > len(data) {
+		return ""
+	}
+	rest := data[offset:]
+	if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+	rest = bytes.TrimLeft(rest, " \t]}\"'")
+	if !bytes.HasPrefix(rest, []byte("#")) {
+		return ""
+	}
+	return commentText(string(rest))
+}
+
+// tomlItemComments returns the comments of the elements of the first array in
+// data. The parser reports at most one comment between two elements, so the
+// array is scanned directly: comments on the line an element ends on trail
+// it, the others belong to the element that follows.
+func tomlItemComments(data []byte) []ItemComment {
+	var (
+		items   []ItemComment
+		pending []string
+		depth   int
+		// inItem is set within an element, and sameLine until the line an
+		// element ended on does.
+		inItem, sameLine bool
+	)
+	start := func() {
+		if depth == 1 && !inItem {
+			items = append(items, ItemComment{Comment: strings.Join(pending, "\n")})
+			pending = nil
+			inItem = true
+		}
+		sameLine = true
+	}
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; c {
+		case ' ', '\t', '\r':
+		case '\n':
+			sameLine = false
+		case '#':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+			if depth == 1 {
+				text := commentText(string(data[i : i+end]))
+				if sameLine && len(items) > 0 {
+					items[len(items)-1].LineComment = text
+				} else {
+					pending = append(pending, text)
+				}
+			}
+			i += end - 1
+		case '[', '{':
+			if depth > 0 {
+				start()
+			}
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				return items
+			}
+			sameLine = true
+		case ',':
+			if depth == 1 {
+				inItem = false
+			}
+			sameLine = true
+		case '"', '\'':
+			start()
+			i = tomlStringEnd(data, i)
+		default:
+			if depth > 0 {
+				start()
+			}
+		}
+	}
+	return items
+}
+
+// tomlStringEnd returns the offset of the quote closing the string that
+// starts at offset i.
+func tomlStringEnd(data []byte, i int) int {
+	quote := data[i : i+1]
+	if bytes.HasPrefix(data[i:], bytes.Repeat(quote, 3)) {
+		end := bytes.Index(data[i+3:], bytes.Repeat(quote, 3))
+		if end < 0 {
+			return len(data)
+		}
+		// Up to two more quotes may close the string's content.
+		end += i + 5
+		for end+1 < len(data) && data[end+1] == quote[0] {
+			end++
+		}
+		return end
+	}
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			if quote[0] == '"' {
+				j++
+			}
+		case quote[0]:
+			return j
+		}
+	}
+	return len(data)
+}
+
+func tomlKeyPath(it unstable.Iterator) []string {
+	var path []string
+	for it.Next() {
+		path = append(path, string(it.Node().Data))
+	}
+	return path
+}
+
+// tomlDescend walks path from object, creating tables as needed. An array of
+// tables resolves to its last element, as in TOML itself.
+func tomlDescend(object *Object, path []string) (*Object, error) {
+	for _, key := range path {
+		value, ok := object.Get(key)
+		if !ok {
+			child := NewObject()
+			object.Set(key, child)
+			object = child
+			continue
+		}
+		switch v := value.(type) {
+		case *Object:
+			object = v
+		case []any:
+			if len(v) == 0 {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			last, ok := v[len(v)-1].(*Object)
+			if !ok {
+				return nil, fmt.Errorf("key %q is not a table", key)
+			}
+			object = last
+		default:
+			return nil, fmt.Errorf("key %q is not a table", key)
+		}
+	}
+	return object, nil
+}
+
+func tomlArrayTable(root *Object, path []string) (*Object, error) {
+	parent, err := tomlDescend(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	table := NewObject()
+
+	existing, ok := parent.Get(key)
+	if !ok {
+		parent.Set(key, []any{table})
+		return table, nil
+	}
+	items, ok := existing.([]any)
+	if !ok {
+		return nil, fmt.Errorf("key %q is not an array of tables", key)
+	}
+	parent.Set(key, append(items, table))
+	return table, nil
+}
+
+func tomlSetKeyValue(object *Object, expr *unstable.Node) error {
+	path := tomlKeyPath(expr.Key())
+	parent, err := tomlDescend(object, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	value, err := tomlValue(expr.Value())
+	if err != nil {
+		return err
+	}
+	parent.Set(path[len(path)-1], value)
+	return nil
+}
+
+func tomlValue(node *unstable.Node) (any, error) {
+	switch node.Kind {
+	case unstable.String:
+		return string(node.Data), nil
+	case unstable.Bool:
+		return string(node.Data) == "true", nil
+	case unstable.Integer:
+		return tomlInteger(string(node.Data))
+	case unstable.Float:
+		return tomlFloat(string(node.Data))
+	case unstable.DateTime:
+		var doc struct{ V time.Time }
+		if err := toml.Unmarshal([]byte("V = "+string(node.Data)), &doc); err != nil {
+			return nil, err
+		}
+		return doc.V, nil
+	case unstable.LocalDate, unstable.LocalTime, unstable.LocalDateTime:
+		return string(node.Data), nil
+	case unstable.Array:
+		items := []any{}
+		it := node.Children()
+		for it.Next() {
+			// Comments are read by tomlItemComments.
+			if it.Node().Kind == unstable.Comment {
+				continue
+			}
+			value, err := tomlValue(it.Node())
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case unstable.InlineTable:
+		object := NewObject()
+		it := node.Children()
+		for it.Next() {
+			if err := tomlSetKeyValue(object, it.Node()); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported TOML value kind %s", node.Kind)
+	}
+}
+
+func tomlInteger(raw string) (any, error) {
+	s := strings.ReplaceAll(raw, "_", "")
+	i, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer %q", raw)
+	}
+	return i, nil
+}
+
+func tomlFloat(raw string) (any, error) {
+	s := strings.ReplaceAll(raw, "_", "")
+	switch strings.TrimLeft(s, "+-") {
+	case "inf":
+		if strings.HasPrefix(s, "-") {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid float %q", raw)
+	}
+	return f, nil
+}
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func encodeTOML(value any) (string, error) {
+	root, ok := value.(*Object)
+	if !ok {
+		return "", errors.New("TOML documents must have a table at the top level")
+	}
+
+	var b strings.Builder
+	if err := writeTOMLTable(&b, root, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeTOMLTable writes the plain key/values of a table first, followed by
+// its sub-tables and arrays of tables, preserving member order within each
+// group.
+func writeTOMLTable(b *strings.Builder, table *Object, path []string) error {
+	for _, member := range table.Members {
+		if member.Value == nil || isTOMLTable(member.Value) || isTOMLArrayOfTables(member.Value) {
+			continue
+		}
+		inline, err := tomlInline(member.Value)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", member.Key, err)
+		}
+		writeTOMLComment(b, member.Comment)
+		if items, ok := member.Value.([]any); ok && len(member.ItemComments) > 0 {
+			if err := writeTOMLCommentedArray(b, member, items); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(b, "%s = %s%s\n", tomlKey(member.Key), inline, tomlLineComment(member.LineComment))
+	}
+
+	for _, member := range table.Members {
+		childPath := append(append([]string{}, path...), member.Key)
+		switch {
+		case isTOMLTable(member.Value):
+			child := member.Value.(*Object)
+			if tomlNeedsHeader(child) || member.Comment != "" || member.LineComment != "" {
+				writeTOMLSeparator(b)
+				writeTOMLComment(b, member.Comment)
+				fmt.Fprintf(b, "[%s]%s\n", tomlKeys(childPath), tomlLineComment(member.LineComment))
+			}
+			if err := writeTOMLTable(b, child, childPath); err != nil {
+				return err
+			}
+		case isTOMLArrayOfTables(member.Value):
+			for i, item := range member.Value.([]any) {
+				writeTOMLSeparator(b)
+				header := fmt.Sprintf("[[%s]]", tomlKeys(childPath))
+				if i == 0 {
+					writeTOMLComment(b, member.Comment)
+					header += tomlLineComment(member.LineComment)
+				} else {
+					header += tomlLineComment(member.itemComment(i).LineComment)
+				}
+				writeTOMLComment(b, member.itemComment(i).Comment)
+				b.WriteString(header + "\n")
+				if err := writeTOMLTable(b, item.(*Object), childPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeTOMLCommentedArray writes an array one element per line, with the
+// comments of its elements.
+func writeTOMLCommentedArray(b *strings.Builder, member Member, items []any) error {
+	fmt.Fprintf(b, "%s = [\n", tomlKey(member.Key))
+	for i, item := range items {
+		inline, err := tomlInline(item)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", member.Key, err)
+		}
+		comments := member.itemComment(i)
+		if comments.Comment != "" {
+			b.WriteString("  " + strings.ReplaceAll(commentMarkup(comments.Comment), "\n", "\n  ") + "\n")
+		}
+		fmt.Fprintf(b, "  %s,%s\n", inline, tomlLineComment(comments.LineComment))
+	}
+	fmt.Fprintf(b, "]%s\n", tomlLineComment(member.LineComment))
+	return nil
+}
+
+func writeTOMLComment(b *strings.Builder, comment string) {
+	if comment != "" {
+		b.WriteString(commentMarkup(comment) + "\n")
+	}
+}
+
+func tomlLineComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	return " " + commentMarkup(strings.ReplaceAll(comment, "\n", " "))
+}
+
+func writeTOMLSeparator(b *strings.Builder) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+}
+
+// tomlNeedsHeader reports whether a table must be declared explicitly: it has
+// plain key/values of its own or is empty.
+func tomlNeedsHeader(table *Object) bool {
+	empty := true
+	for _, member := range table.Members {
+		if member.Value == nil {
+			continue
+		}
+		empty = false
+		if !isTOMLTable(member.Value) && !isTOMLArrayOfTables(member.Value) {
+			return true
+		}
+	}
+	return empty
+}
+
+func isTOMLTable(value any) bool {
+	_, ok := value.(*Object)
+	return ok
+}
+
+func isTOMLArrayOfTables(value any) bool {
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if _, ok := item.(*Object); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlInline(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", errors.New("TOML cannot represent null values")
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return tomlFloatString(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			part, err := tomlInline(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case *Object:
+		parts := make([]string, 0, v.Len())
+		for _, member := range v.Members {
+			if member.Value == nil {
+				continue
+			}
+			part, err := tomlInline(member.Value)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, tomlKey(member.Key)+" = "+part)
+		}
+		if len(parts) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func tomlFloatString(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+func tomlKeys(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
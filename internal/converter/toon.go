@@ -0,0 +1,407 @@
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hannes-sistemica/toon"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+func encodeTOON(value any) (string, error) {
+	// toon turns every number into float64, which loses the digits of large
+	// integers; they go in as placeholder strings, replaced afterwards by the
+	// digits. The placeholder is a marker that occurs nowhere in the document.
+	jsonContent, err := encodeJSON(value)
+	if err != nil {
+		return "", err
+	}
+	marker := "~int~"
+	for strings.Contains(jsonContent, marker) {
+		marker += "~"
+	}
+
+	var digits []string
+	data := toonValue(value, func(s string) string {
+		placeholder := marker + strconv.Itoa(len(digits)) + marker
+		digits = append(digits, placeholder, s)
+		return placeholder
+	})
+	output, err := toon.EncodeWithOptions(data, toon.DefaultOptions())
+	if err != nil {
+		return "", err
+	}
+	return strings.NewReplacer(digits...).Replace(output), nil
+}
+
+// toonValue converts a tree for the toon encoder, which only preserves key
+// order for its own ordered maps. Integers are passed through integer.
+func toonValue(value any, integer func(string) string) any {
+	switch v := value.(type) {
+	case *Object:
+		m := orderedmap.New[string, any](len(v.Members))
+		for _, member := range v.Members {
+			m.Set(member.Key, toonValue(member.Value, integer))
+		}
+		return m
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = toonValue(item, integer)
+		}
+		return out
+	case int64:
+		return integer(strconv.FormatInt(v, 10))
+	case json.Number:
+		return integer(v.String())
+	default:
+		return jsonValue(v)
+	}
+}
+
+type toonLine struct {
+	number  int
+	indent  int
+	content string
+}
+
+type toonParser struct {
+	lines []toonLine
+	pos   int
+}
+
+// toonHeader matches an array header such as `key[3]:`, `[#2|]{a|b}:` or
+// `"my key"[0]:`, capturing the key, length, delimiter and field list.
+var toonHeader = regexp.MustCompile(`^((?:"(?:[^"\\]|\\.)*")|[^\[:"]*)\[#?(\d+)([\t|]?)\](?:\{([^}]*)\})?:(.*)$`)
+
+var toonNumber = regexp.MustCompile(`^-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+
+func decodeTOON(content string) (any, error) {
+	parser := &toonParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(raw, " ")
+		parser.lines = append(parser.lines, toonLine{
+			number:  i + 1,
+			indent:  len(raw) - len(trimmed),
+			content: strings.TrimRight(trimmed, " "),
+		})
+	}
+	if len(parser.lines) == 0 {
+		return NewObject(), nil
+	}
+
+	first := parser.lines[0]
+	if match := toonHeader.FindStringSubmatch(first.content); match != nil && match[1] == "" {
+		parser.pos++
+		value, err := parser.parseArray(match, first.indent)
+		if err != nil {
+			return nil, err
+		}
+		return value, parser.expectEnd()
+	}
+	if len(parser.lines) == 1 && !toonHasKey(first.content) {
+		return parseTOONPrimitive(first.content)
+	}
+
+	object, err := parser.parseObject(first.indent, NewObject())
+	if err != nil {
+		return nil, err
+	}
+	return object, parser.expectEnd()
+}
+
+func (p *toonParser) expectEnd() error {
+	if p.pos < len(p.lines) {
+		return fmt.Errorf("line %d: unexpected content %q", p.lines[p.pos].number, p.lines[p.pos].content)
+	}
+	return nil
+}
+
+// parseObject reads key/value lines at exactly indent into object.
+func (p *toonParser) parseObject(indent int, object *Object) (*Object, error) {
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		if strings.HasPrefix(line.content, "- ") || line.content == "-" {
+			break
+		}
+		p.pos++
+		key, value, err := p.parseKeyValue(line.content, line)
+		if err != nil {
+			return nil, err
+		}
+		object.Set(key, value)
+	}
+	return object, nil
+}
+
+func (p *toonParser) parseKeyValue(content string, line toonLine) (string, any, error) {
+	if match := toonHeader.FindStringSubmatch(content); match != nil && match[1] != "" {
+		key, err := parseTOONKey(match[1])
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		value, err := p.parseArray(match, line.indent)
+		return key, value, err
+	}
+
+	rawKey, rest, ok := splitTOONKey(content)
+	if !ok {
+		return "", nil, fmt.Errorf("line %d: expected \"key: value\", got %q", line.number, content)
+	}
+	key, err := parseTOONKey(rawKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("line %d: %w", line.number, err)
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest != "" {
+		value, err := parseTOONPrimitive(rest)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		return key, value, nil
+	}
+
+	nested := NewObject()
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > line.indent {
+		if _, err := p.parseObject(p.lines[p.pos].indent, nested); err != nil {
+			return "", nil, err
+		}
+	}
+	return key, nested, nil
+}
+
+// parseArray parses the body of an array header match at the given indent.
+func (p *toonParser) parseArray(match []string, indent int) (any, error) {
+	length, err := strconv.Atoi(match[2])
+	if err != nil {
+		return nil, err
+	}
+	delimiter := ","
+	if match[3] != "" {
+		delimiter = match[3]
+	}
+	inline := strings.TrimSpace(match[5])
+
+	items := make([]any, 0, length)
+	switch {
+	case length == 0:
+		return items, nil
+	case inline != "":
+		for _, field := range splitTOONValues(inline, delimiter) {
+			value, err := parseTOONPrimitive(field)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+	case match[4] != "":
+		var fields []string
+		for _, field := range splitTOONValues(match[4], delimiter) {
+			key, err := parseTOONKey(field)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, key)
+		}
+		for len(items) < length && p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+			line := p.lines[p.pos]
+			p.pos++
+			values := splitTOONValues(line.content, delimiter)
+			if len(values) != len(fields) {
+				return nil, fmt.Errorf("line %d: expected %d values, got %d", line.number, len(fields), len(values))
+			}
+			row := NewObject()
+			for i, field := range fields {
+				value, err := parseTOONPrimitive(values[i])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+				row.Set(field, value)
+			}
+			items = append(items, row)
+		}
+	default:
+		for len(items) < length && p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+			line := p.lines[p.pos]
+			if line.content != "-" && !strings.HasPrefix(line.content, "- ") {
+				return nil, fmt.Errorf("line %d: expected list item", line.number)
+			}
+			p.pos++
+			item, err := p.parseListItem(line)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	if len(items) != length {
+		return nil, fmt.Errorf("array declares %d items but has %d", length, len(items))
+	}
+	return items, nil
+}
+
+func (p *toonParser) parseListItem(line toonLine) (any, error) {
+	if line.content == "-" {
+		return NewObject(), nil
+	}
+	content := strings.TrimPrefix(line.content, "- ")
+
+	if match := toonHeader.FindStringSubmatch(content); match != nil && match[1] == "" {
+		return p.parseArray(match, line.indent)
+	}
+	if !toonHasKey(content) {
+		return parseTOONPrimitive(content)
+	}
+
+	object := NewObject()
+	key, value, err := p.parseKeyValue(content, line)
+	if err != nil {
+		return nil, err
+	}
+	object.Set(key, value)
+
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > line.indent {
+		if _, err := p.parseObject(p.lines[p.pos].indent, object); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+// toonHasKey reports whether content starts with a key followed by a colon
+// outside of quotes.
+func toonHasKey(content string) bool {
+	if toonHeader.MatchString(content) {
+		return true
+	}
+	_, _, ok := splitTOONKey(content)
+	return ok
+}
+
+func splitTOONKey(content string) (string, string, bool) {
+	if strings.HasPrefix(content, `"`) {
+		end := closingQuote(content)
+		if end < 0 || end+1 >= len(content) || content[end+1] != ':' {
+			return "", "", false
+		}
+		return content[:end+1], content[end+2:], true
+	}
+	idx := strings.Index(content, ":")
+	if idx <= 0 || strings.ContainsAny(content[:idx], `"`) {
+		return "", "", false
+	}
+	return content[:idx], content[idx+1:], true
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseTOONKey(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, `"`) {
+		return unquoteTOON(raw)
+	}
+	return raw, nil
+}
+
+func parseTOONPrimitive(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case raw == "null":
+		return nil, nil
+	case raw == "true":
+		return true, nil
+	case raw == "false":
+		return false, nil
+	case strings.HasPrefix(raw, `"`):
+		return unquoteTOON(raw)
+	case toonNumber.MatchString(raw) && !(len(raw) > 1 && raw[0] == '0' && raw[1] != '.'):
+		return parseNumber(raw)
+	default:
+		return raw, nil
+	}
+}
+
+func unquoteTOON(raw string) (string, error) {
+	if len(raw) < 2 || !strings.HasSuffix(raw, `"`) || closingQuote(raw) != len(raw)-1 {
+		return "", fmt.Errorf("unterminated string %s", raw)
+	}
+	var b strings.Builder
+	body := raw[1 : len(raw)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(body) {
+			return "", errors.New("invalid escape at end of string")
+		}
+		switch body[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(body[i])
+		default:
+			return "", fmt.Errorf("invalid escape \\%c", body[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// splitTOONValues splits a delimited row, ignoring delimiters inside quotes.
+func splitTOONValues(s, delimiter string) []string {
+	var (
+		values  []string
+		current strings.Builder
+		quoted  bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && quoted && i+1 < len(s):
+			current.WriteByte(c)
+			i++
+			current.WriteByte(s[i])
+		case c == '"':
+			quoted = !quoted
+			current.WriteByte(c)
+		case !quoted && strings.HasPrefix(s[i:], delimiter):
+			values = append(values, strings.TrimSpace(current.String()))
+			current.Reset()
+			i += len(delimiter) - 1
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(values, strings.TrimSpace(current.String()))
+}
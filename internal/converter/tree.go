@@ -0,0 +1,226 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Member is a single key/value pair of an Object. Comment holds the comment
+// lines written above the key and LineComment the comment trailing it on the
+// same line, both without their "#" markers. When Value is an array,
+// ItemComments holds the comments of its elements by index; it may be
+// shorter than the array.
+type Member struct {
+	Key          string
+	Value        any
+	Comment      string
+	LineComment  string
+	ItemComments []ItemComment
+}
+
+// ItemComment holds the comments written above an array element and after it
+// on the same line.
+type ItemComment struct {
+	Comment     string
+	LineComment string
+}
+
+// Object is an ordered mapping used as the common tree for every codec.
+// Documents decode to values of the following types: nil, bool, int64,
+// float64, string, time.Time, []any and *Object, with json.Number for
+// integers beyond int64.
+type Object struct {
+	Members []Member
+	index   map[string]int
+}
+
+// NewObject returns an empty Object.
+func NewObject() *Object {
+	return &Object{}
+}
+
+// lookup returns the position of key, rebuilding the index when Members was
+// changed directly.
+func (o *Object) lookup(key string) (int, bool) {
+	if o.index == nil || len(o.index) != len(o.Members) {
+		o.index = make(map[string]int, len(o.Members))
+		for i, member := range o.Members {
+			o.index[member.Key] = i
+		}
+	}
+	i, ok := o.index[key]
+	return i, ok
+}
+
+// Get returns the value stored under key.
+func (o *Object) Get(key string) (any, bool) {
+	if i, ok := o.lookup(key); ok {
+		return o.Members[i].Value, true
+	}
+	return nil, false
+}
+
+// Set replaces the value of an existing key or appends a new member.
+func (o *Object) Set(key string, value any) {
+	if i, ok := o.lookup(key); ok {
+		o.Members[i].Value = value
+		return
+	}
+	o.index[key] = len(o.Members)
+	o.Members = append(o.Members, Member{Key: key, Value: value})
+}
+
+// setComments attaches comments to an existing member. Empty values leave the
+// current comment untouched.
+func (o *Object) setComments(key, comment, lineComment string) {
+	i, ok := o.lookup(key)
+	if !ok {
+		return
+	}
+	if comment != "" {
+		o.Members[i].Comment = joinComments(o.Members[i].Comment, comment)
+	}
+	if lineComment != "" {
+		o.Members[i].LineComment = lineComment
+	}
+}
+
+// setItemComments attaches the comments of array elements to an existing
+// member, unless there are none.
+func (o *Object) setItemComments(key string, items []ItemComment) {
+	i, ok := o.lookup(key)
+	if !ok {
+		return
+	}
+	for _, item := range items {
+		if item.Comment != "" || item.LineComment != "" {
+			o.Members[i].ItemComments = items
+			return
+		}
+	}
+}
+
+// setItemComment attaches comments to element i of an existing member's
+// array, unless both are empty.
+func (o *Object) setItemComment(key string, i int, comment, lineComment string) {
+	m, ok := o.lookup(key)
+	if !ok || (comment == "" && lineComment == "") {
+		return
+	}
+	items := o.Members[m].ItemComments
+	for len(items) <= i {
+		items = append(items, ItemComment{})
+	}
+	items[i] = ItemComment{Comment: comment, LineComment: lineComment}
+	o.Members[m].ItemComments = items
+}
+
+// itemComment returns the comments of element i of the member's array.
+func (m Member) itemComment(i int) ItemComment {
+	if i < len(m.ItemComments) {
+		return m.ItemComments[i]
+	}
+	return ItemComment{}
+}
+
+// Len returns the number of members.
+func (o *Object) Len() int {
+	return len(o.Members)
+}
+
+// Keys returns the member keys in document order.
+func (o *Object) Keys() []string {
+	keys := make([]string, len(o.Members))
+	for i, member := range o.Members {
+		keys[i] = member.Key
+	}
+	return keys
+}
+
+// MarshalJSON encodes the object with its members in document order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, member := range o.Members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(member.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(jsonValue(member.Value))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Plain converts a tree into plain Go values (map[string]any instead of
+// *Object), for libraries that do not understand ordered objects.
+func Plain(value any) any {
+	switch v := value.(type) {
+	case *Object:
+		m := make(map[string]any, len(v.Members))
+		for _, member := range v.Members {
+			m[member.Key] = Plain(member.Value)
+		}
+		return m
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = Plain(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// jsonValue adapts scalars that encoding/json cannot represent.
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return value
+}
+
+// scalarString renders a scalar for text based formats such as CSV and XML.
+func scalarString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		data, err := json.Marshal(jsonValue(v))
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
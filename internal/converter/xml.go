@@ -0,0 +1,225 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// XML follows the mxj conventions used by xml2json/json2xml: attributes are
+// stored under "-name" keys, mixed text under "#text", repeated elements
+// become arrays and documents without a single root are wrapped in <doc>.
+const (
+	xmlAttrPrefix = "-"
+	xmlTextKey    = "#text"
+	xmlDocElement = "doc"
+	xmlItemName   = "item"
+)
+
+var xmlNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._:-]*$`)
+
+func decodeXML(content string) (any, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = true
+
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("no root element")
+			}
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		value, err := decodeXMLElement(decoder, start)
+		if err != nil {
+			return nil, err
+		}
+
+		root := NewObject()
+		root.Set(xmlName(start.Name), value)
+
+		for {
+			token, err := decoder.RawToken()
+			if err == io.EOF {
+				return root, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := token.(xml.StartElement); ok {
+				return nil, errors.New("multiple root elements")
+			}
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	object := NewObject()
+	for _, attr := range start.Attr {
+		object.Set(xmlAttrPrefix+xmlName(attr.Name), attr.Value)
+	}
+
+	var text strings.Builder
+	hasChildren := false
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("element <%s> is not closed", xmlName(start.Name))
+			}
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			hasChildren = true
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			name := xmlName(t.Name)
+			if existing, ok := object.Get(name); ok {
+				if items, isArray := existing.([]any); isArray {
+					object.Set(name, append(items, child))
+				} else {
+					object.Set(name, []any{existing, child})
+				}
+			} else {
+				object.Set(name, child)
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if xmlName(t.Name) != xmlName(start.Name) {
+				return nil, fmt.Errorf("element <%s> closed by </%s>", xmlName(start.Name), xmlName(t.Name))
+			}
+
+			content := strings.TrimSpace(text.String())
+			if object.Len() == 0 && !hasChildren {
+				return content, nil
+			}
+			if content != "" {
+				object.Set(xmlTextKey, content)
+			}
+			return object, nil
+		}
+	}
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+func encodeXML(value any) (string, error) {
+	var buf bytes.Buffer
+
+	root, ok := value.(*Object)
+	switch {
+	case ok && root.Len() == 1 && isXMLElementKey(root.Members[0].Key) && !isXMLArray(root.Members[0].Value):
+		if err := writeXMLElement(&buf, root.Members[0].Key, root.Members[0].Value, 0); err != nil {
+			return "", err
+		}
+	case ok && root.Len() == 0:
+		buf.WriteString("<" + xmlDocElement + "/>\n")
+	default:
+		if items, isArray := value.([]any); isArray {
+			value = &Object{Members: []Member{{Key: xmlItemName, Value: items}}}
+		}
+		if err := writeXMLElement(&buf, xmlDocElement, value, 0); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+func isXMLElementKey(key string) bool {
+	return !strings.HasPrefix(key, xmlAttrPrefix) && key != xmlTextKey
+}
+
+func isXMLArray(value any) bool {
+	_, ok := value.([]any)
+	return ok
+}
+
+func writeXMLElement(buf *bytes.Buffer, name string, value any, depth int) error {
+	if !xmlNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid element name %q", name)
+	}
+	indent := strings.Repeat("  ", depth)
+
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if nested, ok := item.([]any); ok {
+				item = &Object{Members: []Member{{Key: xmlItemName, Value: nested}}}
+			}
+			if err := writeXMLElement(buf, name, item, depth); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *Object:
+		var attrs strings.Builder
+		var text string
+		var children []Member
+		for _, member := range v.Members {
+			switch {
+			case strings.HasPrefix(member.Key, xmlAttrPrefix):
+				attrName := strings.TrimPrefix(member.Key, xmlAttrPrefix)
+				if !xmlNamePattern.MatchString(attrName) {
+					return fmt.Errorf("invalid attribute name %q", attrName)
+				}
+				attrs.WriteString(" " + attrName + `="` + xmlEscape(scalarString(member.Value)) + `"`)
+			case member.Key == xmlTextKey:
+				text = scalarString(member.Value)
+			default:
+				children = append(children, member)
+			}
+		}
+
+		switch {
+		case len(children) == 0 && text == "":
+			fmt.Fprintf(buf, "%s<%s%s/>\n", indent, name, attrs.String())
+		case len(children) == 0:
+			fmt.Fprintf(buf, "%s<%s%s>%s</%s>\n", indent, name, attrs.String(), xmlEscape(text), name)
+		default:
+			fmt.Fprintf(buf, "%s<%s%s>\n", indent, name, attrs.String())
+			if text != "" {
+				fmt.Fprintf(buf, "%s  %s\n", indent, xmlEscape(text))
+			}
+			for _, child := range children {
+				if err := writeXMLElement(buf, child.Key, child.Value, depth+1); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintf(buf, "%s</%s>\n", indent, name)
+		}
+		return nil
+	default:
+		text := scalarString(v)
+		if text == "" {
+			fmt.Fprintf(buf, "%s<%s/>\n", indent, name)
+			return nil
+		}
+		fmt.Fprintf(buf, "%s<%s>%s</%s>\n", indent, name, xmlEscape(text), name)
+		return nil
+	}
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
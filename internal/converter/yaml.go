@@ -0,0 +1,295 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func decodeYAML(content string) (any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	d := &yamlDecoder{active: map[*yaml.Node]bool{}}
+	d.pending = commentText(doc.HeadComment)
+	return d.value(doc.Content[0])
+}
+
+// maxYAMLAliasNodes bounds alias expansion to guard against "billion laughs"
+// documents.
+const maxYAMLAliasNodes = 100_000
+
+// yamlDecoder carries comments over to the members they describe. Foot
+// comments have no member of their own, so they are kept in pending and
+// become the head comment of the next member.
+type yamlDecoder struct {
+	active     map[*yaml.Node]bool
+	aliasDepth int
+	aliasNodes int
+	pending    string
+}
+
+func (d *yamlDecoder) value(node *yaml.Node) (any, error) {
+	if d.aliasDepth > 0 {
+		d.aliasNodes++
+		if d.aliasNodes > maxYAMLAliasNodes {
+			return nil, errors.New("document contains excessive aliasing")
+		}
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return d.value(node.Content[0])
+	case yaml.AliasNode:
+		return d.alias(node.Alias, d.value)
+	case yaml.SequenceNode:
+		if d.active[node] {
+			return nil, fmt.Errorf("line %d: recursive alias", node.Line)
+		}
+		d.active[node] = true
+		defer delete(d.active, node)
+
+		items := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := d.value(child)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case yaml.MappingNode:
+		if d.active[node] {
+			return nil, fmt.Errorf("line %d: recursive alias", node.Line)
+		}
+		d.active[node] = true
+		defer delete(d.active, node)
+
+		object := NewObject()
+		if err := d.mergeMapping(object, node, false); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case yaml.ScalarNode:
+		return yamlScalarValue(node)
+	default:
+		return nil, fmt.Errorf("unsupported YAML node kind %v", node.Kind)
+	}
+}
+
+func (d *yamlDecoder) alias(node *yaml.Node, fn func(*yaml.Node) (any, error)) (any, error) {
+	if node == nil || d.active[node] {
+		return nil, errors.New("recursive alias")
+	}
+	d.aliasDepth++
+	defer func() { d.aliasDepth-- }()
+	return fn(node)
+}
+
+// mergeMapping copies the members of a mapping node into object,
+// resolving "<<" merge keys. Merged members never override explicit keys.
+func (d *yamlDecoder) mergeMapping(object *Object, node *yaml.Node, merging bool) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		if keyNode.Tag == "!!merge" || (keyNode.Kind == yaml.ScalarNode && keyNode.Value == "<<" && keyNode.Style == 0) {
+			if err := d.merge(object, valueNode); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, err := yamlKey(keyNode)
+		if err != nil {
+			return err
+		}
+		if _, exists := object.Get(key); exists && merging {
+			continue
+		}
+
+		comments := !merging && d.aliasDepth == 0
+		var comment, lineComment string
+		if comments {
+			comment = joinComments(d.pending, commentText(keyNode.HeadComment), commentText(valueNode.HeadComment))
+			lineComment = commentText(keyNode.LineComment)
+			if lineComment == "" {
+				lineComment = commentText(valueNode.LineComment)
+			}
+			d.pending = ""
+		}
+
+		value, err := d.value(valueNode)
+		if err != nil {
+			return err
+		}
+		object.Set(key, value)
+
+		if comments {
+			object.setComments(key, comment, lineComment)
+			if valueNode.Kind == yaml.SequenceNode {
+				object.setItemComments(key, yamlItemComments(valueNode))
+			}
+			d.pending = joinComments(d.pending, commentText(valueNode.FootComment), commentText(keyNode.FootComment))
+		}
+	}
+	return nil
+}
+
+// yamlItemComments returns the comments of the items of a sequence. Only
+// scalars keep a line comment; on collections it belongs to their first
+// member.
+func yamlItemComments(node *yaml.Node) []ItemComment {
+	items := make([]ItemComment, len(node.Content))
+	for i, child := range node.Content {
+		items[i].Comment = commentText(child.HeadComment)
+		if child.Kind == yaml.ScalarNode {
+			items[i].LineComment = commentText(child.LineComment)
+		}
+	}
+	return items
+}
+
+func (d *yamlDecoder) merge(object *Object, node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		_, err := d.alias(node.Alias, func(target *yaml.Node) (any, error) {
+			return nil, d.merge(object, target)
+		})
+		return err
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		if d.active[node] {
+			return fmt.Errorf("line %d: recursive alias", node.Line)
+		}
+		d.active[node] = true
+		defer delete(d.active, node)
+		return d.mergeMapping(object, node, true)
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := d.merge(object, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.New("merge key value must be a mapping or a sequence of mappings")
+	}
+}
+
+func yamlKey(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("line %d: only scalar mapping keys are supported", node.Line)
+	}
+	return node.Value, nil
+}
+
+func yamlScalarValue(node *yaml.Node) (any, error) {
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return json.Number(strconv.FormatUint(v, 10)), nil
+	case float64, bool, string, time.Time, nil:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return node.Value, nil
+	}
+}
+
+func encodeYAML(value any) (string, error) {
+	node, err := yamlNode(value)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	if err := encoder.Encode(node); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func yamlNode(value any) (*yaml.Node, error) {
+	switch v := value.(type) {
+	case *Object:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, member := range v.Members {
+			key := &yaml.Node{}
+			if err := key.Encode(member.Key); err != nil {
+				return nil, err
+			}
+			child, err := yamlNode(member.Value)
+			if err != nil {
+				return nil, err
+			}
+			key.HeadComment = commentMarkup(member.Comment)
+			// Empty collections are written inline, where a comment on
+			// the key would end up before the value.
+			if child.Kind == yaml.ScalarNode || len(child.Content) == 0 {
+				child.LineComment = commentMarkup(member.LineComment)
+			} else {
+				key.LineComment = commentMarkup(member.LineComment)
+			}
+			if child.Kind == yaml.SequenceNode {
+				for i, item := range child.Content {
+					comments := member.itemComment(i)
+					item.HeadComment = commentMarkup(comments.Comment)
+					if item.Kind == yaml.ScalarNode {
+						item.LineComment = commentMarkup(comments.LineComment)
+					}
+				}
+			}
+			node.Content = append(node.Content, key, child)
+		}
+		return node, nil
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			child, err := yamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		return node, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	case json.Number:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: v.String()}, nil
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+}
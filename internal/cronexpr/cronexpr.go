@@ -0,0 +1,522 @@
+// Package cronexpr parses cron expressions and computes when they fire.
+//
+// Standard 5-field expressions (minute hour day-of-month month day-of-week)
+// are accepted, as are 6 fields with a leading seconds or trailing year
+// field, 7 fields with both, the Quartz extensions ?, L, W and #, and the
+// @yearly, @monthly, @weekly, @daily, @hourly, @reboot and @every macros.
+//
+// Days of the week are numbered 0-7 with Sunday as 0 or 7, unless the
+// expression has a seconds field and uses ?, which marks it as Quartz-style:
+// then they are numbered 1-7 with Sunday as 1. A 5-field expression keeps
+// the standard numbering, so "0 0 ? * 6" and "0 0 * * 6" both run on
+// Saturdays.
+package cronexpr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lnquy/cron"
+)
+
+// MaxYear is the last year a schedule can fire in.
+const MaxYear = 2099
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	Expression string
+	// Quartz reports whether days of the week are numbered from 1 (Sunday).
+	Quartz bool
+
+	// expanded is the macro-free expression handed to the describer.
+	expanded string
+	// every is the interval of @every; reboot marks @reboot. Neither has
+	// fields.
+	every  time.Duration
+	reboot bool
+
+	second, minute, hour, month uint64
+	dom, dow                    uint64
+	years                       map[int]bool
+
+	domAny, dowAny bool
+	// lastDay is set for L, with lastOffset days before the end for L-n.
+	lastDay    bool
+	lastOffset int
+	// weekday holds the days of nW; lastWeekday is set for LW.
+	weekday     []int
+	lastWeekday bool
+	// lastOf holds the weekdays of nL, nth their occurrence for n#k.
+	lastOf uint64
+	nth    []nthWeekday
+}
+
+type nthWeekday struct {
+	weekday, n int
+}
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// field describes the values one position of an expression accepts.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty cron expression")
+	}
+	s := &Schedule{Expression: expr}
+
+	if strings.HasPrefix(expr, "@") {
+		name, arg, _ := strings.Cut(expr, " ")
+		name = strings.ToLower(name)
+		arg = strings.TrimSpace(arg)
+		switch {
+		case name == "@reboot" && arg == "":
+			s.reboot = true
+			return s, nil
+		case name == "@every":
+			d, err := time.ParseDuration(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid @every interval %q: %w", arg, err)
+			}
+			if d < time.Second {
+				return nil, fmt.Errorf("@every interval %s is shorter than a second", d)
+			}
+			s.every = d
+			return s, nil
+		case macros[name] != "" && arg == "":
+			expr = macros[name]
+		default:
+			return nil, fmt.Errorf("unknown macro %q", expr)
+		}
+	}
+	s.expanded = expr
+
+	parts := strings.Fields(expr)
+	// Six fields are seconds first, unless the last one is a year, the same
+	// rule the describer applies.
+	var second, year string
+	// Quartz expressions always have seconds.
+	seconds := false
+	switch len(parts) {
+	case 5:
+		second, year = "0", "*"
+	case 6:
+		if isYear(parts[5]) {
+			second, year = "0", parts[5]
+			parts = parts[:5]
+		} else {
+			second, year = parts[0], "*"
+			parts = parts[1:]
+			seconds = true
+		}
+	case 7:
+		second, year = parts[0], parts[6]
+		parts = parts[1:6]
+		seconds = true
+	default:
+		return nil, fmt.Errorf("expected 5 to 7 fields, got %d", len(parts))
+	}
+	s.Quartz = seconds && (strings.Contains(parts[2], "?") || strings.Contains(parts[4], "?"))
+	if parts[2] == "?" && parts[4] == "?" {
+		return nil, errors.New("day-of-month and day-of-week can't both be ?")
+	}
+
+	var err error
+	if s.second, err = parseField(second, field{name: "second", max: 59}); err != nil {
+		return nil, err
+	}
+	if s.minute, err = parseField(parts[0], field{name: "minute", max: 59}); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(parts[1], field{name: "hour", max: 23}); err != nil {
+		return nil, err
+	}
+	if err := s.parseDayOfMonth(parts[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(parts[3], field{name: "month", min: 1, max: 12, names: monthNames}); err != nil {
+		return nil, err
+	}
+	if err := s.parseDayOfWeek(parts[4]); err != nil {
+		return nil, err
+	}
+	if err := s.parseYear(year); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func isYear(part string) bool {
+	if len(part) != 4 {
+		return false
+	}
+	_, err := strconv.Atoi(part)
+	return err == nil
+}
+
+// parseField parses a list of values, ranges and steps into a set of
+// values.
+func parseField(text string, f field) (uint64, error) {
+	var set uint64
+	for item := range strings.SplitSeq(text, ",") {
+		start, end, step, err := parseRange(item, f)
+		if err != nil {
+			return 0, err
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// parseRange parses "*", "n", "a-b" and any of those followed by "/step".
+// A single value with a step runs to the end of the field.
+func parseRange(item string, f field) (start, end, step int, err error) {
+	rangePart, stepPart, hasStep := strings.Cut(item, "/")
+	step = 1
+	if hasStep {
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step < 1 {
+			return 0, 0, 0, fmt.Errorf("%s field: invalid step %q", f.name, stepPart)
+		}
+	}
+
+	if rangePart == "*" {
+		return f.min, f.max, step, nil
+	}
+	startText, endText, isRange := strings.Cut(rangePart, "-")
+	if start, err = f.value(startText); err != nil {
+		return 0, 0, 0, err
+	}
+	end = start
+	switch {
+	case isRange:
+		if end, err = f.value(endText); err != nil {
+			return 0, 0, 0, err
+		}
+		if end < start {
+			return 0, 0, 0, fmt.Errorf("%s field: range %q ends before it starts", f.name, rangePart)
+		}
+	case hasStep:
+		end = f.max
+	}
+	return start, end, step, nil
+}
+
+// value parses a number or name within the field's bounds.
+func (f field) value(text string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(text)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s field: invalid value %q", f.name, text)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s field: value %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (s *Schedule) parseDayOfMonth(text string) error {
+	s.domAny = text == "*" || text == "?"
+	if s.domAny {
+		return nil
+	}
+	f := field{name: "day-of-month", min: 1, max: 31}
+	for item := range strings.SplitSeq(text, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case upper == "L":
+			s.lastDay = true
+		case strings.HasPrefix(upper, "L-"):
+			n, err := strconv.Atoi(upper[2:])
+			if err != nil || n < 0 || n > 30 {
+				return fmt.Errorf("%s field: invalid offset in %q", f.name, item)
+			}
+			s.lastDay, s.lastOffset = true, n
+		case upper == "LW":
+			s.lastWeekday = true
+		case strings.HasSuffix(upper, "W"):
+			v, err := f.value(upper[:len(upper)-1])
+			if err != nil {
+				return err
+			}
+			s.weekday = append(s.weekday, v)
+		default:
+			set, err := parseField(item, f)
+			if err != nil {
+				return err
+			}
+			s.dom |= set
+		}
+	}
+	return nil
+}
+
+func (s *Schedule) parseDayOfWeek(text string) error {
+	s.dowAny = text == "*" || text == "?"
+	if s.dowAny {
+		return nil
+	}
+	f := field{name: "day-of-week", max: 7, names: dayNames}
+	if s.Quartz {
+		f.min = 1
+		f.names = map[string]int{}
+		for name, v := range dayNames {
+			f.names[name] = v + 1
+		}
+	}
+	// weekday maps a number of the field to a time.Weekday.
+	weekday := func(v int) int {
+		if s.Quartz {
+			return v - 1
+		}
+		return v % 7
+	}
+
+	for item := range strings.SplitSeq(text, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case strings.Contains(upper, "#"):
+			dayText, nText, _ := strings.Cut(upper, "#")
+			v, err := f.value(dayText)
+			if err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(nText)
+			if err != nil || n < 1 || n > 5 {
+				return fmt.Errorf("%s field: invalid occurrence in %q", f.name, item)
+			}
+			s.nth = append(s.nth, nthWeekday{weekday: weekday(v), n: n})
+		case len(upper) > 1 && strings.HasSuffix(upper, "L"):
+			v, err := f.value(upper[:len(upper)-1])
+			if err != nil {
+				return err
+			}
+			s.lastOf |= 1 << weekday(v)
+		case upper == "L":
+			// A lone L is the last day of the week, Saturday.
+			s.dow |= 1 << time.Saturday
+		default:
+			start, end, step, err := parseRange(item, f)
+			if err != nil {
+				return err
+			}
+			for v := start; v <= end; v += step {
+				s.dow |= 1 << weekday(v)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schedule) parseYear(text string) error {
+	if text == "*" {
+		return nil
+	}
+	f := field{name: "year", min: 1970, max: MaxYear}
+	s.years = map[int]bool{}
+	for item := range strings.SplitSeq(text, ",") {
+		start, end, step, err := parseRange(item, f)
+		if err != nil {
+			return err
+		}
+		for v := start; v <= end; v += step {
+			s.years[v] = true
+		}
+	}
+	return nil
+}
+
+// Next returns the first time after t, in t's location, at which the
+// schedule fires. It returns false when the schedule never fires again,
+// such as for @reboot or years that have passed.
+func (s *Schedule) Next(t time.Time) (time.Time, bool) {
+	if s.reboot {
+		return time.Time{}, false
+	}
+	if s.every > 0 {
+		return t.Truncate(time.Second).Add(s.every), true
+	}
+
+	loc := t.Location()
+	t = t.Truncate(time.Second).Add(time.Second)
+	for t.Year() <= MaxYear {
+		year, month, day := t.Date()
+		switch {
+		case s.years != nil && !s.years[year]:
+			t = time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+		case s.month&(1<<month) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		// Within a day, moving on in absolute time keeps the hour that
+		// repeats when daylight saving time ends.
+		case s.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Minute).Add(time.Duration(60-t.Minute()) * time.Minute)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case s.second&(1<<t.Second()) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Reboot reports whether the schedule is @reboot, which runs on startup
+// rather than at times Next could return.
+func (s *Schedule) Reboot() bool {
+	return s.reboot
+}
+
+// NextN returns up to n times after t at which the schedule fires.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for range n {
+		next, ok := s.Next(t)
+		if !ok {
+			break
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.domMatches(t)
+	dowMatch := s.dowMatches(t)
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	}
+	// Like cron, a day matches when either of the restricted day fields
+	// does.
+	return domMatch || dowMatch
+}
+
+func (s *Schedule) domMatches(t time.Time) bool {
+	day := t.Day()
+	if s.dom&(1<<day) != 0 {
+		return true
+	}
+	last := daysIn(t)
+	if s.lastDay && day == last-s.lastOffset {
+		return true
+	}
+	if s.lastWeekday && day == nearestWeekday(t, last) {
+		return true
+	}
+	for _, d := range s.weekday {
+		if d <= last && day == nearestWeekday(t, d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schedule) dowMatches(t time.Time) bool {
+	weekday := t.Weekday()
+	if s.dow&(1<<weekday) != 0 {
+		return true
+	}
+	if s.lastOf&(1<<weekday) != 0 && t.Day()+7 > daysIn(t) {
+		return true
+	}
+	for _, nth := range s.nth {
+		if int(weekday) == nth.weekday && (t.Day()-1)/7+1 == nth.n {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday closest to the day of t's month,
+// without leaving the month.
+func nearestWeekday(t time.Time, day int) int {
+	last := daysIn(t)
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// Locales lists the languages Describe supports.
+var Locales = []string{
+	"cs", "da", "de", "en", "es", "fa", "fi", "fr", "he", "it", "ja", "ko", "nb",
+	"nl", "pl", "pt_BR", "ro", "ru", "sk", "sl", "sv", "sw", "tr", "uk", "zh_CN", "zh_TW",
+}
+
+// Describe explains the schedule in words, in one of Locales. @every and
+// @reboot are only described in English.
+func (s *Schedule) Describe(locale string) (string, error) {
+	switch {
+	case s.reboot:
+		return "At system startup", nil
+	case s.every > 0:
+		return "Every " + s.every.String(), nil
+	}
+
+	loc, err := cron.ParseLocale(locale)
+	if err != nil {
+		return "", fmt.Errorf("unsupported locale %q, use one of %s", locale, strings.Join(Locales, ", "))
+	}
+	descriptor, err := cron.NewDescriptor(
+		cron.Use24HourTimeFormat(true),
+		cron.DayOfWeekStartsAtOne(s.Quartz),
+		cron.SetLocales(loc),
+	)
+	if err != nil {
+		return "", err
+	}
+	return descriptor.ToDescription(s.expanded, loc)
+}
@@ -0,0 +1,211 @@
+package cronexpr
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "", wantErr: "empty"},
+		{expr: "*/5 * * * *.", wantErr: `day-of-week field: invalid value "*."`},
+		{expr: "* * * *", wantErr: "expected 5 to 7 fields"},
+		{expr: "60 * * * *", wantErr: "value 60 out of range 0-59"},
+		{expr: "* 24 * * *", wantErr: "hour field"},
+		{expr: "* * 0 * *", wantErr: "day-of-month field"},
+		{expr: "* * * 13 *", wantErr: "month field"},
+		{expr: "* * * * 8", wantErr: "day-of-week field"},
+		{expr: "0 0 0 ? * 0", wantErr: "out of range 1-7"},
+		{expr: "0 0 ? * ?", wantErr: "both be ?"},
+		{expr: "5-1 * * * *", wantErr: "ends before it starts"},
+		{expr: "*/0 * * * *", wantErr: "invalid step"},
+		{expr: "? * * * *", wantErr: "minute field"},
+		{expr: "0 0 1 1 * 1969", wantErr: "year field"},
+		{expr: "@fortnightly", wantErr: "unknown macro"},
+		{expr: "@daily 5", wantErr: "unknown macro"},
+		{expr: "@every soon", wantErr: "invalid @every interval"},
+		{expr: "@every 10ms", wantErr: "shorter than a second"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %q", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// A Wednesday.
+	start := time.Date(2025, 1, 1, 12, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{expr: "*/20 * * * *", want: []string{"2025-01-01 12:40:00", "2025-01-01 13:00:00"}},
+		{expr: "0 9 * * MON-FRI", want: []string{"2025-01-02 09:00:00", "2025-01-03 09:00:00", "2025-01-06 09:00:00"}},
+		{expr: "0 0 * * 7", want: []string{"2025-01-05 00:00:00"}},
+		{expr: "30 */15 * * * *", want: []string{"2025-01-01 12:30:30", "2025-01-01 12:45:30"}},
+		{expr: "0 0 1 1 * 2027", want: []string{"2027-01-01 00:00:00"}},
+		{expr: "0 0 12 ? * 2 *", want: []string{"2025-01-06 12:00:00"}},
+		{expr: "0 0 L * *", want: []string{"2025-01-31 00:00:00", "2025-02-28 00:00:00"}},
+		{expr: "0 0 L-2 * *", want: []string{"2025-01-29 00:00:00"}},
+		{expr: "0 0 0 LW * ?", want: []string{"2025-01-31 00:00:00", "2025-02-28 00:00:00", "2025-03-31 00:00:00", "2025-04-30 00:00:00"}},
+		// February 1st is a Saturday, the 16th a Sunday.
+		{expr: "0 0 0 1W,16W FEB ?", want: []string{"2025-02-03 00:00:00", "2025-02-17 00:00:00"}},
+		{expr: "0 0 0 ? * 6L", want: []string{"2025-01-31 00:00:00", "2025-02-28 00:00:00"}},
+		{expr: "0 0 0 ? * MON#2", want: []string{"2025-01-13 00:00:00", "2025-02-10 00:00:00"}},
+		// Either restricted day field matches: the 15th and every Friday.
+		{expr: "0 0 15 * FRI", want: []string{"2025-01-03 00:00:00", "2025-01-10 00:00:00", "2025-01-15 00:00:00"}},
+		{expr: "0 0 29 2 *", want: []string{"2028-02-29 00:00:00"}},
+		{expr: "@weekly", want: []string{"2025-01-05 00:00:00"}},
+		{expr: "@every 1h30m", want: []string{"2025-01-01 14:00:15", "2025-01-01 15:30:15"}},
+		{expr: "@reboot", want: nil},
+		{expr: "0 0 1 1 * 2024", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+			}
+			var got []string
+			for _, next := range s.NextN(start, max(len(tt.want), 1)) {
+				got = append(got, next.Format(time.DateTime))
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAcrossDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	s, err := Parse("30 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clocks go back from 2:00 to 1:00 on November 2nd, 2025, so 1:30
+	// happens twice.
+	start := time.Date(2025, 11, 2, 0, 45, 0, 0, loc)
+	var got []string
+	for _, next := range s.NextN(start, 4) {
+		got = append(got, next.Format("15:04 MST"))
+	}
+	if want := "01:30 EDT, 01:30 EST, 02:30 EST, 03:30 EST"; strings.Join(got, ", ") != want {
+		t.Errorf("next = %v, want %s", got, want)
+	}
+}
+
+func TestQuestionMarkKeepsWeekdayNumbering(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, pair := range [][2]string{
+		{"0 0 ? * 6", "0 0 * * 6"},
+		{"0 0 ? * 0", "0 0 * * 7"},
+		{"0 0 ? * 1-5", "0 0 * * MON-FRI"},
+		{"0 0 ? * 5L", "0 0 * * 5L"},
+		{"0 0 * * 6 2030", "0 0 ? * 6 2030"},
+	} {
+		var got [2][]string
+		for i, expr := range pair {
+			s, err := Parse(expr)
+			if err != nil {
+				t.Fatalf("%s: %v", expr, err)
+			}
+			for _, next := range s.NextN(start, 3) {
+				got[i] = append(got[i], next.Format(time.DateTime+" Mon"))
+			}
+		}
+		if !slices.Equal(got[0], got[1]) {
+			t.Errorf("%q runs %v, but %q runs %v", pair[0], got[0], pair[1], got[1])
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr, locale, want string
+	}{
+		{expr: "*/5 * * * *", locale: "en", want: "Every 5 minutes"},
+		{expr: "0 9 * * 1", locale: "en", want: "At 09:00, only on Monday"},
+		{expr: "0 9 ? * 1", locale: "en", want: "At 09:00, only on Monday"},
+		{expr: "0 0 9 ? * 2", locale: "en", want: "At 09:00, only on Monday"},
+		{expr: "*/5 * * * *", locale: "de", want: "Alle 5 Minuten"},
+		{expr: "@daily", locale: "en", want: "At 00:00"},
+		{expr: "@every 90s", locale: "en", want: "Every 1m30s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+" "+tt.locale, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.Describe(tt.locale)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	s, _ := Parse("* * * * *")
+	if _, err := s.Describe("xx"); err == nil {
+		t.Error("expected an unsupported locale to fail")
+	}
+}
+
+func TestParseCrontab(t *testing.T) {
+	crontab := `# m h dom mon dow command
+SHELL=/bin/sh
+MAILTO = root
+
+*/5 * * * *   /usr/bin/backup  --quiet
+@reboot /usr/bin/start
+@every 1h ping example.com
+0 0 * * 8 broken
+15 3 * *
+`
+	entries := ParseCrontab(crontab)
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %+v", entries)
+	}
+
+	tests := []struct {
+		line       int
+		expression string
+		command    string
+		wantErr    bool
+	}{
+		{line: 5, expression: "*/5 * * * *", command: "/usr/bin/backup  --quiet"},
+		{line: 6, expression: "@reboot", command: "/usr/bin/start"},
+		{line: 7, expression: "@every 1h", command: "ping example.com"},
+		{line: 8, expression: "0 0 * * 8", command: "broken", wantErr: true},
+		{line: 9, expression: "15 3 * *", wantErr: true},
+	}
+	for i, tt := range tests {
+		got := entries[i]
+		if got.Line != tt.line || got.Expression != tt.expression || got.Command != tt.command {
+			t.Errorf("entry %d = %+v, want line %d %q %q", i, got, tt.line, tt.expression, tt.command)
+		}
+		if (got.Err != nil) != tt.wantErr || (got.Schedule == nil) != tt.wantErr {
+			t.Errorf("entry %d error = %v, want error %v", i, got.Err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cronexpr
+
+import (
+	"regexp"
+	"strings"
+)
+
+// variable matches crontab lines that set environment variables, such as
+// "MAILTO=root".
+var variable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\s*=`)
+
+// CrontabEntry is a scheduled line of a crontab.
+type CrontabEntry struct {
+	// Line is the 1-based line number in the crontab.
+	Line       int
+	Expression string
+	Command    string
+	// Schedule is nil when the expression doesn't parse, Err says why.
+	Schedule *Schedule
+	Err      error
+}
+
+// ParseCrontab returns the scheduled lines of a crontab, skipping blank
+// lines, comments and variable assignments. Lines of system crontabs start
+// their command with the user to run it as.
+func ParseCrontab(text string) []CrontabEntry {
+	var entries []CrontabEntry
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || variable.MatchString(line) {
+			continue
+		}
+
+		n := 5
+		if strings.HasPrefix(line, "@") {
+			n = 1
+			if strings.HasPrefix(strings.ToLower(line), "@every") {
+				n = 2
+			}
+		}
+		expression, command := splitFields(line, n)
+		schedule, err := Parse(expression)
+		entries = append(entries, CrontabEntry{
+			Line:       i + 1,
+			Expression: expression,
+			Command:    command,
+			Schedule:   schedule,
+			Err:        err,
+		})
+	}
+	return entries
+}
+
+// splitFields splits line after its first n whitespace-separated fields,
+// keeping the spacing within the rest.
+func splitFields(line string, n int) (head, rest string) {
+	rest = line
+	for range n {
+		rest = strings.TrimLeft(rest, " \t")
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			return line, ""
+		}
+		rest = rest[end:]
+	}
+	return strings.TrimSpace(line[:len(line)-len(rest)]), strings.TrimSpace(rest)
+}
@@ -0,0 +1,300 @@
+// Package detect guesses which devtui tools apply to a piece of text, so the
+// root TUI can suggest one for whatever was just copied or piped in.
+package detect
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lnquy/cron"
+	"github.com/skatkov/devtui/internal/base64"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/jwt"
+)
+
+// Suggestion is a tool that likely applies to the content. Tool is the id of
+// the tool in the root menu and Score ranges from 1 to 100.
+type Suggestion struct {
+	Tool   string
+	Score  int
+	Reason string
+}
+
+var (
+	cronField        = regexp.MustCompile(`^(\*|\?|[0-9A-Za-z]+([-/,#][0-9A-Za-z*]+)*L?W?|\*/[0-9]+|L(-[0-9]+)?)$`)
+	cronMacro        = regexp.MustCompile(`^@(annually|yearly|monthly|weekly|daily|hourly|reboot|every [0-9a-zµ.]+)$`)
+	radixNumber      = regexp.MustCompile(`^(0[xX][0-9a-fA-F_]+|0[bB][01_]+|0[oO][0-7_]+)$`)
+	base64Text       = regexp.MustCompile(`^[A-Za-z0-9+/]+={0,2}$`)
+	urlPattern       = regexp.MustCompile(`https?://[^\s<>"']+`)
+	graphqlStart     = regexp.MustCompile(`^(query|mutation|subscription|fragment)\b`)
+	htmlStart        = regexp.MustCompile(`(?i)^(<!doctype html|<html[\s>])|<(body|div|head|p|span|script)[\s>]`)
+	cssRule          = regexp.MustCompile(`(?m)^\s*[@.#:A-Za-z*\[][^{};]*\{[^{}]*:[^{}]*\}`)
+	markdownLine     = regexp.MustCompile(`(?m)^(#{1,6} \S|[-*] \S|\d+\. \S|> \S|` + "```" + `)`)
+	markdownLink     = regexp.MustCompile(`\[[^\]]+\]\([^)]+\)`)
+	markdownEmphasis = regexp.MustCompile(`\*\*[^*\n]+\*\*|__[^_\n]+__`)
+)
+
+// Detect ranks the tools that apply to content, best first. It returns nil
+// when nothing recognizes the content.
+func Detect(content string) []Suggestion {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return nil
+	}
+
+	var suggestions []Suggestion
+	add := func(tool string, score int, reason string) {
+		for i, s := range suggestions {
+			if s.Tool == tool {
+				if score > s.Score {
+					suggestions[i] = Suggestion{Tool: tool, Score: score, Reason: reason}
+				}
+				return
+			}
+		}
+		suggestions = append(suggestions, Suggestion{Tool: tool, Score: score, Reason: reason})
+	}
+
+	singleLine := !strings.ContainsAny(trimmed, "\r\n")
+	if singleLine {
+		detectToken(trimmed, add)
+	}
+	detectDocument(trimmed, add)
+
+	if urls := urlPattern.FindAllString(trimmed, -1); len(urls) > 0 {
+		if len(urls) == 1 && urls[0] == trimmed {
+			add("url-extractor", 40, "a URL")
+		} else {
+			add("url-extractor", 30+min(len(urls), 5)*5, "text with links")
+		}
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int { return b.Score - a.Score })
+	return suggestions
+}
+
+// detectToken recognizes single-line values: tokens, identifiers, numbers,
+// dates, cron expressions and Base64.
+func detectToken(value string, add func(string, int, string)) {
+	if strings.Count(value, ".") == 2 || strings.HasPrefix(value, "Bearer ") {
+		if _, err := jwt.Decode(value); err == nil {
+			add("jwt", 100, "a JSON Web Token")
+			return
+		}
+	}
+
+	if _, err := uuid.Parse(value); err == nil {
+		add("uuiddecode", 95, "a UUID")
+		return
+	}
+
+	if isCron(value) {
+		add("cron", 90, "a cron expression")
+		return
+	}
+
+	if isDigits(value) {
+		// 9 to 19 digits cover epoch seconds from 2001 up to nanoseconds.
+		if len(value) >= 9 && len(value) <= 19 {
+			add("timestamp", 85, "a Unix timestamp")
+			add("numbers", 40, "a number")
+		} else {
+			add("numbers", 70, "a number")
+		}
+		return
+	}
+	if radixNumber.MatchString(value) {
+		add("numbers", 85, "a "+radixName(value)+" number")
+		return
+	}
+	if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		add("timestamp", 80, "a date")
+		return
+	}
+
+	if isBase64Text(value) {
+		add("base64-decoder", 75, "Base64 encoded text")
+	}
+}
+
+// detectDocument recognizes multi-line or structured content.
+func detectDocument(content string, add func(string, int, string)) {
+	if graphqlStart.MatchString(content) && strings.Contains(content, "{") {
+		add("graphql-query", 80, "a GraphQL query")
+		return
+	}
+
+	if strings.HasPrefix(content, "<") {
+		if htmlStart.MatchString(content) {
+			add("html", 85, "an HTML document")
+			return
+		}
+		add("xml", 80, "an XML document")
+		return
+	}
+
+	format, err := converter.Detect(content)
+	if err == nil {
+		switch format {
+		case converter.FormatJSON:
+			if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+				add("json", 90, "a JSON document")
+				add("query", 50, "a JSON document")
+				add("jsonstruct", 45, "a JSON document")
+			}
+			return
+		case converter.FormatJSONL:
+			add("ndjson", 90, "JSON Lines")
+			add("query", 45, "JSON Lines")
+			return
+		case converter.FormatTOML:
+			add("toml", 75, "a TOML document")
+			add("toml2json", 50, "a TOML document")
+			return
+		case converter.FormatYAML:
+			if isYAMLDocument(content) {
+				add("yaml", 70, "a YAML document")
+				add("yamlstruct", 40, "a YAML document")
+				return
+			}
+		case converter.FormatTSV:
+			if isTable(content, '\t') {
+				add("tsv2md", 70, "a TSV table")
+				return
+			}
+		case converter.FormatCSV:
+			if isTable(content, ',') {
+				add("csv2json", 70, "a CSV table")
+				add("csv2md", 60, "a CSV table")
+				return
+			}
+		}
+	}
+
+	if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+		if !json.Valid([]byte(content)) && strings.Contains(content, ":") {
+			add("jsonrepair", 70, "broken JSON")
+			return
+		}
+	}
+
+	if cssRule.MatchString(content) {
+		add("css", 65, "a CSS stylesheet")
+		return
+	}
+
+	if markdownLine.MatchString(content) || markdownLink.MatchString(content) || markdownEmphasis.MatchString(content) {
+		add("markdown", 45, "Markdown text")
+	}
+}
+
+func isCron(value string) bool {
+	if cronMacro.MatchString(value) {
+		return true
+	}
+	fields := strings.Fields(value)
+	if len(fields) < 5 || len(fields) > 7 {
+		return false
+	}
+	hasSchedule := false
+	for _, field := range fields {
+		if !cronField.MatchString(field) {
+			return false
+		}
+		if strings.ContainsAny(field, "*0123456789") {
+			hasSchedule = true
+		}
+	}
+	if !hasSchedule {
+		return false
+	}
+	descriptor, err := cron.NewDescriptor()
+	if err != nil {
+		return false
+	}
+	_, err = descriptor.ToDescription(value, cron.Locale_en)
+	return err == nil
+}
+
+func isDigits(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func radixName(value string) string {
+	switch strings.ToLower(value[:2]) {
+	case "0x":
+		return "hexadecimal"
+	case "0b":
+		return "binary"
+	default:
+		return "octal"
+	}
+}
+
+// isBase64Text reports whether value is padded Base64 that decodes to
+// readable text. Short values and plain words are left out, since most
+// identifiers happen to be valid Base64.
+func isBase64Text(value string) bool {
+	if len(value) < 8 || len(value)%4 != 0 || !base64Text.MatchString(value) {
+		return false
+	}
+	if _, err := strconv.ParseUint(value, 16, 64); err == nil {
+		return false
+	}
+	decoded, err := base64.Decode(value)
+	if err != nil || !utf8.Valid(decoded) {
+		return false
+	}
+	for _, r := range string(decoded) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isYAMLDocument tells YAML apart from prose that happens to parse as a
+// YAML mapping, such as a single "Note: something" line.
+func isYAMLDocument(content string) bool {
+	if strings.HasPrefix(content, "---") {
+		return true
+	}
+	lines := 0
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines++
+	}
+	return lines > 1
+}
+
+// isTable reports whether content has at least two rows with the same
+// number of columns, and more than one column.
+func isTable(content string, delimiter rune) bool {
+	r := csv.NewReader(strings.NewReader(content))
+	r.Comma = delimiter
+	records, err := r.ReadAll()
+	if err != nil || len(records) < 2 {
+		return false
+	}
+	return len(records[0]) > 1
+}
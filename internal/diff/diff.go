@@ -0,0 +1,232 @@
+// Package diff compares two documents decoded by the converter and reports
+// the differences as paths, JSON Patch (RFC 6902) or JSON Merge Patch
+// (RFC 7386).
+//
+// Documents are compared semantically: key order and formatting are ignored,
+// integers and floats are equal when they hold the same number, and dates
+// are equal to strings with the same RFC 3339 text.
+package diff
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// Kind classifies a change.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Path addresses a value inside a document. Elements are object keys
+// (string) and array indexes (int).
+type Path []any
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// String renders the path in jq syntax, for example .servers[0].host.
+func (p Path) String() string {
+	if len(p) == 0 {
+		return "."
+	}
+	var b strings.Builder
+	for _, elem := range p {
+		switch v := elem.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(v) + "]")
+		case string:
+			if identifier.MatchString(v) {
+				b.WriteString("." + v)
+			} else {
+				b.WriteString("[" + strconv.Quote(v) + "]")
+			}
+		}
+	}
+	return b.String()
+}
+
+// Pointer renders the path as a JSON Pointer (RFC 6901).
+func (p Path) Pointer() string {
+	var b strings.Builder
+	for _, elem := range p {
+		b.WriteByte('/')
+		switch v := elem.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		case string:
+			v = strings.ReplaceAll(v, "~", "~0")
+			b.WriteString(strings.ReplaceAll(v, "/", "~1"))
+		}
+	}
+	return b.String()
+}
+
+func (p Path) append(elem any) Path {
+	return append(p[:len(p):len(p)], elem)
+}
+
+// Change is a single difference between two documents. Old is unset for
+// additions and New for removals.
+type Change struct {
+	Kind Kind
+	Path Path
+	Old  any
+	New  any
+}
+
+// Compare returns the changes that turn a into b, in document order. Arrays
+// are compared element by element; surplus elements are reported as removed
+// from the highest index down, so the changes can be applied in sequence.
+func Compare(a, b any) []Change {
+	var changes []Change
+	compare(nil, a, b, &changes)
+	return changes
+}
+
+func compare(path Path, a, b any, changes *[]Change) {
+	switch av := a.(type) {
+	case *converter.Object:
+		bv, ok := b.(*converter.Object)
+		if !ok {
+			break
+		}
+		for _, member := range av.Members {
+			value, ok := bv.Get(member.Key)
+			if !ok {
+				*changes = append(*changes, Change{Kind: Removed, Path: path.append(member.Key), Old: member.Value})
+				continue
+			}
+			compare(path.append(member.Key), member.Value, value, changes)
+		}
+		for _, member := range bv.Members {
+			if _, ok := av.Get(member.Key); !ok {
+				*changes = append(*changes, Change{Kind: Added, Path: path.append(member.Key), New: member.Value})
+			}
+		}
+		return
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		common := min(len(av), len(bv))
+		for i := range common {
+			compare(path.append(i), av[i], bv[i], changes)
+		}
+		for i := common; i < len(bv); i++ {
+			*changes = append(*changes, Change{Kind: Added, Path: path.append(i), New: bv[i]})
+		}
+		for i := len(av) - 1; i >= common; i-- {
+			*changes = append(*changes, Change{Kind: Removed, Path: path.append(i), Old: av[i]})
+		}
+		return
+	}
+	if !Equal(a, b) {
+		*changes = append(*changes, Change{Kind: Changed, Path: path, Old: a, New: b})
+	}
+}
+
+// Equal reports whether two values are semantically equal.
+func Equal(a, b any) bool {
+	switch av := a.(type) {
+	case *converter.Object:
+		bv, ok := b.(*converter.Object)
+		if !ok || av.Len() != bv.Len() {
+			return false
+		}
+		for _, member := range av.Members {
+			value, ok := bv.Get(member.Key)
+			if !ok || !Equal(member.Value, value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !Equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Integers beyond int64 are only equal to the same digits, as floats
+	// would round them together.
+	if an, ok := a.(json.Number); ok {
+		bn, ok := b.(json.Number)
+		return ok && an == bn
+	}
+	if _, ok := b.(json.Number); ok {
+		return false
+	}
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		if !ok {
+			return false
+		}
+		if ai, ok := a.(int64); ok {
+			if bi, ok := b.(int64); ok {
+				return ai == bi
+			}
+		}
+		return af == bf
+	}
+	if as, ok := stringValue(a); ok {
+		bs, ok := stringValue(b)
+		return ok && as == bs
+	}
+	return a == b
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func stringValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	default:
+		return "", false
+	}
+}
+
+// FormatValue renders a value as compact JSON for reports.
+func FormatValue(value any) string {
+	if s, ok := stringValue(value); ok {
+		value = s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		// NaN and infinities have no JSON representation.
+		if f, ok := value.(float64); ok {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return err.Error()
+	}
+	return string(data)
+}
@@ -0,0 +1,235 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+func decode(t *testing.T, content string, format converter.Format) any {
+	t.Helper()
+	value, err := converter.Decode(content, format)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return value
+}
+
+func encodeJSON(t *testing.T, value any) string {
+	t.Helper()
+	out, err := converter.Encode(value, converter.FormatJSON)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return strings.TrimSpace(out)
+}
+
+func TestCompareIgnoresFormattingAndKeyOrder(t *testing.T) {
+	a := decode(t, `{"name":"api","port":8080,"ratio":1.0,"tags":["a","b"]}`, converter.FormatJSON)
+	b := decode(t, "tags: [a, b]\nratio: 1\nport: 8080\nname: api\n", converter.FormatYAML)
+
+	if changes := Compare(a, b); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := decode(t, `{"server":{"host":"a","port":8080},"debug":false,"items":[1,2,3]}`, converter.FormatJSON)
+	b := decode(t, `{"server":{"host":"a","port":9090,"tls":true},"items":[1,5]}`, converter.FormatJSON)
+
+	got := Compare(a, b)
+	want := []struct {
+		kind Kind
+		path string
+	}{
+		{Changed, ".server.port"},
+		{Added, ".server.tls"},
+		{Removed, ".debug"},
+		{Changed, ".items[1]"},
+		{Removed, ".items[2]"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Compare() returned %d changes, want %d: %+v", len(got), len(want), got)
+	}
+	for i, change := range got {
+		if change.Kind != want[i].kind || change.Path.String() != want[i].path {
+			t.Errorf("change %d = %s %s, want %s %s", i, change.Kind, change.Path, want[i].kind, want[i].path)
+		}
+	}
+}
+
+func TestCompareBigIntegers(t *testing.T) {
+	a := decode(t, `{"id":12345678901234567890,"max":9223372036854775807}`, converter.FormatJSON)
+	b := decode(t, `{"id":12345678901234567891,"max":9223372036854775808}`, converter.FormatJSON)
+
+	got := Compare(a, b)
+	if len(got) != 2 || got[0].Path.String() != ".id" || got[1].Path.String() != ".max" {
+		t.Fatalf("Compare() = %+v, want .id and .max changed", got)
+	}
+	if changes := Compare(a, a); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestPathPointer(t *testing.T) {
+	path := Path{"a/b", "m~n", 0, "key"}
+	if got := path.Pointer(); got != "/a~1b/m~0n/0/key" {
+		t.Fatalf("Pointer() = %q", got)
+	}
+	if got := path.String(); got != `["a/b"]["m~n"][0].key` {
+		t.Fatalf("String() = %q", got)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	a := decode(t, `{"a":1,"b":[1,2,3],"c":null}`, converter.FormatJSON)
+	b := decode(t, `{"a":2,"b":[1],"c":null,"d":{"e":true}}`, converter.FormatJSON)
+
+	got := encodeJSON(t, JSONPatch(Compare(a, b)))
+	want := `[
+  {
+    "op": "replace",
+    "path": "/a",
+    "value": 2
+  },
+  {
+    "op": "remove",
+    "path": "/b/2"
+  },
+  {
+    "op": "remove",
+    "path": "/b/1"
+  },
+  {
+    "op": "add",
+    "path": "/d",
+    "value": {
+      "e": true
+    }
+  }
+]`
+	if got != want {
+		t.Fatalf("JSONPatch() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "nested objects",
+			a:    `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"text"}`,
+			b:    `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"text","phoneNumber":"+01-123-456-7890"}`,
+			want: `{"title":"Hello!","author":{"familyName":null},"tags":["example"],"phoneNumber":"+01-123-456-7890"}`,
+		},
+		{
+			name: "identical",
+			a:    `{"a":[1,2]}`,
+			b:    `{"a":[1,2]}`,
+			want: `{}`,
+		},
+		{
+			name: "not objects",
+			a:    `[1]`,
+			b:    `[2]`,
+			want: `[2]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := MergePatch(decode(t, tt.a, converter.FormatJSON), decode(t, tt.b, converter.FormatJSON))
+			var got bytes.Buffer
+			if err := json.Compact(&got, []byte(encodeJSON(t, patch))); err != nil {
+				t.Fatalf("Compact() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Fatalf("MergePatch() = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTree(t *testing.T) {
+	a := decode(t, `{"server":{"host":"a","port":8080},"debug":false,"list":[{"id":1}]}`, converter.FormatJSON)
+	b := decode(t, `{"server":{"host":"a","port":9090,"tls":true},"list":[{"id":2}]}`, converter.FormatJSON)
+
+	got := Tree(Compare(a, b), TreeStyles{})
+	want := `server
+  ~ port: 8080 → 9090
+  + tls: true
+- debug: false
+list
+  [0]
+    ~ id: 1 → 2
+`
+	if got != want {
+		t.Fatalf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLines(t *testing.T) {
+	left := []string{"{", `  "a": 1,`, `  "b": 2,`, `  "c": 3`, "}"}
+	right := []string{"{", `  "a": 1,`, `  "b": 5,`, `  "c": 3,`, `  "d": 4`, "}"}
+
+	rows := Lines(left, right)
+	var kinds []RowKind
+	for _, row := range rows {
+		kinds = append(kinds, row.Kind)
+	}
+	want := []RowKind{RowEqual, RowEqual, RowChanged, RowChanged, RowAdded, RowEqual}
+	if !slices.Equal(kinds, want) {
+		t.Fatalf("Lines() kinds = %v, want %v", kinds, want)
+	}
+	if rows[2].Left != `  "b": 2,` || rows[2].Right != `  "b": 5,` {
+		t.Fatalf("unexpected pairing: %+v", rows[2])
+	}
+}
+
+func TestUnified(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+
+	got := Unified("old.txt", "new.txt", before, after, 2)
+	want := `--- old.txt
++++ new.txt
+@@ -1,4 +1,4 @@
+ a
+-b
++B
+ c
+ d
+@@ -9,3 +9,4 @@
+ i
+ j
+-k
+\ No newline at end of file
++k
++l
+`
+	if got != want {
+		t.Fatalf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := Unified("a", "b", before, before, 3); got != "" {
+		t.Fatalf("Unified() of equal texts = %q, want empty", got)
+	}
+
+	// Changes closer than twice the context share a hunk.
+	got = Unified("a", "b", "1\n2\n3\n4\n5\n", "0\n2\n3\n4\n6\n", 1)
+	if strings.Count(got, "@@ -") != 2 {
+		t.Fatalf("expected two hunks with context 1:\n%s", got)
+	}
+	got = Unified("a", "b", "1\n2\n3\n4\n5\n", "0\n2\n3\n4\n6\n", 2)
+	if strings.Count(got, "@@ -") != 1 || !strings.Contains(got, "@@ -1,5 +1,5 @@") {
+		t.Fatalf("expected one hunk with context 2:\n%s", got)
+	}
+}
@@ -0,0 +1,110 @@
+package diff
+
+// RowKind classifies a row of a side-by-side view.
+type RowKind int
+
+const (
+	RowEqual RowKind = iota
+	RowAdded
+	RowRemoved
+	RowChanged
+)
+
+// Row pairs a line of the left document with a line of the right one. Added
+// rows have no left line and removed rows no right line.
+type Row struct {
+	Kind  RowKind
+	Left  string
+	Right string
+}
+
+// maxLCSCells bounds the memory of the line alignment. Larger differing
+// regions are paired line by line instead.
+const maxLCSCells = 4_000_000
+
+// Lines aligns two documents line by line for a side-by-side view, using the
+// longest common subsequence of the lines. Runs of removed and added lines
+// are paired up as changed rows.
+func Lines(left, right []string) []Row {
+	prefix := 0
+	for prefix < len(left) && prefix < len(right) && left[prefix] == right[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(left)-prefix && suffix < len(right)-prefix &&
+		left[len(left)-1-suffix] == right[len(right)-1-suffix] {
+		suffix++
+	}
+
+	rows := make([]Row, 0, max(len(left), len(right)))
+	for i := range prefix {
+		rows = append(rows, Row{Kind: RowEqual, Left: left[i], Right: right[i]})
+	}
+	rows = append(rows, align(left[prefix:len(left)-suffix], right[prefix:len(right)-suffix])...)
+	for i := len(left) - suffix; i < len(left); i++ {
+		rows = append(rows, Row{Kind: RowEqual, Left: left[i], Right: right[i-len(left)+len(right)]})
+	}
+	return rows
+}
+
+func align(left, right []string) []Row {
+	if len(left)*len(right) > maxLCSCells {
+		return pair(left, right)
+	}
+
+	// lengths[i][j] is the LCS length of left[i:] and right[j:].
+	width := len(right) + 1
+	lengths := make([]int, (len(left)+1)*width)
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lengths[i*width+j] = lengths[(i+1)*width+j+1] + 1
+			} else {
+				lengths[i*width+j] = max(lengths[(i+1)*width+j], lengths[i*width+j+1])
+			}
+		}
+	}
+
+	var (
+		rows           []Row
+		removed, added []string
+		i, j           int
+	)
+	flush := func() {
+		rows = append(rows, pair(removed, added)...)
+		removed, added = nil, nil
+	}
+	for i < len(left) || j < len(right) {
+		switch {
+		case i < len(left) && j < len(right) && left[i] == right[j]:
+			flush()
+			rows = append(rows, Row{Kind: RowEqual, Left: left[i], Right: right[j]})
+			i++
+			j++
+		case j == len(right) || (i < len(left) && lengths[(i+1)*width+j] >= lengths[i*width+j+1]):
+			removed = append(removed, left[i])
+			i++
+		default:
+			added = append(added, right[j])
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+// pair matches removed and added lines one to one as changed rows.
+func pair(removed, added []string) []Row {
+	rows := make([]Row, 0, max(len(removed), len(added)))
+	for i := range max(len(removed), len(added)) {
+		switch {
+		case i >= len(removed):
+			rows = append(rows, Row{Kind: RowAdded, Right: added[i]})
+		case i >= len(added):
+			rows = append(rows, Row{Kind: RowRemoved, Left: removed[i]})
+		default:
+			rows = append(rows, Row{Kind: RowChanged, Left: removed[i], Right: added[i]})
+		}
+	}
+	return rows
+}
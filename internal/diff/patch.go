@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// JSONPatch converts changes into a JSON Patch (RFC 6902) document. The
+// result is a converter tree, so it can be encoded in any format.
+func JSONPatch(changes []Change) []any {
+	patch := make([]any, 0, len(changes))
+	for _, change := range changes {
+		op := converter.NewObject()
+		switch change.Kind {
+		case Added:
+			op.Set("op", "add")
+			op.Set("path", change.Path.Pointer())
+			op.Set("value", change.New)
+		case Removed:
+			op.Set("op", "remove")
+			op.Set("path", change.Path.Pointer())
+		case Changed:
+			op.Set("op", "replace")
+			op.Set("path", change.Path.Pointer())
+			op.Set("value", change.New)
+		}
+		patch = append(patch, op)
+	}
+	return patch
+}
+
+// MergePatch returns the JSON Merge Patch (RFC 7386) that turns a into b.
+// Arrays are replaced as a whole, and a null value in b cannot be told apart
+// from a removal, as the RFC notes. Identical documents yield an empty
+// object.
+func MergePatch(a, b any) any {
+	av, aok := a.(*converter.Object)
+	bv, bok := b.(*converter.Object)
+	if !aok || !bok {
+		if Equal(a, b) {
+			return converter.NewObject()
+		}
+		return b
+	}
+
+	patch := converter.NewObject()
+	for _, member := range av.Members {
+		if _, ok := bv.Get(member.Key); !ok {
+			patch.Set(member.Key, nil)
+		}
+	}
+	for _, member := range bv.Members {
+		old, ok := av.Get(member.Key)
+		switch {
+		case !ok:
+			patch.Set(member.Key, member.Value)
+		case Equal(old, member.Value):
+		default:
+			if _, isObject := member.Value.(*converter.Object); isObject {
+				if _, wasObject := old.(*converter.Object); wasObject {
+					patch.Set(member.Key, MergePatch(old, member.Value))
+					continue
+				}
+			}
+			patch.Set(member.Key, member.Value)
+		}
+	}
+	return patch
+}
@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// TreeStyles colors the markers and values of a tree report.
+type TreeStyles struct {
+	Added   lipgloss.Style
+	Removed lipgloss.Style
+	Changed lipgloss.Style
+	Path    lipgloss.Style
+}
+
+// DefaultTreeStyles returns the usual green, red and yellow diff colors.
+func DefaultTreeStyles() TreeStyles {
+	return TreeStyles{
+		Added:   lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		Removed: lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Changed: lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+		Path:    lipgloss.NewStyle().Bold(true),
+	}
+}
+
+// Tree renders changes as an indented tree of the paths they touch:
+//
+//	server
+//	  ~ port: 8080 → 9090
+//	  + tls: true
+//	- debug: false
+//
+// Changes must be in document order, as returned by Compare.
+func Tree(changes []Change, styles TreeStyles) string {
+	var (
+		b      strings.Builder
+		parent Path
+	)
+	for _, change := range changes {
+		dir := change.Path
+		if len(dir) > 0 {
+			dir = dir[:len(dir)-1]
+		}
+
+		// Print the headers of the parents not shared with the previous
+		// change.
+		shared := 0
+		for shared < len(dir) && shared < len(parent) && dir[shared] == parent[shared] {
+			shared++
+		}
+		for depth := shared; depth < len(dir); depth++ {
+			b.WriteString(strings.Repeat("  ", depth))
+			b.WriteString(styles.Path.Render(segment(dir[depth])))
+			b.WriteByte('\n')
+		}
+		parent = dir
+
+		name := "."
+		if len(change.Path) > 0 {
+			name = segment(change.Path[len(change.Path)-1])
+		}
+		b.WriteString(strings.Repeat("  ", len(dir)))
+		switch change.Kind {
+		case Added:
+			b.WriteString(styles.Added.Render("+ " + name + ": " + FormatValue(change.New)))
+		case Removed:
+			b.WriteString(styles.Removed.Render("- " + name + ": " + FormatValue(change.Old)))
+		case Changed:
+			b.WriteString(styles.Changed.Render("~ " + name + ": " + FormatValue(change.Old) + " → " + FormatValue(change.New)))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func segment(elem any) string {
+	switch v := elem.(type) {
+	case int:
+		return "[" + strconv.Itoa(v) + "]"
+	case string:
+		if identifier.MatchString(v) {
+			return v
+		}
+		return strconv.Quote(v)
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unified renders the line differences between two texts as a unified diff
+// with context lines around every change, as produced by diff -u. It returns
+// an empty string when the texts are equal.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+
+	ops := unifiedOps(Lines(splitLines(oldText), splitLines(newText)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	// oldLine and newLine count the lines of each side before ops[i].
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if op.kind != '+' {
+			oldLine[i+1]++
+		}
+		if op.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough for the
+		// context of both to overlap.
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+		from := max(start-context, 0)
+		to := min(end+context, len(ops))
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[from], oldLine[to]-oldLine[from]),
+			hunkRange(newLine[from], newLine[to]-newLine[from]))
+		for _, op := range ops[from:to] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = to
+	}
+	return b.String()
+}
+
+type unifiedOp struct {
+	kind byte
+	line string
+}
+
+// unifiedOps flattens aligned rows into diff lines, listing the removed
+// lines of every run of changes before the added ones.
+func unifiedOps(rows []Row) []unifiedOp {
+	var (
+		ops            []unifiedOp
+		removed, added []unifiedOp
+	)
+	flush := func() {
+		ops = append(ops, removed...)
+		ops = append(ops, added...)
+		removed, added = nil, nil
+	}
+	for _, row := range rows {
+		switch row.Kind {
+		case RowEqual:
+			flush()
+			ops = append(ops, unifiedOp{' ', row.Left})
+		case RowRemoved:
+			removed = append(removed, unifiedOp{'-', row.Left})
+		case RowAdded:
+			added = append(added, unifiedOp{'+', row.Right})
+		case RowChanged:
+			removed = append(removed, unifiedOp{'-', row.Left})
+			added = append(added, unifiedOp{'+', row.Right})
+		}
+	}
+	flush()
+	return ops
+}
+
+// splitLines splits text after every newline, so that a missing final
+// newline counts as a difference.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// hunkRange formats the start and length of one side of a hunk. An empty
+// range starts at the line before it.
+func hunkRange(before, count int) string {
+	start := before + 1
+	if count == 0 {
+		start = before
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,290 @@
+package hashutil
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/zeebo/blake3"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Algorithm describes a supported digest algorithm.
+type Algorithm struct {
+	Name  string
+	Label string
+	New   func() hash.Hash
+}
+
+// Encoding describes how digests are rendered.
+type Encoding string
+
+const (
+	EncodingHex    Encoding = "hex"
+	EncodingBase64 Encoding = "base64"
+)
+
+// Digest is a computed digest for a single algorithm.
+type Digest struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// Result contains digests computed for an input.
+type Result struct {
+	Source   string   `json:"source"`
+	Encoding Encoding `json:"encoding"`
+	Digests  []Digest `json:"digests"`
+}
+
+// CheckResult is the outcome of verifying a single checksum file entry.
+type CheckResult struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual,omitempty"`
+	OK        bool   `json:"ok"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Algorithms lists supported digest algorithms.
+var Algorithms = []Algorithm{
+	{Name: "md5", Label: "MD5", New: md5.New},
+	{Name: "sha1", Label: "SHA-1", New: sha1.New},
+	{Name: "sha224", Label: "SHA-224", New: sha256.New224},
+	{Name: "sha256", Label: "SHA-256", New: sha256.New},
+	{Name: "sha384", Label: "SHA-384", New: sha512.New384},
+	{Name: "sha512", Label: "SHA-512", New: sha512.New},
+	{Name: "sha3-224", Label: "SHA3-224", New: func() hash.Hash { return sha3.New224() }},
+	{Name: "sha3-256", Label: "SHA3-256", New: func() hash.Hash { return sha3.New256() }},
+	{Name: "sha3-384", Label: "SHA3-384", New: func() hash.Hash { return sha3.New384() }},
+	{Name: "sha3-512", Label: "SHA3-512", New: func() hash.Hash { return sha3.New512() }},
+	{Name: "blake2b-256", Label: "BLAKE2b-256", New: mustBlake2b(blake2b.New256)},
+	{Name: "blake2b-512", Label: "BLAKE2b-512", New: mustBlake2b(blake2b.New512)},
+	{Name: "blake3", Label: "BLAKE3", New: func() hash.Hash { return blake3.New() }},
+}
+
+// Names returns the names of all supported algorithms.
+func Names() []string {
+	names := make([]string, 0, len(Algorithms))
+	for _, algorithm := range Algorithms {
+		names = append(names, algorithm.Name)
+	}
+	return names
+}
+
+// Lookup finds an algorithm by name. Names are matched case-insensitively and
+// dashes are optional, so "SHA-256", "sha256" and "Sha256" are equivalent.
+func Lookup(name string) (Algorithm, error) {
+	normalized := normalizeName(name)
+	for _, algorithm := range Algorithms {
+		if normalizeName(algorithm.Name) == normalized || normalizeName(algorithm.Label) == normalized {
+			return algorithm, nil
+		}
+	}
+	return Algorithm{}, fmt.Errorf("unsupported hash algorithm: %s (supported: %s)", name, strings.Join(Names(), ", "))
+}
+
+// ParseEncoding validates an encoding name.
+func ParseEncoding(name string) (Encoding, error) {
+	switch Encoding(strings.ToLower(name)) {
+	case EncodingHex:
+		return EncodingHex, nil
+	case EncodingBase64:
+		return EncodingBase64, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding: %s (supported: hex, base64)", name)
+	}
+}
+
+// Sum reads r once and computes digests for every requested algorithm.
+func Sum(r io.Reader, algorithms []Algorithm, encoding Encoding) ([]Digest, error) {
+	hashes := make([]hash.Hash, len(algorithms))
+	writers := make([]io.Writer, len(algorithms))
+	for i, algorithm := range algorithms {
+		hashes[i] = algorithm.New()
+		writers[i] = hashes[i]
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+
+	digests := make([]Digest, 0, len(algorithms))
+	for i, algorithm := range algorithms {
+		digests = append(digests, Digest{
+			Algorithm: algorithm.Name,
+			Value:     encode(hashes[i].Sum(nil), encoding),
+		})
+	}
+	return digests, nil
+}
+
+// SumString computes digests of a string for every requested algorithm.
+func SumString(s string, algorithms []Algorithm, encoding Encoding) []Digest {
+	// Reading from a strings.Reader never fails.
+	digests, _ := Sum(strings.NewReader(s), algorithms, encoding)
+	return digests
+}
+
+// Check verifies every entry of a checksum file. Both the GNU coreutils format
+// ("<digest>  <file>") and the BSD tag format ("SHA256 (<file>) = <digest>")
+// are understood. When algorithm is empty, it is inferred from each line.
+// open is used to read the files referenced by the checksum file.
+func Check(checksums io.Reader, algorithm string, open func(name string) (io.ReadCloser, error)) ([]CheckResult, error) {
+	var results []CheckResult
+
+	scanner := bufio.NewScanner(checksums)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry, err := parseChecksumLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		name := algorithm
+		if name == "" {
+			name = entry.algorithm
+		}
+		alg, err := resolveCheckAlgorithm(name, entry.digest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		results = append(results, verify(entry, alg, open))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.New("no checksum lines found")
+	}
+
+	return results, nil
+}
+
+type checksumEntry struct {
+	algorithm string
+	digest    string
+	name      string
+}
+
+var bsdChecksumLine = regexp.MustCompile(`^([A-Za-z0-9-]+) \((.+)\) = ([0-9A-Za-z+/=]+)$`)
+
+func parseChecksumLine(line string) (checksumEntry, error) {
+	if matches := bsdChecksumLine.FindStringSubmatch(line); matches != nil {
+		return checksumEntry{algorithm: matches[1], name: matches[2], digest: matches[3]}, nil
+	}
+
+	digest, name, ok := strings.Cut(line, " ")
+	if !ok {
+		return checksumEntry{}, fmt.Errorf("malformed checksum line: %q", line)
+	}
+	// GNU tools separate digest and name with two characters: a space and
+	// either another space (text mode) or an asterisk (binary mode).
+	name = strings.TrimPrefix(name, " ")
+	name = strings.TrimPrefix(name, "*")
+	if name == "" {
+		return checksumEntry{}, fmt.Errorf("malformed checksum line: %q", line)
+	}
+
+	return checksumEntry{digest: digest, name: name}, nil
+}
+
+func resolveCheckAlgorithm(name, digest string) (Algorithm, error) {
+	if name != "" {
+		return Lookup(name)
+	}
+
+	// Only hex digests can be inferred: base64 checksum files always carry
+	// their algorithm in the BSD tag format.
+	switch len(digest) {
+	case md5.Size * 2:
+		return Lookup("md5")
+	case sha1.Size * 2:
+		return Lookup("sha1")
+	case sha256.Size224 * 2:
+		return Lookup("sha224")
+	case sha256.Size * 2:
+		return Lookup("sha256")
+	case sha512.Size384 * 2:
+		return Lookup("sha384")
+	case sha512.Size * 2:
+		return Lookup("sha512")
+	default:
+		return Algorithm{}, fmt.Errorf("cannot infer algorithm for digest %q, use --algorithm", digest)
+	}
+}
+
+func verify(entry checksumEntry, algorithm Algorithm, open func(name string) (io.ReadCloser, error)) CheckResult {
+	result := CheckResult{
+		Name:      entry.name,
+		Algorithm: algorithm.Name,
+		Expected:  entry.digest,
+	}
+
+	f, err := open(entry.name)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer func() { _ = f.Close() }()
+
+	encoding := EncodingHex
+	if _, err := hex.DecodeString(entry.digest); err != nil {
+		encoding = EncodingBase64
+	}
+
+	digests, err := Sum(f, []Algorithm{algorithm}, encoding)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Actual = digests[0].Value
+	if encoding == EncodingHex {
+		result.OK = strings.EqualFold(result.Actual, entry.digest)
+	} else {
+		result.OK = result.Actual == entry.digest
+	}
+	return result
+}
+
+func encode(sum []byte, encoding Encoding) string {
+	if encoding == EncodingBase64 {
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+	return hex.EncodeToString(sum)
+}
+
+func normalizeName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "")
+}
+
+func mustBlake2b(constructor func(key []byte) (hash.Hash, error)) func() hash.Hash {
+	return func() hash.Hash {
+		// Constructors only fail for keys longer than 64 bytes; we never pass a key.
+		h, err := constructor(nil)
+		if err != nil {
+			panic(err)
+		}
+		return h
+	}
+}
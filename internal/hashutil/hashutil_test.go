@@ -0,0 +1,109 @@
+package hashutil
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSumStringKnownDigests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		algorithm string
+		encoding  Encoding
+		want      string
+	}{
+		{"md5", EncodingHex, "5eb63bbbe01eeed093cb22bb8f5acdc3"},
+		{"sha1", EncodingHex, "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed"},
+		{"sha256", EncodingHex, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
+		{"sha3-256", EncodingHex, "644bcc7e564373040999aac89e7622f3ca71fba1d972fd94a31c3bfbf24e3938"},
+		{"blake3", EncodingHex, "d74981efa70a0c880b8d8c1985d075dbcbf679b99a5f9914e5aaf96b831a9e24"},
+		{"sha256", EncodingBase64, "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm+"-"+string(tt.encoding), func(t *testing.T) {
+			t.Parallel()
+
+			algorithm, err := Lookup(tt.algorithm)
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tt.algorithm, err)
+			}
+
+			digests := SumString("hello world", []Algorithm{algorithm}, tt.encoding)
+			if got := digests[0].Value; got != tt.want {
+				t.Fatalf("digest = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupNormalizesNames(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"SHA-256", "sha256", "Sha256", "SHA3-512", "blake2b-256"} {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("Lookup(%q) error = %v", name, err)
+		}
+	}
+
+	if _, err := Lookup("crc32"); err == nil {
+		t.Error("expected error for unsupported algorithm")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"good.txt": "hello world",
+		"bad.txt":  "goodbye",
+	}
+	open := func(name string) (io.ReadCloser, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, errors.New("no such file")
+		}
+		return io.NopCloser(strings.NewReader(content)), nil
+	}
+
+	checksums := strings.Join([]string{
+		"# generated by sha256sum",
+		"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  good.txt",
+		"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9 *bad.txt",
+		"MD5 (good.txt) = 5eb63bbbe01eeed093cb22bb8f5acdc3",
+		"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  missing.txt",
+	}, "\n")
+
+	results, err := Check(strings.NewReader(checksums), "", open)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+
+	wantOK := []bool{true, false, true, false}
+	for i, result := range results {
+		if result.OK != wantOK[i] {
+			t.Errorf("result %d (%s) OK = %v, want %v", i, result.Name, result.OK, wantOK[i])
+		}
+	}
+	if results[2].Algorithm != "md5" {
+		t.Errorf("expected BSD line to use md5, got %s", results[2].Algorithm)
+	}
+	if results[3].Error == "" {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestCheckRejectsMalformedLines(t *testing.T) {
+	t.Parallel()
+
+	_, err := Check(strings.NewReader("not-a-checksum-line"), "", nil)
+	if err == nil {
+		t.Fatal("expected error for malformed checksum line")
+	}
+}
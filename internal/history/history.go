@@ -0,0 +1,310 @@
+// Package history keeps the recent inputs and outputs of the TUI tools
+// showing their result in a pager under the XDG data directory, so that they
+// reopen with their last input. Tools built as forms, such as the cron
+// parser or the password generator, keep no history.
+//
+// It is configured in the [history] table of the devtui configuration:
+//
+//	[history]
+//	enabled = true
+//	max-entries = 50
+//	disable = ["hash"]
+//	encrypt = ["jwt"]
+//
+// Encrypted logs use AES-GCM with the key in history.key, or with a key
+// derived with scrypt from the DEVTUI_HISTORY_KEY passphrase when it is set.
+// The passphrase is only read from the environment, so that it can't end up
+// in a committed .devtui.toml.
+package history
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/skatkov/devtui/internal/config"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// DefaultMaxEntries is how many entries a log keeps unless configured.
+	DefaultMaxEntries = 50
+	// MaxEntrySize is the largest input plus output stored. Larger outputs
+	// are dropped, and entries whose input alone is larger aren't stored.
+	MaxEntrySize = 256 << 10
+	// KeyEnv is the environment variable holding the passphrase of
+	// encrypted logs.
+	KeyEnv = "DEVTUI_HISTORY_KEY"
+)
+
+// DefaultEncrypted lists the tools whose history is encrypted unless
+// configured otherwise, since their inputs are secrets.
+var DefaultEncrypted = []string{"jwt"}
+
+// SettingKeys are the configuration keys read by this package.
+var SettingKeys = []string{
+	"history.enabled",
+	"history.max-entries",
+	"history.disable",
+	"history.encrypt",
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Entry is one input given to a tool and the output it produced.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Input  string    `json:"input"`
+	Output string    `json:"output,omitempty"`
+}
+
+// Log is the history of one tool. A nil *Log keeps no history, so callers
+// can use it without checking whether history is enabled.
+type Log struct {
+	path       string
+	key        []byte
+	maxEntries int
+}
+
+// Dir returns the directory history is stored in.
+func Dir() string {
+	return filepath.Join(xdg.DataHome, "devtui", "history")
+}
+
+// KeyPath returns the file holding the key of encrypted logs. It lives with
+// the configuration rather than the data, so copying the history alone
+// doesn't reveal it.
+func KeyPath() string {
+	return filepath.Join(xdg.ConfigHome, "devtui", "history.key")
+}
+
+// SaltPath returns the file holding the salt the passphrase key is derived
+// with.
+func SaltPath() string {
+	return filepath.Join(Dir(), "passphrase.salt")
+}
+
+// ForTool returns the log of the tool with the given id, configured by cfg,
+// or nil when history is disabled for it.
+func ForTool(cfg *config.Config, tool string) (*Log, error) {
+	if !cfg.Bool("history.enabled", true) || slices.Contains(toolList(cfg, "history.disable", nil), tool) {
+		return nil, nil
+	}
+
+	name := unsafeChars.ReplaceAllString(tool, "_")
+	log := &Log{
+		path:       filepath.Join(Dir(), name+".json"),
+		maxEntries: max(cfg.Int("history.max-entries", DefaultMaxEntries), 1),
+	}
+	if slices.Contains(toolList(cfg, "history.encrypt", DefaultEncrypted), tool) {
+		key, err := loadKey(os.Getenv(KeyEnv))
+		if err != nil {
+			return nil, fmt.Errorf("history key: %w", err)
+		}
+		log.path = filepath.Join(Dir(), name+".enc")
+		log.key = key
+	}
+	return log, nil
+}
+
+func toolList(cfg *config.Config, key string, def []string) []string {
+	value := cfg.String(key, strings.Join(def, ","))
+	var tools []string
+	for tool := range strings.SplitSeq(value, ",") {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// Encrypted reports whether the log is stored encrypted.
+func (l *Log) Encrypted() bool {
+	return l != nil && l.key != nil
+}
+
+// Entries returns the entries, newest first.
+func (l *Log) Entries() ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if l.key != nil {
+		if data, err = decrypt(l.key, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", l.path, err)
+		}
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", l.path, err)
+	}
+	return entries, nil
+}
+
+// Last returns the newest entry.
+func (l *Log) Last() (Entry, bool) {
+	entries, err := l.Entries()
+	if err != nil || len(entries) == 0 {
+		return Entry{}, false
+	}
+	return entries[0], true
+}
+
+// Add stores an entry as the newest one. Giving the newest input again
+// replaces its entry instead of repeating it, and the oldest entries are
+// dropped beyond the configured maximum.
+func (l *Log) Add(entry Entry) error {
+	if l == nil || strings.TrimSpace(entry.Input) == "" {
+		return nil
+	}
+	if len(entry.Input)+len(entry.Output) > MaxEntrySize {
+		entry.Output = ""
+	}
+	if len(entry.Input) > MaxEntrySize {
+		return nil
+	}
+
+	entries, err := l.Entries()
+	if err != nil {
+		// An unreadable log, for example after changing the key, is
+		// replaced rather than blocking new history.
+		entries = nil
+	}
+	if len(entries) > 0 && entries[0].Input == entry.Input {
+		entries = entries[1:]
+	}
+	entries = append([]Entry{entry}, entries...)
+	if len(entries) > l.maxEntries {
+		entries = entries[:l.maxEntries]
+	}
+	return l.write(entries)
+}
+
+// Remove deletes the entry at index i, counted from the newest.
+func (l *Log) Remove(i int) error {
+	entries, err := l.Entries()
+	if err != nil {
+		return err
+	}
+	if i < 0 || i >= len(entries) {
+		return nil
+	}
+	return l.write(slices.Delete(entries, i, i+1))
+}
+
+// Clear deletes the whole log.
+func (l *Log) Clear() error {
+	if l == nil {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Log) write(entries []Entry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if l.key != nil {
+		if data, err = encrypt(l.key, data); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, data, 0o600)
+}
+
+// loadKey derives the key from passphrase, or reads the key file, creating
+// it with a random key on first use.
+func loadKey(passphrase string) ([]byte, error) {
+	if passphrase != "" {
+		salt, err := readOrCreate(SaltPath(), 16)
+		if err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	}
+	return readOrCreate(KeyPath(), 32)
+}
+
+// readOrCreate reads the hex encoded bytes in path, creating the file with
+// size random bytes on first use.
+func readOrCreate(path string, size int) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return hex.DecodeString(strings.TrimSpace(string(data)))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(b)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("encrypted history is truncated")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("can't decrypt history, was the key changed?")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,215 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/skatkov/devtui/internal/config"
+)
+
+// useDirs points the XDG directories at a temporary directory.
+func useDirs(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func loadConfig(t *testing.T, environ ...string) *config.Config {
+	t.Helper()
+
+	cfg, err := config.LoadFiles(environ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestAddKeepsNewestFirstAndBounded(t *testing.T) {
+	useDirs(t)
+
+	log, err := ForTool(loadConfig(t, "DEVTUI_HISTORY_MAX_ENTRIES=3"), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		if err := log.Add(Entry{Time: time.Unix(int64(i), 0), Input: fmt.Sprintf(`{"n":%d}`, i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Giving the newest input again doesn't add a duplicate.
+	if err := log.Add(Entry{Input: `{"n":4}`, Output: "formatted"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inputs []string
+	for _, e := range entries {
+		inputs = append(inputs, e.Input)
+	}
+	if got, want := strings.Join(inputs, " "), `{"n":4} {"n":3} {"n":2}`; got != want {
+		t.Errorf("inputs = %s, want %s", got, want)
+	}
+	if last, ok := log.Last(); !ok || last.Output != "formatted" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+
+	if err := log.Remove(1); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := log.Entries(); len(entries) != 2 || entries[1].Input != `{"n":2}` {
+		t.Errorf("after Remove(1) entries = %+v", entries)
+	}
+	if err := log.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := log.Last(); ok {
+		t.Error("Clear() kept entries")
+	}
+}
+
+func TestEncryptedLog(t *testing.T) {
+	useDirs(t)
+
+	log, err := ForTool(loadConfig(t), "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !log.Encrypted() {
+		t.Fatal("jwt history should be encrypted by default")
+	}
+	if err := log.Add(Entry{Input: "secret-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(Dir(), "jwt.enc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret-token")) {
+		t.Error("encrypted history contains the input in plain text")
+	}
+	if last, ok := log.Last(); !ok || last.Input != "secret-token" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+
+	// A different passphrase can't read it.
+	t.Setenv(KeyEnv, "another")
+	other, err := ForTool(loadConfig(t), "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Entries(); err == nil {
+		t.Error("Entries() decrypted with the wrong key")
+	}
+}
+
+func TestPassphraseKey(t *testing.T) {
+	useDirs(t)
+	t.Setenv(KeyEnv, "correct horse")
+
+	log, err := ForTool(loadConfig(t), "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Add(Entry{Input: "secret-token"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(SaltPath()); err != nil {
+		t.Fatalf("salt wasn't stored: %v", err)
+	}
+	if _, err := os.Stat(KeyPath()); err == nil {
+		t.Error("a key file was created although a passphrase is set")
+	}
+
+	// The same passphrase reads the log again.
+	again, err := ForTool(loadConfig(t), "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last, ok := again.Last(); !ok || last.Input != "secret-token" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+
+	// With a new salt, the passphrase derives another key.
+	if err := os.Remove(SaltPath()); err != nil {
+		t.Fatal(err)
+	}
+	salted, err := ForTool(loadConfig(t), "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := salted.Entries(); err == nil {
+		t.Error("Entries() decrypted with a key derived from another salt")
+	}
+}
+
+func TestPassphraseIsNotReadFromConfig(t *testing.T) {
+	useDirs(t)
+
+	project := filepath.Join(t.TempDir(), ".devtui.toml")
+	if err := os.WriteFile(project, []byte("[history]\nkey = \"committed\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadFiles(nil, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ForTool(cfg, "jwt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(SaltPath()); err == nil {
+		t.Error("history.key in a config file was used as the passphrase")
+	}
+	if _, err := os.Stat(KeyPath()); err != nil {
+		t.Errorf("key file wasn't created: %v", err)
+	}
+}
+
+func TestDisabledHistory(t *testing.T) {
+	useDirs(t)
+
+	for _, environ := range [][]string{
+		{"DEVTUI_HISTORY_ENABLED=false"},
+		{"DEVTUI_HISTORY_DISABLE=hash,json"},
+	} {
+		log, err := ForTool(loadConfig(t, environ...), "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if log != nil {
+			t.Errorf("%v: history enabled", environ)
+		}
+		// A nil log is usable and keeps nothing.
+		if err := log.Add(Entry{Input: "x"}); err != nil {
+			t.Error(err)
+		}
+		if _, ok := log.Last(); ok {
+			t.Errorf("%v: nil log has entries", environ)
+		}
+	}
+}
+
+func TestToolNamesAreSafeFileNames(t *testing.T) {
+	useDirs(t)
+
+	log, err := ForTool(loadConfig(t), "recipe:../x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(log.path) != Dir() {
+		t.Errorf("log path %s escapes %s", log.path, Dir())
+	}
+}
@@ -0,0 +1,127 @@
+package input
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ExpandFiles resolves file patterns to a list of paths. Patterns are globs
+// as understood by filepath.Match, where a "**" path element also matches
+// any number of directories. Paths are returned in pattern order, sorted
+// within each pattern and without duplicates. A pattern that matches no
+// files is an error.
+func ExpandFiles(patterns []string) ([]string, error) {
+	var (
+		paths []string
+		seen  = map[string]bool{}
+	)
+	for _, pattern := range patterns {
+		matches, err := expand(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: no files match", pattern)
+		}
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+func expand(pattern string) ([]string, error) {
+	if !hasMeta(pattern) {
+		info, err := os.Stat(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("%s: is a directory", pattern)
+		}
+		return []string{pattern}, nil
+	}
+
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+		return slices.DeleteFunc(matches, isDir), nil
+	}
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	i := slices.IndexFunc(segments, hasMeta)
+	root := strings.Join(segments[:i], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		}
+	}
+	for _, segment := range segments[i:] {
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+	}
+
+	var matches []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(root), path)
+		if err != nil {
+			return err
+		}
+		if matchSegments(segments[i:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(matches)
+	return matches, nil
+}
+
+// matchSegments reports whether the path elements match the pattern
+// elements, letting "**" stand for zero or more elements.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, _ := filepath.Match(pattern[0], path[0])
+	return ok && matchSegments(pattern[1:], path[1:])
+}
+
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[`)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
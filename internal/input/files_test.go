@@ -0,0 +1,63 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExpandFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"a.yaml",
+		"b.json",
+		"k8s/deploy.yaml",
+		"k8s/base/service.yaml",
+		"k8s/base/notes.txt",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(names ...string) []string {
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(dir, filepath.FromSlash(name))
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "plain file", patterns: join("b.json"), want: join("b.json")},
+		{name: "glob", patterns: join("*.yaml"), want: join("a.yaml")},
+		{name: "recursive glob", patterns: join("**/*.yaml"), want: join("a.yaml", "k8s/base/service.yaml", "k8s/deploy.yaml")},
+		{name: "recursive glob below directory", patterns: join("k8s/**/*.yaml"), want: join("k8s/base/service.yaml", "k8s/deploy.yaml")},
+		{name: "duplicates removed", patterns: join("a.yaml", "*.yaml"), want: join("a.yaml")},
+		{name: "glob skips directories", patterns: join("*"), want: join("a.yaml", "b.json")},
+		{name: "no match", patterns: join("*.toml"), wantErr: true},
+		{name: "missing file", patterns: join("missing.json"), wantErr: true},
+		{name: "directory", patterns: join("k8s"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandFiles(tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ExpandFiles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package input
 import (
 	"io"
 	"os"
+	"strings"
 
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -29,11 +30,29 @@ func ReadBytesFromArgsOrStdin(cmd *cobra.Command, args []string) ([]byte, error)
 	return readBytes(cmd.InOrStdin())
 }
 
+// ReaderFromArgsOrStdin returns the first argument or stdin as a reader, for
+// commands that stream their input instead of reading it all at once. An
+// interactive terminal yields an empty reader.
+func ReaderFromArgsOrStdin(cmd *cobra.Command, args []string) io.Reader {
+	if len(args) > 0 {
+		return strings.NewReader(args[0])
+	}
+
+	r := cmd.InOrStdin()
+	if isTerminal(r) {
+		return strings.NewReader("")
+	}
+	return r
+}
+
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
 func readBytes(r io.Reader) ([]byte, error) {
-	if f, ok := r.(*os.File); ok {
-		if isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()) {
-			return nil, nil
-		}
+	if isTerminal(r) {
+		return nil, nil
 	}
 
 	return io.ReadAll(r)
@@ -1,6 +1,7 @@
 package input
 
 import (
+	"io"
 	"strings"
 	"testing"
 
@@ -125,3 +126,24 @@ func TestReadBytesFromArgsOrStdin(t *testing.T) {
 		})
 	}
 }
+
+func TestReaderFromArgsOrStdin(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("from stdin"))
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: nil, want: "from stdin"},
+		{args: []string{"from args"}, want: "from args"},
+	} {
+		data, err := io.ReadAll(ReaderFromArgsOrStdin(cmd, tt.args))
+		if err != nil {
+			t.Fatalf("ReaderFromArgsOrStdin() error = %v", err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("ReaderFromArgsOrStdin(%q) = %q, want %q", tt.args, data, tt.want)
+		}
+	}
+}
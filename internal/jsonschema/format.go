@@ -0,0 +1,55 @@
+package jsonschema
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+)
+
+// formats holds the checks for the asserted "format" values.
+var formats = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		for _, layout := range []string{"15:04:05Z07:00", "15:04:05.999999999Z07:00"} {
+			if _, err := time.Parse(layout, s); err == nil {
+				return true
+			}
+		}
+		return false
+	},
+	"email": func(s string) bool {
+		address, err := mail.ParseAddress(s)
+		return err == nil && address.Address == s
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"uuid": uuidPattern.MatchString,
+}
@@ -0,0 +1,164 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// typeNames lists the JSON Schema types in the order they are written.
+var typeNames = []string{"null", "boolean", "integer", "number", "string", "array", "object"}
+
+// shape accumulates what the samples seen at one location have in common.
+type shape struct {
+	types map[string]bool
+
+	// Strings.
+	format  string
+	strings int
+
+	// Objects: properties in first-seen order and how many objects had them.
+	keys       []string
+	properties map[string]*shape
+	seen       map[string]int
+	objects    int
+
+	// Arrays.
+	items *shape
+}
+
+func newShape() *shape {
+	return &shape{types: map[string]bool{}}
+}
+
+// Infer derives a draft 2020-12 schema that every sample satisfies. Object
+// properties present in all samples are required, integers and floats at the
+// same location widen to "number", and string formats such as date-time are
+// kept when every sample agrees.
+func Infer(samples ...any) *converter.Object {
+	root := newShape()
+	for _, sample := range samples {
+		root.add(sample)
+	}
+
+	schema := converter.NewObject()
+	schema.Set("$schema", Draft)
+	root.write(schema)
+	return schema
+}
+
+func (s *shape) add(value any) {
+	switch v := value.(type) {
+	case nil:
+		s.types["null"] = true
+	case bool:
+		s.types["boolean"] = true
+	case int64, json.Number:
+		s.types["integer"] = true
+	case float64:
+		if isIntegral(v) {
+			s.types["integer"] = true
+		} else {
+			s.types["number"] = true
+		}
+	case time.Time:
+		s.addString("date-time")
+	case string:
+		s.addString(stringFormat(v))
+	case []any:
+		s.types["array"] = true
+		for _, item := range v {
+			if s.items == nil {
+				s.items = newShape()
+			}
+			s.items.add(item)
+		}
+	case *converter.Object:
+		s.types["object"] = true
+		if s.properties == nil {
+			s.properties = map[string]*shape{}
+			s.seen = map[string]int{}
+		}
+		s.objects++
+		for _, member := range v.Members {
+			property, ok := s.properties[member.Key]
+			if !ok {
+				property = newShape()
+				s.properties[member.Key] = property
+				s.keys = append(s.keys, member.Key)
+			}
+			s.seen[member.Key]++
+			property.add(member.Value)
+		}
+	}
+}
+
+func (s *shape) addString(format string) {
+	s.types["string"] = true
+	if s.strings == 0 {
+		s.format = format
+	} else if s.format != format {
+		s.format = ""
+	}
+	s.strings++
+}
+
+// write adds the keywords describing the shape to schema.
+func (s *shape) write(schema *converter.Object) {
+	if s.types["number"] {
+		delete(s.types, "integer")
+	}
+	var types []any
+	for _, name := range typeNames {
+		if s.types[name] {
+			types = append(types, name)
+		}
+	}
+	switch len(types) {
+	case 0:
+		// No samples: anything goes.
+		return
+	case 1:
+		schema.Set("type", types[0])
+	default:
+		schema.Set("type", types)
+	}
+
+	if s.types["string"] && s.format != "" {
+		schema.Set("format", s.format)
+	}
+
+	if s.types["object"] {
+		properties := converter.NewObject()
+		var required []any
+		for _, key := range s.keys {
+			property := converter.NewObject()
+			s.properties[key].write(property)
+			properties.Set(key, property)
+			if s.seen[key] == s.objects {
+				required = append(required, key)
+			}
+		}
+		schema.Set("properties", properties)
+		if len(required) > 0 {
+			schema.Set("required", required)
+		}
+	}
+
+	if s.types["array"] && s.items != nil {
+		items := converter.NewObject()
+		s.items.write(items)
+		schema.Set("items", items)
+	}
+}
+
+// stringFormat guesses the format of a string sample.
+func stringFormat(s string) string {
+	for _, name := range []string{"date-time", "date", "uuid", "email"} {
+		if formats[name](s) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,15 @@
+// Package jsonschema infers JSON Schemas from sample documents and validates
+// documents against them.
+//
+// Schemas and documents are converter trees, so both can come from any
+// format the converter reads. The validator implements the draft 2020-12
+// assertion keywords: type, enum, const, the numeric, string, array and
+// object constraints, allOf, anyOf, oneOf, not, if/then/else,
+// dependentRequired, dependentSchemas and $ref to locations within the same
+// schema ("#/$defs/name", "#anchor"). The formats date-time, date, time,
+// email, hostname, ipv4, ipv6, uri and uuid are asserted; other formats and
+// annotation keywords are ignored.
+package jsonschema
+
+// Draft is the dialect URI written by Infer.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
@@ -0,0 +1,231 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+func decode(t *testing.T, content string, format converter.Format) any {
+	t.Helper()
+	value, err := converter.Decode(content, format)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return value
+}
+
+func TestInfer(t *testing.T) {
+	samples := []any{
+		decode(t, `{"id":1,"name":"a","score":1.5,"tags":["x"],"created":"2024-01-02T03:04:05Z"}`, converter.FormatJSON),
+		decode(t, "id: 2\nscore: 2\ntags: []\ncreated: 2024-02-03T00:00:00Z\nextra: null\n", converter.FormatYAML),
+	}
+
+	out, err := converter.Encode(Infer(samples...), converter.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer"
+    },
+    "name": {
+      "type": "string"
+    },
+    "score": {
+      "type": "number"
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      }
+    },
+    "created": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "extra": {
+      "type": "null"
+    }
+  },
+  "required": [
+    "id",
+    "score",
+    "tags",
+    "created"
+  ]
+}`
+	if strings.TrimSpace(out) != want {
+		t.Fatalf("Infer() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestInferredSchemaAcceptsSamples(t *testing.T) {
+	samples := []any{
+		decode(t, `{"a":[1,"two",{"b":null}],"c":true}`, converter.FormatJSON),
+		decode(t, `{"a":[],"c":false,"d":1.25}`, converter.FormatJSON),
+	}
+	schema, err := Compile(Infer(samples...))
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	for i, sample := range samples {
+		if violations := schema.Validate(sample); len(violations) != 0 {
+			t.Errorf("sample %d: unexpected violations %v", i, violations)
+		}
+	}
+}
+
+const testSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name", "port"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "minLength": 2, "pattern": "^[a-z]+$"},
+    "port": {"type": "integer", "minimum": 1, "maximum": 65535},
+    "mode": {"enum": ["dev", "prod"]},
+    "admin": {"type": "string", "format": "email"},
+    "servers": {"type": "array", "items": {"$ref": "#/$defs/server"}, "uniqueItems": true},
+    "tls": {"oneOf": [{"type": "boolean"}, {"$ref": "#cert"}]}
+  },
+  "$defs": {
+    "server": {"type": "object", "required": ["host"], "properties": {"host": {"type": "string"}}},
+    "cert": {"$anchor": "cert", "type": "object", "required": ["file"]}
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile(decode(t, testSchema, converter.FormatJSON))
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{
+			name:     "valid",
+			document: `{"name":"api","port":8080,"mode":"dev","servers":[{"host":"a"}],"tls":{"file":"x"}}`,
+		},
+		{
+			name:     "every violation",
+			document: `{"name":"A","port":70000,"mode":"test","admin":"nope","servers":[{"host":1},{}],"tls":"yes","extra":1}`,
+			want: []string{
+				`/name: must be at least 2 characters long, got 1 (minLength)`,
+				`/name: must match pattern "^[a-z]+$" (pattern)`,
+				`/port: must be <= 65535 (maximum)`,
+				`/mode: must be one of "dev", "prod" (enum)`,
+				`/admin: must be a valid email (format)`,
+				`/servers/0/host: must be string, got integer (type)`,
+				`/servers/1: missing required property "host" (required)`,
+				`/tls: must match exactly one of 2 schemas, matched 0 (oneOf)`,
+				`/extra: property "extra" is not allowed (additionalProperties)`,
+			},
+		},
+		{
+			name:     "missing required and wrong root type",
+			document: `[]`,
+			want:     []string{`/: must be object, got array (type)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := schema.Validate(decode(t, tt.document, converter.FormatJSON))
+			got := make([]string, len(violations))
+			for i, violation := range violations {
+				got[i] = violation.String()
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("Validate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{`{"type":"text"}`, "unknown type"},
+		{`{"pattern":"("}`, "invalid pattern"},
+		{`{"$ref":"#/$defs/missing"}`, "unresolved $ref"},
+		{`{"$ref":"other.json"}`, "unsupported $ref"},
+		{`[1]`, "must be an object or a boolean"},
+	}
+	for _, tt := range tests {
+		_, err := Compile(decode(t, tt.schema, converter.FormatJSON))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%s) error = %v, want %q", tt.schema, err, tt.want)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  converter.Format
+		pointer string
+		want    Position
+	}{
+		{
+			name:    "json",
+			content: "{\n  \"a\": {\n    \"b\": [1, 2]\n  }\n}",
+			format:  converter.FormatJSON,
+			pointer: "/a/b/1",
+			want:    Position{Line: 3, Column: 14},
+		},
+		{
+			name:    "json lines",
+			content: "{\"a\":1}\n\n{\"a\":\"x\"}\n",
+			format:  converter.FormatJSONL,
+			pointer: "/1/a",
+			want:    Position{Line: 3, Column: 6},
+		},
+		{
+			name:    "yaml",
+			content: "server:\n  ports:\n    - 80\n    - 443\n",
+			format:  converter.FormatYAML,
+			pointer: "/server/ports/1",
+			want:    Position{Line: 4, Column: 7},
+		},
+		{
+			name:    "toml array of tables",
+			content: "[[servers]]\nhost = \"a\"\n\n[[servers]]\nhost = \"b\"\nports = [80, 443]\n",
+			format:  converter.FormatTOML,
+			pointer: "/servers/1/ports/1",
+			want:    Position{Line: 6, Column: 14},
+		},
+		{
+			name:    "falls back to the parent",
+			content: "a:\n  b: 1\n",
+			format:  converter.FormatYAML,
+			pointer: "/a/missing",
+			want:    Position{Line: 2, Column: 3},
+		},
+		{
+			name:    "unsupported format",
+			content: "a,b\n1,2\n",
+			format:  converter.FormatCSV,
+			pointer: "/0/a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Locate(tt.content, tt.format).Find(tt.pointer); got != tt.want {
+				t.Fatalf("Find(%q) = %+v, want %+v", tt.pointer, got, tt.want)
+			}
+		})
+	}
+}
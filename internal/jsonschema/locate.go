@@ -0,0 +1,248 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/skatkov/devtui/internal/converter"
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a 1-based line and column in a source document. The zero
+// value means the position is unknown.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return ""
+	}
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// Locations maps the JSON Pointers of a document to where their values
+// start in the source.
+type Locations map[string]Position
+
+// Locate records the source positions of the values in content. JSON, JSON
+// Lines, YAML and TOML are supported; for JSON Lines the pointers start with
+// the record index. Other formats, and documents that fail to parse, yield
+// no locations.
+func Locate(content string, format converter.Format) Locations {
+	locations := Locations{}
+	offsets := map[string]int{}
+	data := []byte(content)
+	switch format {
+	case converter.FormatJSON:
+		locateJSON(data, 0, "", offsets)
+	case converter.FormatJSONL:
+		record, offset := 0, 0
+		for _, line := range strings.SplitAfter(content, "\n") {
+			if strings.TrimSpace(line) != "" {
+				locateJSON(data[:offset+len(line)], offset, "/"+strconv.Itoa(record), offsets)
+				record++
+			}
+			offset += len(line)
+		}
+	case converter.FormatYAML:
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err == nil && len(node.Content) > 0 {
+			locateYAML(node.Content[0], "", locations)
+		}
+	case converter.FormatTOML:
+		locateTOML(data, offsets)
+	}
+
+	for pointer, offset := range offsets {
+		locations[pointer] = offsetPosition(data, offset)
+	}
+	return locations
+}
+
+// Find returns the position of pointer, falling back to the closest
+// ancestor that has one.
+func (l Locations) Find(pointer string) Position {
+	for {
+		if position, ok := l[pointer]; ok {
+			return position
+		}
+		if pointer == "" {
+			return Position{}
+		}
+		pointer = pointer[:strings.LastIndex(pointer, "/")]
+	}
+}
+
+// offsetPosition converts a byte offset into a line and column.
+func offsetPosition(data []byte, offset int) Position {
+	offset = min(offset, len(data))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	start := bytes.LastIndexByte(before, '\n') + 1
+	return Position{Line: line, Column: utf8.RuneCount(before[start:]) + 1}
+}
+
+func locateJSON(data []byte, start int, prefix string, offsets map[string]int) {
+	decoder := json.NewDecoder(bytes.NewReader(data[start:]))
+	_ = locateJSONValue(decoder, data[start:], start, prefix, offsets)
+}
+
+func locateJSONValue(decoder *json.Decoder, data []byte, base int, pointer string, offsets map[string]int) error {
+	offset := skipJSONSeparators(data, int(decoder.InputOffset()))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	offsets[pointer] = base + offset
+
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			if err := locateJSONValue(decoder, data, base, pointer+"/"+escape(name), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := locateJSONValue(decoder, data, base, pointer+"/"+strconv.Itoa(i), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+	}
+	return err
+}
+
+// skipJSONSeparators moves past the whitespace, commas and colons the
+// decoder has not consumed yet.
+func skipJSONSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// locateYAML records the positions of a node and its children. Aliases are
+// not followed; their targets are located where they are defined.
+func locateYAML(node *yaml.Node, pointer string, locations Locations) {
+	locations[pointer] = Position{Line: node.Line, Column: node.Column}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				continue
+			}
+			locateYAML(value, pointer+"/"+escape(key.Value), locations)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			locateYAML(item, pointer+"/"+strconv.Itoa(i), locations)
+		}
+	}
+}
+
+func locateTOML(data []byte, offsets map[string]int) {
+	offsets[""] = 0
+	table := ""
+	arrayTables := map[string]int{}
+
+	parser := unstable.Parser{}
+	parser.Reset(data)
+	for parser.NextExpression() {
+		expr := parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			keys, offset := tomlKeys(expr)
+			if len(keys) == 0 {
+				continue
+			}
+			if expr.Kind == unstable.Table {
+				table = tomlPointer(keys, arrayTables)
+			} else {
+				table = tomlPointer(keys[:len(keys)-1], arrayTables) + "/" + escape(keys[len(keys)-1])
+				index := arrayTables[table]
+				arrayTables[table] = index + 1
+				table += "/" + strconv.Itoa(index)
+			}
+			offsets[table] = offset
+		case unstable.KeyValue:
+			keys, offset := tomlKeys(expr)
+			pointer := table + tomlPointer(keys, nil)
+			offsets[pointer] = offset
+			locateTOMLValue(expr.Value(), pointer, offsets)
+		}
+	}
+}
+
+// locateTOMLValue records the elements of arrays and inline tables, which
+// carry their own offsets.
+func locateTOMLValue(node *unstable.Node, pointer string, offsets map[string]int) {
+	switch node.Kind {
+	case unstable.Array:
+		it := node.Children()
+		for i := 0; it.Next(); i++ {
+			child := it.Node()
+			at := pointer + "/" + strconv.Itoa(i)
+			if child.Raw.Length > 0 {
+				offsets[at] = int(child.Raw.Offset)
+			}
+			locateTOMLValue(child, at, offsets)
+		}
+	case unstable.InlineTable:
+		it := node.Children()
+		for it.Next() {
+			child := it.Node()
+			keys, offset := tomlKeys(child)
+			at := pointer + tomlPointer(keys, nil)
+			offsets[at] = offset
+			locateTOMLValue(child.Value(), at, offsets)
+		}
+	}
+}
+
+// tomlKeys returns the dotted key of an expression and the offset of its
+// first part.
+func tomlKeys(expr *unstable.Node) ([]string, int) {
+	var keys []string
+	offset := -1
+	it := expr.Key()
+	for it.Next() {
+		node := it.Node()
+		if offset < 0 {
+			offset = int(node.Raw.Offset)
+		}
+		keys = append(keys, string(node.Data))
+	}
+	return keys, max(offset, 0)
+}
+
+// tomlPointer builds a pointer from keys, descending into the latest
+// element of any array of tables on the way.
+func tomlPointer(keys []string, arrayTables map[string]int) string {
+	pointer := ""
+	for _, key := range keys {
+		pointer += "/" + escape(key)
+		if n, ok := arrayTables[pointer]; ok {
+			pointer += "/" + strconv.Itoa(n-1)
+		}
+	}
+	return pointer
+}
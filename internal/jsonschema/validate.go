@@ -0,0 +1,616 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/diff"
+)
+
+// maxDepth bounds $ref expansion, which may recurse through the instance.
+const maxDepth = 256
+
+// Violation is a single failed assertion.
+type Violation struct {
+	// Pointer is the JSON Pointer of the offending value in the document.
+	Pointer string
+	// Keyword is the schema keyword that failed.
+	Keyword string
+	Message string
+}
+
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return pointer + ": " + v.Message + " (" + v.Keyword + ")"
+}
+
+// Schema is a compiled schema.
+type Schema struct {
+	root     any
+	anchors  map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile checks a decoded schema and prepares it for validation. It fails
+// on malformed keywords, invalid patterns and references that cannot be
+// resolved within the schema.
+func Compile(root any) (*Schema, error) {
+	switch root.(type) {
+	case bool, *converter.Object:
+	default:
+		return nil, errors.New("schema must be an object or a boolean")
+	}
+
+	s := &Schema{
+		root:     root,
+		anchors:  map[string]any{},
+		patterns: map[string]*regexp.Regexp{},
+	}
+	var refs []string
+	if err := s.scan(root, "", &refs); err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if _, err := s.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// scan walks every subschema, compiling patterns and collecting anchors and
+// references.
+func (s *Schema) scan(schema any, location string, refs *[]string) error {
+	object, ok := schema.(*converter.Object)
+	if !ok {
+		return nil
+	}
+	for _, member := range object.Members {
+		at := location + "/" + escape(member.Key)
+		switch member.Key {
+		case "$ref":
+			ref, ok := member.Value.(string)
+			if !ok {
+				return fmt.Errorf("%s: $ref must be a string", at)
+			}
+			*refs = append(*refs, ref)
+		case "$anchor":
+			if anchor, ok := member.Value.(string); ok {
+				s.anchors[anchor] = object
+			}
+		case "type":
+			if err := checkType(member.Value); err != nil {
+				return fmt.Errorf("%s: %w", at, err)
+			}
+		case "pattern":
+			if pattern, ok := member.Value.(string); ok {
+				if err := s.compilePattern(pattern); err != nil {
+					return fmt.Errorf("%s: %w", at, err)
+				}
+			}
+		case "patternProperties":
+			if patterns, ok := member.Value.(*converter.Object); ok {
+				for _, key := range patterns.Keys() {
+					if err := s.compilePattern(key); err != nil {
+						return fmt.Errorf("%s: %w", at, err)
+					}
+				}
+			}
+		case "enum", "const", "examples", "default":
+			// Data, not subschemas.
+			continue
+		}
+
+		switch v := member.Value.(type) {
+		case *converter.Object:
+			if err := s.scan(v, at, refs); err != nil {
+				return err
+			}
+		case []any:
+			for i, item := range v {
+				if err := s.scan(item, at+"/"+strconv.Itoa(i), refs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkType(value any) error {
+	names := []any{value}
+	if list, ok := value.([]any); ok {
+		names = list
+	}
+	for _, name := range names {
+		if s, ok := name.(string); !ok || !slices.Contains(typeNames, s) {
+			return fmt.Errorf("unknown type %s", diff.FormatValue(name))
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compilePattern(pattern string) error {
+	if _, ok := s.patterns[pattern]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	s.patterns[pattern] = re
+	return nil
+}
+
+// resolve finds the subschema a $ref points to.
+func (s *Schema) resolve(ref string) (any, error) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q: only references within the schema are supported", ref)
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		if target, ok := s.anchors[fragment]; ok {
+			return target, nil
+		}
+		return nil, fmt.Errorf("unresolved $ref %q", ref)
+	}
+
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+	target := s.root
+	for _, token := range splitPointer(fragment) {
+		switch v := target.(type) {
+		case *converter.Object:
+			value, ok := v.Get(token)
+			if !ok {
+				return nil, fmt.Errorf("unresolved $ref %q", ref)
+			}
+			target = value
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("unresolved $ref %q", ref)
+			}
+			target = v[i]
+		default:
+			return nil, fmt.Errorf("unresolved $ref %q", ref)
+		}
+	}
+	return target, nil
+}
+
+// Validate returns every violation of the schema by value, in document
+// order of the schema keywords.
+func (s *Schema) Validate(value any) []Violation {
+	v := validator{schema: s}
+	v.validate(s.root, value, "", 0)
+	return v.violations
+}
+
+type validator struct {
+	schema     *Schema
+	violations []Violation
+}
+
+func (v *validator) report(pointer, keyword, format string, args ...any) {
+	v.violations = append(v.violations, Violation{
+		Pointer: pointer,
+		Keyword: keyword,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// valid checks value against schema without reporting violations.
+func (v *validator) valid(schema, value any, pointer string, depth int) bool {
+	sub := validator{schema: v.schema}
+	sub.validate(schema, value, pointer, depth)
+	return len(sub.violations) == 0
+}
+
+func (v *validator) validate(schema, value any, pointer string, depth int) {
+	if b, ok := schema.(bool); ok {
+		if !b {
+			v.report(pointer, "false", "no value is allowed here")
+		}
+		return
+	}
+	s, ok := schema.(*converter.Object)
+	if !ok {
+		return
+	}
+
+	if ref, ok := s.Get("$ref"); ok {
+		if depth >= maxDepth {
+			v.report(pointer, "$ref", "references are nested too deeply")
+			return
+		}
+		target, err := v.schema.resolve(ref.(string))
+		if err != nil {
+			v.report(pointer, "$ref", "%s", err)
+		} else {
+			v.validate(target, value, pointer, depth+1)
+		}
+	}
+
+	if types, ok := s.Get("type"); ok && !matchesType(types, value) {
+		v.report(pointer, "type", "must be %s, got %s", describeTypes(types), typeOf(value))
+		// The remaining keywords would only restate the type mismatch.
+		return
+	}
+	if enum, ok := s.Get("enum"); ok {
+		values, _ := enum.([]any)
+		if !slices.ContainsFunc(values, func(item any) bool { return diff.Equal(item, value) }) {
+			options := make([]string, len(values))
+			for i, item := range values {
+				options[i] = diff.FormatValue(item)
+			}
+			v.report(pointer, "enum", "must be one of %s", strings.Join(options, ", "))
+		}
+	}
+	if constant, ok := s.Get("const"); ok && !diff.Equal(constant, value) {
+		v.report(pointer, "const", "must be %s", diff.FormatValue(constant))
+	}
+
+	switch instance := value.(type) {
+	case int64, float64, json.Number:
+		v.validateNumber(s, toFloat(instance), pointer)
+	case string:
+		v.validateString(s, instance, pointer)
+	case time.Time:
+		v.validateString(s, instance.Format(time.RFC3339Nano), pointer)
+	case []any:
+		v.validateArray(s, instance, pointer, depth)
+	case *converter.Object:
+		v.validateObject(s, instance, pointer, depth)
+	}
+
+	v.validateCombinators(s, value, pointer, depth)
+}
+
+func (v *validator) validateNumber(s *converter.Object, n float64, pointer string) {
+	if limit, ok := number(s, "minimum"); ok && n < limit {
+		v.report(pointer, "minimum", "must be >= %s", formatNumber(limit))
+	}
+	if limit, ok := number(s, "maximum"); ok && n > limit {
+		v.report(pointer, "maximum", "must be <= %s", formatNumber(limit))
+	}
+	if limit, ok := number(s, "exclusiveMinimum"); ok && n <= limit {
+		v.report(pointer, "exclusiveMinimum", "must be > %s", formatNumber(limit))
+	}
+	if limit, ok := number(s, "exclusiveMaximum"); ok && n >= limit {
+		v.report(pointer, "exclusiveMaximum", "must be < %s", formatNumber(limit))
+	}
+	if divisor, ok := number(s, "multipleOf"); ok && divisor > 0 {
+		if q := n / divisor; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.report(pointer, "multipleOf", "must be a multiple of %s", formatNumber(divisor))
+		}
+	}
+}
+
+func (v *validator) validateString(s *converter.Object, str, pointer string) {
+	length := utf8.RuneCountInString(str)
+	if limit, ok := count(s, "minLength"); ok && length < limit {
+		v.report(pointer, "minLength", "must be at least %d characters long, got %d", limit, length)
+	}
+	if limit, ok := count(s, "maxLength"); ok && length > limit {
+		v.report(pointer, "maxLength", "must be at most %d characters long, got %d", limit, length)
+	}
+	if pattern, ok := s.Get("pattern"); ok {
+		if re := v.schema.patterns[fmt.Sprint(pattern)]; re != nil && !re.MatchString(str) {
+			v.report(pointer, "pattern", "must match pattern %q", pattern)
+		}
+	}
+	if format, ok := s.Get("format"); ok {
+		if check := formats[fmt.Sprint(format)]; check != nil && !check(str) {
+			v.report(pointer, "format", "must be a valid %s", format)
+		}
+	}
+}
+
+func (v *validator) validateArray(s *converter.Object, items []any, pointer string, depth int) {
+	prefix := 0
+	if schemas, ok := s.Get("prefixItems"); ok {
+		list, _ := schemas.([]any)
+		for i := 0; i < len(list) && i < len(items); i++ {
+			v.validate(list[i], items[i], pointer+"/"+strconv.Itoa(i), depth)
+		}
+		prefix = len(list)
+	}
+	if schema, ok := s.Get("items"); ok {
+		for i := prefix; i < len(items); i++ {
+			v.validate(schema, items[i], pointer+"/"+strconv.Itoa(i), depth)
+		}
+	}
+
+	if schema, ok := s.Get("contains"); ok {
+		matches := 0
+		for i, item := range items {
+			if v.valid(schema, item, pointer+"/"+strconv.Itoa(i), depth) {
+				matches++
+			}
+		}
+		minimum, ok := count(s, "minContains")
+		if !ok {
+			minimum = 1
+		}
+		if matches < minimum {
+			v.report(pointer, "contains", "must contain at least %d matching items, got %d", minimum, matches)
+		}
+		if maximum, ok := count(s, "maxContains"); ok && matches > maximum {
+			v.report(pointer, "maxContains", "must contain at most %d matching items, got %d", maximum, matches)
+		}
+	}
+
+	if limit, ok := count(s, "minItems"); ok && len(items) < limit {
+		v.report(pointer, "minItems", "must have at least %d items, got %d", limit, len(items))
+	}
+	if limit, ok := count(s, "maxItems"); ok && len(items) > limit {
+		v.report(pointer, "maxItems", "must have at most %d items, got %d", limit, len(items))
+	}
+	if unique, _ := s.Get("uniqueItems"); unique == true {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if diff.Equal(items[i], items[j]) {
+					v.report(pointer, "uniqueItems", "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) validateObject(s *converter.Object, object *converter.Object, pointer string, depth int) {
+	properties, _ := s.Get("properties")
+	declared, _ := properties.(*converter.Object)
+	patternProperties, _ := s.Get("patternProperties")
+	patterns, _ := patternProperties.(*converter.Object)
+	additional, hasAdditional := s.Get("additionalProperties")
+	names, hasNames := s.Get("propertyNames")
+
+	for _, member := range object.Members {
+		at := pointer + "/" + escape(member.Key)
+		matched := false
+		if declared != nil {
+			if schema, ok := declared.Get(member.Key); ok {
+				v.validate(schema, member.Value, at, depth)
+				matched = true
+			}
+		}
+		if patterns != nil {
+			for _, pattern := range patterns.Members {
+				if re := v.schema.patterns[pattern.Key]; re != nil && re.MatchString(member.Key) {
+					v.validate(pattern.Value, member.Value, at, depth)
+					matched = true
+				}
+			}
+		}
+		if !matched && hasAdditional {
+			if additional == false {
+				v.report(at, "additionalProperties", "property %q is not allowed", member.Key)
+			} else {
+				v.validate(additional, member.Value, at, depth)
+			}
+		}
+		if hasNames && !v.valid(names, member.Key, at, depth) {
+			v.report(at, "propertyNames", "property name %q is not allowed", member.Key)
+		}
+	}
+
+	if required, ok := s.Get("required"); ok {
+		list, _ := required.([]any)
+		for _, name := range list {
+			if key, ok := name.(string); ok {
+				if _, present := object.Get(key); !present {
+					v.report(pointer, "required", "missing required property %q", key)
+				}
+			}
+		}
+	}
+	if limit, ok := count(s, "minProperties"); ok && object.Len() < limit {
+		v.report(pointer, "minProperties", "must have at least %d properties, got %d", limit, object.Len())
+	}
+	if limit, ok := count(s, "maxProperties"); ok && object.Len() > limit {
+		v.report(pointer, "maxProperties", "must have at most %d properties, got %d", limit, object.Len())
+	}
+
+	if dependent, ok := s.Get("dependentRequired"); ok {
+		if rules, ok := dependent.(*converter.Object); ok {
+			for _, rule := range rules.Members {
+				if _, present := object.Get(rule.Key); !present {
+					continue
+				}
+				list, _ := rule.Value.([]any)
+				for _, name := range list {
+					if key, ok := name.(string); ok {
+						if _, present := object.Get(key); !present {
+							v.report(pointer, "dependentRequired", "property %q is required when %q is present", key, rule.Key)
+						}
+					}
+				}
+			}
+		}
+	}
+	if dependent, ok := s.Get("dependentSchemas"); ok {
+		if rules, ok := dependent.(*converter.Object); ok {
+			for _, rule := range rules.Members {
+				if _, present := object.Get(rule.Key); present {
+					v.validate(rule.Value, object, pointer, depth)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) validateCombinators(s *converter.Object, value any, pointer string, depth int) {
+	if schemas, ok := s.Get("allOf"); ok {
+		list, _ := schemas.([]any)
+		for _, schema := range list {
+			v.validate(schema, value, pointer, depth)
+		}
+	}
+	if schemas, ok := s.Get("anyOf"); ok {
+		list, _ := schemas.([]any)
+		if !slices.ContainsFunc(list, func(schema any) bool { return v.valid(schema, value, pointer, depth) }) {
+			v.report(pointer, "anyOf", "must match at least one of %d schemas", len(list))
+		}
+	}
+	if schemas, ok := s.Get("oneOf"); ok {
+		list, _ := schemas.([]any)
+		matches := 0
+		for _, schema := range list {
+			if v.valid(schema, value, pointer, depth) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			v.report(pointer, "oneOf", "must match exactly one of %d schemas, matched %d", len(list), matches)
+		}
+	}
+	if schema, ok := s.Get("not"); ok && v.valid(schema, value, pointer, depth) {
+		v.report(pointer, "not", "must not match the schema")
+	}
+	if condition, ok := s.Get("if"); ok {
+		branch := "else"
+		if v.valid(condition, value, pointer, depth) {
+			branch = "then"
+		}
+		if schema, ok := s.Get(branch); ok {
+			v.validate(schema, value, pointer, depth)
+		}
+	}
+}
+
+func matchesType(types, value any) bool {
+	names := []any{types}
+	if list, ok := types.([]any); ok {
+		names = list
+	}
+	for _, name := range names {
+		switch name {
+		case "integer":
+			if f, ok := value.(float64); ok && isIntegral(f) {
+				return true
+			}
+			if _, ok := value.(int64); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+			if _, ok := value.(int64); ok {
+				return true
+			}
+		default:
+			if typeOf(value) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func describeTypes(types any) string {
+	list, ok := types.([]any)
+	if !ok {
+		return fmt.Sprint(types)
+	}
+	names := make([]string, len(list))
+	for i, name := range list {
+		names[i] = fmt.Sprint(name)
+	}
+	return strings.Join(names, " or ")
+}
+
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int64, json.Number:
+		return "integer"
+	case float64:
+		if isIntegral(v) {
+			return "integer"
+		}
+		return "number"
+	case string, time.Time:
+		return "string"
+	case []any:
+		return "array"
+	case *converter.Object:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func isIntegral(f float64) bool {
+	return f == math.Trunc(f) && !math.IsInf(f, 0)
+}
+
+func toFloat(value any) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	}
+	return 0
+}
+
+func number(s *converter.Object, keyword string) (float64, bool) {
+	value, ok := s.Get(keyword)
+	if !ok {
+		return 0, false
+	}
+	switch value.(type) {
+	case int64, float64:
+		return toFloat(value), true
+	}
+	return 0, false
+}
+
+func count(s *converter.Object, keyword string) (int, bool) {
+	n, ok := number(s, keyword)
+	return int(n), ok
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// escape encodes a key as a JSON Pointer reference token.
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// splitPointer decodes the reference tokens of a JSON Pointer.
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
@@ -0,0 +1,219 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skatkov/devtui/internal/base64"
+)
+
+// Token is a decoded, not yet verified, JSON Web Token.
+type Token struct {
+	Raw          string
+	Header       map[string]any
+	Payload      map[string]any
+	HeaderJSON   string
+	PayloadJSON  string
+	Signature    []byte
+	SigningInput string
+}
+
+// Claim explains a registered claim found in the payload.
+type Claim struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Value       string `json:"value"`
+	Status      string `json:"status,omitempty"`
+}
+
+const (
+	StatusValid       = "valid"
+	StatusExpired     = "expired"
+	StatusNotYetValid = "not yet valid"
+)
+
+var registeredClaims = []struct {
+	name        string
+	description string
+}{
+	{"iss", "Issuer"},
+	{"sub", "Subject"},
+	{"aud", "Audience"},
+	{"exp", "Expiration Time"},
+	{"nbf", "Not Before"},
+	{"iat", "Issued At"},
+	{"jti", "JWT ID"},
+}
+
+// Decode splits a compact JWS token and decodes its header and payload.
+// A leading "Bearer " prefix is ignored.
+func Decode(raw string) (*Token, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimSpace(strings.TrimPrefix(raw, "Bearer "))
+	if raw == "" {
+		return nil, errors.New("empty token")
+	}
+
+	parts := strings.Split(raw, ".")
+	switch len(parts) {
+	case 3:
+	case 5:
+		return nil, errors.New("encrypted tokens (JWE) are not supported")
+	default:
+		return nil, fmt.Errorf("invalid token: expected 3 dot-separated segments, got %d", len(parts))
+	}
+
+	headerJSON, err := base64.DecodeURL(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	payloadJSON, err := base64.DecodeURL(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	signature, err := base64.DecodeURL(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	token := &Token{
+		Raw:          raw,
+		HeaderJSON:   string(headerJSON),
+		PayloadJSON:  string(payloadJSON),
+		Signature:    signature,
+		SigningInput: parts[0] + "." + parts[1],
+	}
+
+	if err := unmarshalObject(headerJSON, &token.Header); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	if err := unmarshalObject(payloadJSON, &token.Payload); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+
+	return token, nil
+}
+
+// Algorithm returns the "alg" header value.
+func (t *Token) Algorithm() string {
+	alg, _ := t.Header["alg"].(string)
+	return alg
+}
+
+// KeyID returns the "kid" header value.
+func (t *Token) KeyID() string {
+	kid, _ := t.Header["kid"].(string)
+	return kid
+}
+
+// Claims explains the registered claims present in the payload. Time based
+// claims are rendered as dates and carry a validity status relative to now.
+func (t *Token) Claims(now time.Time) []Claim {
+	claims := make([]Claim, 0, len(registeredClaims))
+	for _, registered := range registeredClaims {
+		value, ok := t.Payload[registered.name]
+		if !ok {
+			continue
+		}
+
+		claim := Claim{
+			Name:        registered.name,
+			Description: registered.description,
+			Value:       formatClaimValue(value),
+		}
+
+		if ts, ok := numericDate(value); ok {
+			claim.Value = fmt.Sprintf("%s (%d)", ts.UTC().Format(time.RFC3339), ts.Unix())
+			switch registered.name {
+			case "exp":
+				claim.Status = StatusValid
+				if !now.Before(ts) {
+					claim.Status = StatusExpired
+				}
+			case "nbf":
+				claim.Status = StatusValid
+				if now.Before(ts) {
+					claim.Status = StatusNotYetValid
+				}
+			case "iat":
+				if now.Before(ts) {
+					claim.Status = "issued in the future"
+				}
+			}
+		}
+
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// Status summarizes the time validity of the token: expired, not yet valid or
+// valid. Tokens without exp/nbf claims are valid.
+func (t *Token) Status(now time.Time) string {
+	for _, claim := range t.Claims(now) {
+		if claim.Status == StatusExpired || claim.Status == StatusNotYetValid {
+			return claim.Status
+		}
+	}
+	return StatusValid
+}
+
+func numericDate(value any) (time.Time, bool) {
+	var seconds float64
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		seconds = f
+	case float64:
+		seconds = v
+	default:
+		return time.Time{}, false
+	}
+
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))), true
+}
+
+func formatClaimValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, formatClaimValue(item))
+		}
+		return strings.Join(parts, ", ")
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(bytes)
+	}
+}
+
+func unmarshalObject(data []byte, target *map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(target); err != nil {
+		return err
+	}
+	if *target == nil {
+		return errors.New("expected a JSON object")
+	}
+	return nil
+}
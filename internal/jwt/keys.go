@@ -0,0 +1,216 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/skatkov/devtui/internal/base64"
+)
+
+// JWK is a single JSON Web Key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	K   string `json:"k,omitempty"`
+}
+
+type jwks struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ParseKey parses verification key material for the token: a PEM encoded
+// public key, certificate or private key, a single JWK, or a JWKS document.
+// For JWKS documents the key is selected by the token's "kid" header, falling
+// back to the first key compatible with its algorithm.
+func ParseKey(data []byte, t *Token) (any, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseJWKData(trimmed, t)
+	}
+	return parsePEM(trimmed)
+}
+
+func parseJWKData(data []byte, t *Token) (any, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	if len(set.Keys) == 0 {
+		var single JWK
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("invalid JWK: %w", err)
+		}
+		if single.Kty == "" {
+			return nil, errors.New("invalid JWK: missing \"kty\"")
+		}
+		return single.PublicKey()
+	}
+
+	kid := t.KeyID()
+	if kid != "" {
+		for _, key := range set.Keys {
+			if key.Kid == kid {
+				return key.PublicKey()
+			}
+		}
+		return nil, fmt.Errorf("no key with kid %q in JWKS", kid)
+	}
+
+	wantKty := keyTypeFor(t.Algorithm())
+	for _, key := range set.Keys {
+		if key.Kty == wantKty && (key.Alg == "" || key.Alg == t.Algorithm()) {
+			return key.PublicKey()
+		}
+	}
+	return nil, fmt.Errorf("no %s key for %s in JWKS", wantKty, t.Algorithm())
+}
+
+// PublicKey converts the JWK into a key usable by Verify.
+func (k JWK) PublicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("invalid RSA exponent: too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve: %s", k.Crv)
+		}
+		x, err := base64.DecodeURL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key length")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		return base64.DecodeURL(k.K)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+func parsePEM(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block or JWKS found in key file")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return publicFromPrivate(key)
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return &key.PublicKey, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return &key.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+}
+
+func publicFromPrivate(key any) (any, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey, nil
+	case ed25519.PrivateKey:
+		return k.Public(), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+func keyTypeFor(alg string) string {
+	switch {
+	case alg == "EdDSA" || alg == "Ed25519":
+		return "OKP"
+	case len(alg) > 2 && alg[:2] == "HS":
+		return "oct"
+	case len(alg) > 2 && alg[:2] == "ES":
+		return "EC"
+	default:
+		return "RSA"
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	bytes, err := base64.DecodeURL(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
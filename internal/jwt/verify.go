@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // register SHA-256 for crypto.Hash
+	_ "crypto/sha512" // register SHA-384/512 for crypto.Hash
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidSignature is returned when a signature does not match.
+var ErrInvalidSignature = errors.New("signature verification failed")
+
+// SupportedAlgorithms lists the signature algorithms Verify understands.
+var SupportedAlgorithms = []string{
+	"HS256", "HS384", "HS512",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// Verify checks the token signature against key, which is either a []byte
+// HMAC secret or a public key (*rsa.PublicKey, *ecdsa.PublicKey,
+// ed25519.PublicKey).
+func Verify(t *Token, key any) error {
+	alg := t.Algorithm()
+	input := []byte(t.SigningInput)
+
+	switch alg {
+	case "none", "":
+		return errors.New("token is unsigned (alg \"none\")")
+	case "HS256", "HS384", "HS512":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%s requires a shared secret", alg)
+		}
+		mac := hmac.New(hashFor(alg).New, secret)
+		mac.Write(input)
+		if !hmac.Equal(mac.Sum(nil), t.Signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		public, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an RSA public key", alg)
+		}
+		h := hashFor(alg)
+		digest := sum(h, input)
+		var err error
+		if alg[0] == 'P' {
+			err = rsa.VerifyPSS(public, h, digest, t.Signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(public, h, digest, t.Signature)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256", "ES384", "ES512":
+		public, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an ECDSA public key", alg)
+		}
+		// RFC 7518 §3.4 ties each algorithm to one curve.
+		if curve := curveFor(alg); public.Curve != curve {
+			return fmt.Errorf("%s requires a %s key, not %s", alg, curve.Params().Name, public.Curve.Params().Name)
+		}
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if len(t.Signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(t.Signature[:size])
+		s := new(big.Int).SetBytes(t.Signature[size:])
+		if !ecdsa.Verify(public, sum(hashFor(alg), input), r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "EdDSA", "Ed25519":
+		public, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an Ed25519 public key", alg)
+		}
+		if !ed25519.Verify(public, input, t.Signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm: %s", alg)
+	}
+}
+
+func hashFor(alg string) crypto.Hash {
+	switch alg[len(alg)-3:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+func curveFor(alg string) elliptic.Curve {
+	switch alg {
+	case "ES384":
+		return elliptic.P384()
+	case "ES512":
+		return elliptic.P521()
+	default:
+		return elliptic.P256()
+	}
+}
+
+func sum(h crypto.Hash, data []byte) []byte {
+	hasher := h.New()
+	hasher.Write(data)
+	return hasher.Sum(nil)
+}
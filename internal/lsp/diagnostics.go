@@ -0,0 +1,158 @@
+package lsp
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlLine finds the line number in yaml.v3 errors such as
+// "yaml: line 3: mapping values are not allowed in this context".
+var yamlLine = regexp.MustCompile(`line (\d+):`)
+
+// Diagnose parses text as the language and reports its parse error, if
+// any, at the position the parser gives. Languages without a parser are
+// never reported.
+func Diagnose(languageID, text string) []Diagnostic {
+	var (
+		rng Range
+		err error
+	)
+	switch languageID {
+	case "json":
+		rng, err = diagnoseJSON(text)
+	case "yaml":
+		rng, err = diagnoseYAML(text)
+	case "toml":
+		rng, err = diagnoseTOML(text)
+	case "xml":
+		rng, err = diagnoseXML(text)
+	case "graphql":
+		rng, err = diagnoseGraphQL(text)
+	}
+	if err == nil {
+		return nil
+	}
+	return []Diagnostic{{
+		Range:    rng,
+		Severity: SeverityError,
+		Source:   "devtui",
+		Message:  err.Error(),
+	}}
+}
+
+func diagnoseJSON(text string) (Range, error) {
+	if strings.TrimSpace(text) == "" {
+		return Range{}, nil
+	}
+	var value any
+	err := json.Unmarshal([]byte(text), &value)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset counts the bytes read, including the offending one.
+		start := positionAt(text, int(syntaxErr.Offset)-1)
+		end := positionAt(text, int(syntaxErr.Offset))
+		return Range{Start: start, End: end}, err
+	}
+	return fullRange(text), err
+}
+
+func diagnoseYAML(text string) (Range, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(text))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return Range{}, nil
+		}
+		if err != nil {
+			if match := yamlLine.FindStringSubmatch(err.Error()); match != nil {
+				line, _ := strconv.Atoi(match[1])
+				return lineRange(text, line-1), err
+			}
+			return fullRange(text), err
+		}
+	}
+}
+
+func diagnoseTOML(text string) (Range, error) {
+	var value map[string]any
+	err := toml.Unmarshal([]byte(text), &value)
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		row, column := decodeErr.Position()
+		start := positionAt(text, offsetAt(text, Position{Line: row - 1})+column-1)
+		return Range{Start: start, End: lineRange(text, row-1).End}, err
+	}
+	return fullRange(text), err
+}
+
+func diagnoseXML(text string) (Range, error) {
+	if strings.TrimSpace(text) == "" {
+		return Range{}, nil
+	}
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return Range{}, nil
+		}
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return lineRange(text, syntaxErr.Line-1), err
+		}
+		if err != nil {
+			return fullRange(text), err
+		}
+	}
+}
+
+// diagnoseGraphQL accepts queries as well as schemas. When the text is
+// neither, the error of the parser that got further is the likelier one.
+func diagnoseGraphQL(text string) (Range, error) {
+	source := &ast.Source{Input: text}
+	_, queryErr := parser.ParseQuery(source)
+	if queryErr == nil {
+		return Range{}, nil
+	}
+	_, schemaErr := parser.ParseSchema(source)
+	if schemaErr == nil {
+		return Range{}, nil
+	}
+
+	queryPos, queryOK := graphQLPosition(queryErr)
+	schemaPos, schemaOK := graphQLPosition(schemaErr)
+	err, pos, ok := queryErr, queryPos, queryOK
+	if schemaOK && (!queryOK || offsetAt(text, schemaPos) > offsetAt(text, queryPos)) {
+		err, pos, ok = schemaErr, schemaPos, schemaOK
+	}
+	if !ok {
+		return fullRange(text), err
+	}
+
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		err = errors.New(gqlErr.Message)
+	}
+	end := positionAt(text, offsetAt(text, pos)+1)
+	return Range{Start: pos, End: end}, err
+}
+
+func graphQLPosition(err error) (Position, bool) {
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) || len(gqlErr.Locations) == 0 {
+		return Position{}, false
+	}
+	loc := gqlErr.Locations[0]
+	return Position{Line: loc.Line - 1, Character: loc.Column - 1}, true
+}
@@ -0,0 +1,62 @@
+package lsp
+
+import "testing"
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name       string
+		languageID string
+		text       string
+		wantLine   int // -1 for no diagnostic
+		wantChar   int
+	}{
+		{name: "valid json", languageID: "json", text: `{"a": [1, 2]}`, wantLine: -1},
+		{name: "json", languageID: "json", text: "{\n  \"a\": 1,\n}", wantLine: 2, wantChar: 0},
+		{name: "valid yaml", languageID: "yaml", text: "a: 1\nb: [1, 2]\n", wantLine: -1},
+		{name: "yaml", languageID: "yaml", text: "a: 1\nb: c: d\n", wantLine: 1, wantChar: 0},
+		{name: "valid toml", languageID: "toml", text: "a = 1\n[b]\nc = 'd'\n", wantLine: -1},
+		{name: "toml", languageID: "toml", text: "a = 1\nb = \n", wantLine: 1, wantChar: 4},
+		{name: "valid xml", languageID: "xml", text: "<a><b/></a>", wantLine: -1},
+		{name: "xml", languageID: "xml", text: "<a>\n<b>\n</a>", wantLine: 2, wantChar: 0},
+		{name: "graphql query", languageID: "graphql", text: "query { user { name } }", wantLine: -1},
+		{name: "graphql schema", languageID: "graphql", text: "type User { name: String }", wantLine: -1},
+		{name: "graphql", languageID: "graphql", text: "query {\n  user {\n}", wantLine: 2, wantChar: 0},
+		{name: "no parser", languageID: "css", text: "a { color: }", wantLine: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := Diagnose(tt.languageID, tt.text)
+			if tt.wantLine < 0 {
+				if len(diagnostics) != 0 {
+					t.Fatalf("expected no diagnostics, got %+v", diagnostics)
+				}
+				return
+			}
+			if len(diagnostics) != 1 {
+				t.Fatalf("expected one diagnostic, got %+v", diagnostics)
+			}
+			start := diagnostics[0].Range.Start
+			if start.Line != tt.wantLine || start.Character != tt.wantChar {
+				t.Errorf("diagnostic %q starts at %+v, want line %d character %d",
+					diagnostics[0].Message, start, tt.wantLine, tt.wantChar)
+			}
+		})
+	}
+}
+
+func TestPositionsCountUTF16(t *testing.T) {
+	text := "a😀b\nc"
+	if got := positionAt(text, len("a😀")); got != (Position{Line: 0, Character: 3}) {
+		t.Errorf("positionAt = %+v", got)
+	}
+	if got := offsetAt(text, Position{Line: 0, Character: 3}); got != len("a😀") {
+		t.Errorf("offsetAt = %d", got)
+	}
+	if got := offsetAt(text, Position{Line: 0, Character: 99}); got != len("a😀b") {
+		t.Errorf("offsetAt clamps to the line end, got %d", got)
+	}
+	if got := offsetAt(text, Position{Line: 5}); got != len(text) {
+		t.Errorf("offsetAt clamps to the text end, got %d", got)
+	}
+}
@@ -0,0 +1,288 @@
+// Package lsp implements a language server that formats documents, reports
+// their parse errors and offers code actions on selected text. The
+// formatters and actions are supplied by the caller, so that editors get the
+// same results as the command line.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// showCommand is the command of actions whose result is shown rather than
+// inserted; its only argument is the text to show.
+const showCommand = "devtui.show"
+
+// FormatFunc formats the whole text of a document.
+type FormatFunc func(text string) (string, error)
+
+// Action is a code action offered for selected text.
+type Action struct {
+	Title string
+	// Run returns what the selection becomes. An error means the action
+	// doesn't apply to the selection, which is then not offered.
+	Run func(selection string) (string, error)
+	// Show displays the result in a message instead of replacing the
+	// selection.
+	Show bool
+}
+
+// Server is a language server for one client.
+type Server struct {
+	// Formatters format documents by language ID, such as "json".
+	Formatters map[string]FormatFunc
+	Actions    []Action
+
+	docs     map[string]document
+	out      io.Writer
+	shutdown bool
+}
+
+type document struct {
+	languageID string
+	text       string
+}
+
+func NewServer(formatters map[string]FormatFunc, actions []Action) *Server {
+	return &Server{
+		Formatters: formatters,
+		Actions:    actions,
+		docs:       map[string]document{},
+	}
+}
+
+// Serve handles the messages read from r, writing responses and
+// notifications to w, until the client exits or closes r.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := bufio.NewReader(r)
+	for {
+		body, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.respond(json.RawMessage("null"), nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit before shutdown")
+			}
+			return nil
+		}
+
+		result, err := s.handle(msg.Method, msg.Params)
+		if msg.ID == nil {
+			// Notifications get no response, not even for errors.
+			continue
+		}
+		if err := s.respond(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized", "textDocument/didSave", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		s.docs[p.TextDocument.URI] = document{languageID: p.TextDocument.LanguageID, text: p.TextDocument.Text}
+		return nil, s.publishDiagnostics(p.TextDocument.URI)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		doc, ok := s.docs[p.TextDocument.URI]
+		if !ok || len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// The server asks for full document sync, so the last change holds
+		// the whole text.
+		doc.text = p.ContentChanges[len(p.ContentChanges)-1].Text
+		s.docs[p.TextDocument.URI] = doc
+		return nil, s.publishDiagnostics(p.TextDocument.URI)
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         p.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	case "textDocument/formatting":
+		var p formattingParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.format(p.TextDocument.URI)
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.codeActions(p.TextDocument.URI, p.Range), nil
+	case "workspace/executeCommand":
+		var p executeCommandParams
+		if err := unmarshalParams(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, s.executeCommand(p)
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + method}
+}
+
+func (s *Server) initialize() any {
+	return map[string]any{
+		"capabilities": map[string]any{
+			// Full document sync: every change sends the whole text.
+			"textDocumentSync":           1,
+			"documentFormattingProvider": true,
+			"codeActionProvider":         true,
+			"executeCommandProvider": map[string]any{
+				"commands": []string{showCommand},
+			},
+		},
+		"serverInfo": map[string]any{"name": "devtui"},
+	}
+}
+
+func (s *Server) publishDiagnostics(uri string) error {
+	doc := s.docs[uri]
+	diagnostics := Diagnose(doc.languageID, doc.text)
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// format returns the edits formatting the document, or none when it has no
+// formatter or is formatted already.
+func (s *Server) format(uri string) ([]TextEdit, error) {
+	doc, ok := s.docs[uri]
+	if !ok {
+		return nil, &responseError{Code: codeInvalidParams, Message: "unknown document " + uri}
+	}
+	format, ok := s.Formatters[doc.languageID]
+	if !ok {
+		return []TextEdit{}, nil
+	}
+
+	formatted, err := format(doc.text)
+	if err != nil {
+		return nil, &responseError{Code: codeRequestFailed, Message: err.Error()}
+	}
+	if formatted == doc.text {
+		return []TextEdit{}, nil
+	}
+	return []TextEdit{{Range: fullRange(doc.text), NewText: formatted}}, nil
+}
+
+// codeActions runs the actions on the selection and offers those that
+// apply to it.
+func (s *Server) codeActions(uri string, rng Range) []CodeAction {
+	doc, ok := s.docs[uri]
+	if !ok {
+		return []CodeAction{}
+	}
+	start, end := offsetAt(doc.text, rng.Start), offsetAt(doc.text, rng.End)
+	start, end = min(start, end), max(start, end)
+	selection := doc.text[start:end]
+	if strings.TrimSpace(selection) == "" {
+		return []CodeAction{}
+	}
+
+	actions := []CodeAction{}
+	for _, action := range s.Actions {
+		result, err := action.Run(selection)
+		if err != nil {
+			continue
+		}
+		if action.Show {
+			actions = append(actions, CodeAction{
+				Title:   action.Title,
+				Kind:    "refactor",
+				Command: &Command{Title: action.Title, Command: showCommand, Arguments: []any{result}},
+			})
+			continue
+		}
+		if result == selection {
+			continue
+		}
+		actions = append(actions, CodeAction{
+			Title: action.Title,
+			Kind:  "refactor.rewrite",
+			Edit: &WorkspaceEdit{Changes: map[string][]TextEdit{
+				uri: {{Range: Range{Start: positionAt(doc.text, start), End: positionAt(doc.text, end)}, NewText: result}},
+			}},
+		})
+	}
+	return actions
+}
+
+func (s *Server) executeCommand(p executeCommandParams) error {
+	if p.Command != showCommand || len(p.Arguments) != 1 {
+		return &responseError{Code: codeInvalidParams, Message: "unknown command " + p.Command}
+	}
+	var text string
+	if err := json.Unmarshal(p.Arguments[0], &text); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	const messageTypeInfo = 3
+	return s.notify("window/showMessage", showMessageParams{Type: messageTypeInfo, Message: text})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return writeMessage(s.out, map[string]any{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	})
+}
+
+func (s *Server) respond(id json.RawMessage, result any, err error) error {
+	response := map[string]any{"jsonrpc": "2.0", "id": id}
+	var rpcErr *responseError
+	switch {
+	case err == nil:
+		response["result"] = result
+	case errors.As(err, &rpcErr):
+		response["error"] = rpcErr
+	default:
+		response["error"] = &responseError{Code: codeRequestFailed, Message: err.Error()}
+	}
+	return writeMessage(s.out, response)
+}
+
+func unmarshalParams(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// session frames the given messages as a client would, serves them and
+// returns the decoded messages the server wrote.
+func session(t *testing.T, s *Server, messages ...map[string]any) []map[string]any {
+	t.Helper()
+
+	var in bytes.Buffer
+	for _, msg := range messages {
+		msg["jsonrpc"] = "2.0"
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out bytes.Buffer
+	if err := s.Serve(&in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	var written []map[string]any
+	reader := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		var msg map[string]any
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		written = append(written, msg)
+	}
+	return written
+}
+
+// response returns the response to the request with the given id.
+func response(t *testing.T, written []map[string]any, id float64) map[string]any {
+	t.Helper()
+
+	for _, msg := range written {
+		if msg["id"] == id {
+			return msg
+		}
+	}
+	t.Fatalf("no response to request %v in %v", id, written)
+	return nil
+}
+
+func open(uri, languageID, text string) map[string]any {
+	return map[string]any{
+		"method": "textDocument/didOpen",
+		"params": map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": languageID, "version": 1, "text": text}},
+	}
+}
+
+func newTestServer() *Server {
+	return NewServer(
+		map[string]FormatFunc{
+			"json": func(text string) (string, error) {
+				if !json.Valid([]byte(text)) {
+					return "", errors.New("invalid JSON")
+				}
+				return strings.ReplaceAll(text, " ", ""), nil
+			},
+		},
+		[]Action{
+			{Title: "Upper", Run: func(s string) (string, error) { return strings.ToUpper(s), nil }},
+			{Title: "Length", Show: true, Run: func(s string) (string, error) { return fmt.Sprint(len(s)), nil }},
+			{Title: "Never", Run: func(string) (string, error) { return "", errors.New("doesn't apply") }},
+		},
+	)
+}
+
+func TestServerFormatting(t *testing.T) {
+	written := session(t, newTestServer(),
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}},
+		open("file:///a.json", "json", `{"a": 1}`),
+		open("file:///b.json", "json", `{"a": }`),
+		open("file:///c.txt", "plaintext", "text"),
+		map[string]any{"id": 2, "method": "textDocument/formatting", "params": map[string]any{"textDocument": map[string]any{"uri": "file:///a.json"}}},
+		map[string]any{"id": 3, "method": "textDocument/formatting", "params": map[string]any{"textDocument": map[string]any{"uri": "file:///b.json"}}},
+		map[string]any{"id": 4, "method": "textDocument/formatting", "params": map[string]any{"textDocument": map[string]any{"uri": "file:///c.txt"}}},
+		map[string]any{"id": 5, "method": "textDocument/hover", "params": map[string]any{}},
+		map[string]any{"id": 6, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+	)
+
+	caps := response(t, written, 1)["result"].(map[string]any)["capabilities"].(map[string]any)
+	if caps["documentFormattingProvider"] != true {
+		t.Errorf("capabilities = %v", caps)
+	}
+
+	edits := response(t, written, 2)["result"].([]any)
+	if len(edits) != 1 || edits[0].(map[string]any)["newText"] != `{"a":1}` {
+		t.Errorf("formatting edits = %v", edits)
+	}
+	if err := response(t, written, 3)["error"]; err == nil {
+		t.Error("expected formatting invalid JSON to fail")
+	}
+	if edits := response(t, written, 4)["result"].([]any); len(edits) != 0 {
+		t.Errorf("expected no edits without a formatter, got %v", edits)
+	}
+	if code := response(t, written, 5)["error"].(map[string]any)["code"]; code != float64(codeMethodNotFound) {
+		t.Errorf("unsupported method error code = %v", code)
+	}
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	written := session(t, newTestServer(),
+		open("file:///a.json", "json", "{\n  \"a\": }"),
+		map[string]any{"method": "textDocument/didChange", "params": map[string]any{
+			"textDocument":   map[string]any{"uri": "file:///a.json", "version": 2},
+			"contentChanges": []any{map[string]any{"text": `{"a": 1}`}},
+		}},
+	)
+
+	var published [][]any
+	for _, msg := range written {
+		if msg["method"] == "textDocument/publishDiagnostics" {
+			published = append(published, msg["params"].(map[string]any)["diagnostics"].([]any))
+		}
+	}
+	if len(published) != 2 {
+		t.Fatalf("expected diagnostics on open and change, got %v", written)
+	}
+	if len(published[0]) != 1 {
+		t.Fatalf("expected a parse error, got %v", published[0])
+	}
+	start := published[0][0].(map[string]any)["range"].(map[string]any)["start"].(map[string]any)
+	if start["line"] != float64(1) || start["character"] != float64(7) {
+		t.Errorf("diagnostic starts at %v, want line 1 character 7", start)
+	}
+	if len(published[1]) != 0 {
+		t.Errorf("expected the fix to clear diagnostics, got %v", published[1])
+	}
+}
+
+func TestServerCodeActions(t *testing.T) {
+	written := session(t, newTestServer(),
+		open("file:///a.txt", "plaintext", "one\ntwo 😀 three"),
+		map[string]any{"id": 1, "method": "textDocument/codeAction", "params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///a.txt"},
+			// The emoji is two UTF-16 code units.
+			"range":   map[string]any{"start": map[string]any{"line": 1, "character": 7}, "end": map[string]any{"line": 1, "character": 12}},
+			"context": map[string]any{"diagnostics": []any{}},
+		}},
+		map[string]any{"id": 2, "method": "workspace/executeCommand", "params": map[string]any{
+			"command": showCommand, "arguments": []any{"shown"},
+		}},
+	)
+
+	actions := response(t, written, 1)["result"].([]any)
+	if len(actions) != 2 {
+		t.Fatalf("expected two applicable actions, got %v", actions)
+	}
+	upper := actions[0].(map[string]any)
+	edit := upper["edit"].(map[string]any)["changes"].(map[string]any)["file:///a.txt"].([]any)[0].(map[string]any)
+	if edit["newText"] != "THREE" {
+		t.Errorf("Upper edit = %v", edit)
+	}
+	length := actions[1].(map[string]any)["command"].(map[string]any)
+	if args := length["arguments"].([]any); len(args) != 1 || args[0] != "5" {
+		t.Errorf("Length command = %v", length)
+	}
+
+	var message string
+	for _, msg := range written {
+		if msg["method"] == "window/showMessage" {
+			message = msg["params"].(map[string]any)["message"].(string)
+		}
+	}
+	if message != "shown" {
+		t.Errorf("showMessage = %q", message)
+	}
+}
+
+func TestExitBeforeShutdown(t *testing.T) {
+	var in, out bytes.Buffer
+	if err := writeMessage(&in, map[string]any{"jsonrpc": "2.0", "method": "exit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTestServer().Serve(&in, &out); err == nil {
+		t.Error("expected an error for exit without shutdown")
+	}
+}
@@ -0,0 +1,67 @@
+package lsp
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// positionAt returns the position of the byte offset in text.
+func positionAt(text string, offset int) Position {
+	offset = max(0, min(offset, len(text)))
+	before := text[:offset]
+	line := strings.Count(before, "\n")
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	return Position{Line: line, Character: utf16Len(before[lineStart:])}
+}
+
+// offsetAt returns the byte offset of pos in text. Positions beyond the end
+// of a line or of the text are clamped to it.
+func offsetAt(text string, pos Position) int {
+	offset := 0
+	for range pos.Line {
+		next := strings.IndexByte(text[offset:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+
+	units := 0
+	for i, r := range text[offset:] {
+		if r == '\n' || units >= pos.Character {
+			return offset + i
+		}
+		units += utf16RuneLen(r)
+	}
+	return len(text)
+}
+
+// lineRange returns the range of the whole line, without its line break.
+func lineRange(text string, line int) Range {
+	start := offsetAt(text, Position{Line: line})
+	end := len(text)
+	if next := strings.IndexByte(text[start:], '\n'); next >= 0 {
+		end = start + next
+	}
+	return Range{Start: positionAt(text, start), End: positionAt(text, end)}
+}
+
+// fullRange returns the range covering all of text.
+func fullRange(text string) Range {
+	return Range{End: positionAt(text, len(text))}
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16RuneLen(r)
+	}
+	return n
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
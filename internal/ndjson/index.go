@@ -0,0 +1,108 @@
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+type span struct {
+	offset int64
+	length int
+}
+
+// Index records where each record of a newline delimited document starts, so
+// records can be read on demand without holding the document in memory.
+type Index struct {
+	source io.ReaderAt
+	closer io.Closer
+	spans  []span
+}
+
+// NewIndex scans size bytes of r and indexes its records.
+func NewIndex(r io.ReaderAt, size int64) (*Index, error) {
+	index := &Index{source: r}
+	reader := bufio.NewReaderSize(io.NewSectionReader(r, 0, size), 64*1024)
+
+	var offset int64
+	for {
+		data, err := reader.ReadSlice('\n')
+		lineLength := len(data)
+		if errors.Is(err, bufio.ErrBufferFull) {
+			// Long line: keep reading until its end to learn its length.
+			for errors.Is(err, bufio.ErrBufferFull) {
+				var more []byte
+				more, err = reader.ReadSlice('\n')
+				lineLength += len(more)
+			}
+			index.spans = append(index.spans, span{offset: offset, length: lineLength})
+		} else if len(bytes.TrimSpace(data)) > 0 {
+			index.spans = append(index.spans, span{offset: offset, length: lineLength})
+		}
+		offset += int64(lineLength)
+
+		if err == io.EOF {
+			return index, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// FromString indexes an in-memory document.
+func FromString(content string) *Index {
+	index, _ := NewIndex(strings.NewReader(content), int64(len(content)))
+	return index
+}
+
+// Open indexes the file at path. The index keeps the file open until Close
+// is called.
+func Open(path string) (*Index, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	index, err := NewIndex(file, info.Size())
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	index.closer = file
+	return index, nil
+}
+
+// Len returns the number of records.
+func (x *Index) Len() int {
+	return len(x.spans)
+}
+
+// Record returns the i-th record without surrounding whitespace.
+func (x *Index) Record(i int) (string, error) {
+	if i < 0 || i >= len(x.spans) {
+		return "", errors.New("record out of range")
+	}
+	s := x.spans[i]
+	buf := make([]byte, s.length)
+	n, err := x.source.ReadAt(buf, s.offset)
+	if err != nil && !(errors.Is(err, io.EOF) && n == s.length) {
+		return "", err
+	}
+	return string(bytes.TrimSpace(buf[:n])), nil
+}
+
+// Close releases the underlying file, if any.
+func (x *Index) Close() error {
+	if x.closer == nil {
+		return nil
+	}
+	return x.closer.Close()
+}
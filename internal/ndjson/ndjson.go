@@ -0,0 +1,116 @@
+// Package ndjson reads newline delimited JSON (JSON Lines) one record at a
+// time, so large logs can be processed in constant memory.
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Scanner reads records from newline delimited input. Blank lines are
+// skipped. Unlike bufio.Scanner it has no limit on the length of a line.
+type Scanner struct {
+	reader *bufio.Reader
+	record []byte
+	line   int
+	err    error
+}
+
+// NewScanner returns a Scanner reading from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{reader: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Scan advances to the next record and reports whether there is one.
+func (s *Scanner) Scan() bool {
+	for s.err == nil {
+		data, err := s.reader.ReadBytes('\n')
+		if len(data) > 0 {
+			s.line++
+		}
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+				return false
+			}
+			s.err = io.EOF
+		}
+
+		record := bytes.TrimSpace(data)
+		if len(record) > 0 {
+			s.record = record
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the current record. The slice is only valid until the next
+// call to Scan.
+func (s *Scanner) Bytes() []byte {
+	return s.record
+}
+
+// Text returns the current record as a string.
+func (s *Scanner) Text() string {
+	return string(s.record)
+}
+
+// Line returns the 1-based input line of the current record.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Err returns the first non-EOF error encountered while reading.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// Transform applies fn to every record of r and writes the results to w, one
+// after another with separator between them. Results are written as they are
+// produced, and flushed whenever the input has nothing more buffered, so a
+// live stream is passed on as it arrives. It returns the number of records
+// written. Errors returned by fn are annotated with the record and line
+// number, after the results before it have been written.
+func Transform(r io.Reader, w io.Writer, separator string, fn func(record string) (string, error)) (int, error) {
+	scanner := NewScanner(r)
+	out := bufio.NewWriter(w)
+	count := 0
+	for {
+		if scanner.reader.Buffered() == 0 {
+			if err := out.Flush(); err != nil {
+				return count, err
+			}
+		}
+		if !scanner.Scan() {
+			break
+		}
+		result, err := fn(scanner.Text())
+		if err != nil {
+			if flushErr := out.Flush(); flushErr != nil {
+				return count, flushErr
+			}
+			return count, fmt.Errorf("record %d, line %d: %w", count+1, scanner.Line(), err)
+		}
+		if count > 0 {
+			if _, err := out.WriteString(separator); err != nil {
+				return count, err
+			}
+		}
+		if _, err := out.WriteString(strings.TrimRight(result, "\n") + "\n"); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		_ = out.Flush()
+		return count, err
+	}
+	return count, out.Flush()
+}
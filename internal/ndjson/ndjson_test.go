@@ -0,0 +1,167 @@
+package ndjson
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	scanner := NewScanner(strings.NewReader("{\"a\":1}\n\n  {\"a\":2}  \r\n{\"a\":3}"))
+	var records []string
+	var lines []int
+	for scanner.Scan() {
+		records = append(records, scanner.Text())
+		lines = append(lines, scanner.Line())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if got := strings.Join(records, "|"); got != `{"a":1}|{"a":2}|{"a":3}` {
+		t.Fatalf("records = %q", got)
+	}
+	if want := []int{1, 3, 4}; !slices.Equal(lines, want) {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestScannerLongLine(t *testing.T) {
+	t.Parallel()
+
+	long := `{"data":"` + strings.Repeat("x", 200_000) + `"}`
+	scanner := NewScanner(strings.NewReader(long + "\n{}\n"))
+	if !scanner.Scan() || scanner.Text() != long {
+		t.Fatal("expected the long record first")
+	}
+	if !scanner.Scan() || scanner.Text() != "{}" {
+		t.Fatal("expected a second record")
+	}
+	if scanner.Scan() {
+		t.Fatal("expected no more records")
+	}
+}
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	count, err := Transform(strings.NewReader("a\nb\n\nc\n"), &out, "--\n", func(record string) (string, error) {
+		return strings.ToUpper(record) + "\n\n", nil
+	})
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("count = %d, want 3", count)
+	}
+	if got, want := out.String(), "A\n--\nB\n--\nC\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	_, err = Transform(strings.NewReader("ok\n\nbad\n"), &out, "", func(record string) (string, error) {
+		if record == "bad" {
+			return "", errors.New("boom")
+		}
+		return record, nil
+	})
+	if err == nil || err.Error() != "record 2, line 3: boom" {
+		t.Fatalf("Transform() error = %v, want record 2, line 3: boom", err)
+	}
+	if got := out.String(); got != "ok\n" {
+		t.Fatalf("output before the error = %q, want %q", got, "ok\n")
+	}
+}
+
+// chanWriter sends everything written to it on the channel.
+type chanWriter chan string
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+func TestTransformStreams(t *testing.T) {
+	t.Parallel()
+
+	r, w := io.Pipe()
+	written := make(chanWriter, 10)
+	done := make(chan error, 1)
+	go func() {
+		_, err := Transform(r, written, "", func(record string) (string, error) {
+			return record, nil
+		})
+		done <- err
+	}()
+
+	// The first record is written while the input is still open.
+	if _, err := io.WriteString(w, "a\n"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-written:
+		if got != "a\n" {
+			t.Fatalf("written = %q, want %q", got, "a\n")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first record was not written before the input ended")
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	long := `{"data":"` + strings.Repeat("y", 100_000) + `"}`
+	index := FromString("{\"id\":1}\n\n" + long + "\n   \n{\"id\":3}")
+	if index.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", index.Len())
+	}
+
+	for i, want := range []string{`{"id":1}`, long, `{"id":3}`} {
+		got, err := index.Record(i)
+		if err != nil {
+			t.Fatalf("Record(%d) error = %v", i, err)
+		}
+		if got != want {
+			t.Fatalf("Record(%d) = %.40q, want %.40q", i, got, want)
+		}
+	}
+	if _, err := index.Record(3); err == nil {
+		t.Fatal("expected an error for an out of range record")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	if err := os.WriteFile(path, []byte("{\"a\":1}\n{\"a\":2}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = index.Close() }()
+
+	record, err := index.Record(1)
+	if err != nil || record != `{"a":2}` {
+		t.Fatalf("Record(1) = %q, %v", record, err)
+	}
+}
@@ -0,0 +1,869 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// MaxCheckLength bounds the characters Check analyzes; the rest are
+// counted as random.
+const MaxCheckLength = 100
+
+// Guess counts below which a part of a password counts as trivial, as in
+// zxcvbn.
+const (
+	minGuessesSingleChar = 10
+	minGuessesMultiChar  = 50
+	// minGuessesBeforeGrowing is added for every pattern beyond the first,
+	// as attackers try few patterns before many.
+	minGuessesBeforeGrowing = 10000
+	bruteforceCardinality   = 10
+	// wordRank is the rank given to words of the EFF wordlist, which isn't
+	// ordered by frequency.
+	wordRank = 2000
+	// maxWordLength bounds the tokens looked up in the dictionary, which
+	// holds no longer words.
+	maxWordLength = 16
+)
+
+// Match is a part of a password that follows a guessable pattern.
+type Match struct {
+	Pattern string `json:"pattern"`
+	Token   string `json:"token"`
+	// Start and End are the rune offsets of the token, End exclusive.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Detail explains the match, such as the word behind a l33t token.
+	Detail string `json:"detail,omitempty"`
+	// Guesses is the log10 of the guesses needed to find the token.
+	Guesses float64 `json:"guesses_log10"`
+
+	// warning is shown when the match dominates the password.
+	warning string
+}
+
+// CrackTime is how long guessing a password takes in one attack scenario.
+type CrackTime struct {
+	Scenario string  `json:"scenario"`
+	Seconds  float64 `json:"seconds"`
+	Display  string  `json:"display"`
+}
+
+// Report is the strength analysis of a password.
+type Report struct {
+	Length int `json:"length"`
+	// CharacterSets are the IDs of the CharacterSets the password draws
+	// from; CharsetEntropy is the entropy if it were random among them.
+	CharacterSets  []string `json:"character_sets"`
+	CharsetEntropy float64  `json:"charset_entropy_bits"`
+	// Guesses is the log10 of the guesses needed to find the password by
+	// trying its patterns; Entropy is the same in bits.
+	Guesses    float64     `json:"guesses_log10"`
+	Entropy    float64     `json:"entropy_bits"`
+	Strength   Strength    `json:"strength"`
+	CrackTimes []CrackTime `json:"crack_times"`
+	Matches    []Match     `json:"matches"`
+	Feedback   []string    `json:"feedback"`
+}
+
+// scenarios are the guessing rates of zxcvbn's attack scenarios, per second.
+var scenarios = []struct {
+	name string
+	rate float64
+}{
+	{name: "Online, throttled (100/hour)", rate: 100.0 / 3600},
+	{name: "Online, unthrottled (10/s)", rate: 10},
+	{name: "Offline, slow hash (10k/s)", rate: 1e4},
+	{name: "Offline, fast hash (10B/s)", rate: 1e10},
+}
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// rankedWords maps common passwords and words of the EFF wordlist to their
+// frequency rank.
+var rankedWords = sync.OnceValue(func() map[string]int {
+	ranks := map[string]int{}
+	for _, word := range Wordlist() {
+		ranks[word] = wordRank
+	}
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordList))
+	for rank := 1; scanner.Scan(); rank++ {
+		ranks[scanner.Text()] = rank
+	}
+	return ranks
+})
+
+// isCommonRank reports whether rank is one of the common password list.
+func isCommonRank(rank int) bool {
+	return rank < wordRank
+}
+
+// Check estimates how hard the password is to guess, in the manner of
+// zxcvbn: the password is split into the sequence of dictionary words,
+// keyboard walks, repeats, sequences, dates and random characters that is
+// cheapest to guess.
+func Check(secret string) Report {
+	runes := []rune(secret)
+	analyzed := runes[:min(len(runes), MaxCheckLength)]
+
+	guesses, matches := mostGuessableSequence(analyzed)
+	// Characters past the analyzed ones count as random.
+	guesses += float64(len(runes)-len(analyzed)) * math.Log10(bruteforceCardinality)
+
+	report := Report{
+		Length:   len(runes),
+		Guesses:  guesses,
+		Entropy:  guesses / math.Log10(2),
+		Strength: strengthOfGuesses(guesses),
+		Matches:  matches,
+	}
+	report.CharacterSets, report.CharsetEntropy = charsetEntropy(runes)
+	for _, scenario := range scenarios {
+		seconds := math.Pow(10, guesses) / scenario.rate
+		report.CrackTimes = append(report.CrackTimes, CrackTime{
+			Scenario: scenario.name,
+			Seconds:  seconds,
+			Display:  displayTime(seconds),
+		})
+	}
+	report.Feedback = feedback(report)
+	return report
+}
+
+// charsetEntropy returns the character sets the password uses, with any
+// other characters counted as a set of 100, and the entropy of a random
+// password of its length drawn from them.
+func charsetEntropy(runes []rune) ([]string, float64) {
+	sets := []string{}
+	pool := 0
+	for _, set := range CharacterSets {
+		if slices.ContainsFunc(runes, func(r rune) bool { return strings.ContainsRune(set.Chars, r) }) {
+			sets = append(sets, set.ID)
+			pool += len(set.Chars)
+		}
+	}
+	other := slices.ContainsFunc(runes, func(r rune) bool {
+		return !slices.ContainsFunc(CharacterSets, func(set CharacterSet) bool { return strings.ContainsRune(set.Chars, r) })
+	})
+	if other {
+		sets = append(sets, "other")
+		pool += 100
+	}
+	if pool == 0 {
+		return sets, 0
+	}
+	return sets, float64(len(runes)) * math.Log2(float64(pool))
+}
+
+// mostGuessableSequence finds the sequence of matches and random runs that
+// covers the password with the fewest guesses, returning their log10.
+func mostGuessableSequence(runes []rune) (float64, []Match) {
+	n := len(runes)
+	if n == 0 {
+		return 0, []Match{}
+	}
+
+	byEnd := make([][]Match, n+1)
+	for _, m := range findMatches(runes) {
+		byEnd[m.End] = append(byEnd[m.End], m)
+	}
+
+	// best[k][l] is the cheapest cover of the first k runes with l parts,
+	// as the sum of the log10 guesses of the parts.
+	type state struct {
+		sum   float64
+		match Match
+		ok    bool
+	}
+	best := make([][]state, n+1)
+	for k := range best {
+		best[k] = make([]state, n+1)
+	}
+	best[0][0] = state{ok: true}
+
+	for k := 1; k <= n; k++ {
+		candidates := slices.Clone(byEnd[k])
+		for i := range k {
+			candidates = append(candidates, bruteforceMatch(runes, i, k))
+		}
+		for _, m := range candidates {
+			for l := 1; l <= k; l++ {
+				prev := best[m.Start][l-1]
+				if !prev.ok {
+					continue
+				}
+				sum := prev.sum + m.Guesses
+				if cur := best[k][l]; !cur.ok || sum < cur.sum {
+					best[k][l] = state{sum: sum, match: m, ok: true}
+				}
+			}
+		}
+	}
+
+	// A sequence of l parts can be ordered in l! ways, and attackers try
+	// single patterns first.
+	total, parts := math.Inf(1), 0
+	for l := 1; l <= n; l++ {
+		if !best[n][l].ok {
+			continue
+		}
+		factorial, _ := math.Lgamma(float64(l + 1))
+		guesses := logSum(factorial/math.Ln10+best[n][l].sum, float64(l-1)*math.Log10(minGuessesBeforeGrowing))
+		if guesses < total {
+			total, parts = guesses, l
+		}
+	}
+
+	matches := make([]Match, parts)
+	for k, l := n, parts; l > 0; l-- {
+		m := best[k][l].match
+		matches[l-1] = m
+		k = m.Start
+	}
+	return total, matches
+}
+
+// logSum returns log10(10^a + 10^b).
+func logSum(a, b float64) float64 {
+	hi, lo := max(a, b), min(a, b)
+	return hi + math.Log10(1+math.Pow(10, lo-hi))
+}
+
+func bruteforceMatch(runes []rune, start, end int) Match {
+	return withMinimum(Match{
+		Pattern: "bruteforce",
+		Token:   string(runes[start:end]),
+		Start:   start,
+		End:     end,
+		Guesses: float64(end-start) * math.Log10(bruteforceCardinality),
+	})
+}
+
+// withMinimum raises the guesses of a match to the minimum for its length.
+func withMinimum(m Match) Match {
+	minimum := float64(minGuessesMultiChar)
+	if m.End-m.Start == 1 {
+		minimum = minGuessesSingleChar
+	}
+	m.Guesses = max(m.Guesses, math.Log10(minimum))
+	return m
+}
+
+func findMatches(runes []rune) []Match {
+	var matches []Match
+	matches = append(matches, dictionaryMatches(runes)...)
+	matches = append(matches, spatialMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, dateMatches(runes)...)
+	for i, m := range matches {
+		matches[i] = withMinimum(m)
+	}
+	return matches
+}
+
+// l33tTable maps characters to the letters they commonly stand in for.
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'}, '6': {'g'}, '9': {'g'}, '1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'7': {'l', 't'}, '0': {'o'}, '$': {'s'}, '5': {'s'}, '+': {'t'}, '%': {'x'}, '2': {'z'},
+}
+
+// dictionaryMatches finds common passwords and words, also when reversed
+// or spelled with l33t substitutions.
+func dictionaryMatches(runes []rune) []Match {
+	ranks := rankedWords()
+	var matches []Match
+	for i := range runes {
+		for j := i + 3; j <= min(len(runes), i+maxWordLength); j++ {
+			token := runes[i:j]
+			lower := []rune(strings.ToLower(string(token)))
+			caseGuesses := uppercaseVariations(token)
+
+			if rank, ok := ranks[string(lower)]; ok {
+				matches = append(matches, dictionaryMatch(token, i, j, "", rank, caseGuesses, ""))
+			}
+			reversed := slices.Clone(lower)
+			slices.Reverse(reversed)
+			if rank, ok := ranks[string(reversed)]; ok && string(reversed) != string(lower) {
+				matches = append(matches, dictionaryMatch(token, i, j, string(reversed), rank, caseGuesses*2, "reversed"))
+			}
+			for _, word := range unl33t(lower) {
+				if rank, ok := ranks[string(word)]; ok {
+					guesses := caseGuesses * l33tVariations(lower, word)
+					matches = append(matches, dictionaryMatch(token, i, j, string(word), rank, guesses, "l33t"))
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+func dictionaryMatch(token []rune, start, end int, word string, rank int, variations float64, kind string) Match {
+	m := Match{
+		Pattern: "dictionary",
+		Token:   string(token),
+		Start:   start,
+		End:     end,
+		Guesses: math.Log10(float64(rank) * variations),
+	}
+	common := isCommonRank(rank)
+	switch {
+	case common && rank <= 10:
+		m.warning = "This is a top-10 common password."
+	case common:
+		m.warning = "This is similar to a commonly used password."
+	default:
+		m.warning = "A word by itself is easy to guess."
+	}
+	switch kind {
+	case "reversed":
+		m.Detail = fmt.Sprintf("%q reversed", word)
+		m.warning = "Reversed words aren't much harder to guess."
+	case "l33t":
+		m.Detail = fmt.Sprintf("%q with l33t substitutions", word)
+		m.warning = "Predictable substitutions like '@' instead of 'a' don't help very much."
+	}
+	if common {
+		m.Detail = strings.TrimSpace("common password " + m.Detail)
+	}
+	return m
+}
+
+// unl33t returns the words token could spell with its l33t characters
+// replaced by letters. Like zxcvbn, every occurrence of a character is
+// replaced by the same letter.
+func unl33t(token []rune) [][]rune {
+	subs := []map[rune]rune{{}}
+	for _, r := range token {
+		letters, ok := l33tTable[r]
+		if _, seen := subs[0][r]; !ok || seen {
+			continue
+		}
+		var next []map[rune]rune
+		for _, sub := range subs {
+			for _, letter := range letters {
+				sub := maps.Clone(sub)
+				sub[r] = letter
+				next = append(next, sub)
+			}
+		}
+		subs = next
+	}
+	if len(subs[0]) == 0 {
+		return nil
+	}
+
+	words := make([][]rune, len(subs))
+	for i, sub := range subs {
+		words[i] = make([]rune, len(token))
+		for j, r := range token {
+			if letter, ok := sub[r]; ok {
+				r = letter
+			}
+			words[i][j] = r
+		}
+	}
+	return words
+}
+
+// uppercaseVariations counts the capitalizations an attacker tries to find
+// the token's, as zxcvbn does.
+func uppercaseVariations(token []rune) float64 {
+	var upper, lower int
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	// Capitalizing the first or last letter, or all of them, is common.
+	first, last := unicode.IsUpper(token[0]), unicode.IsUpper(token[len(token)-1])
+	if lower == 0 || (upper == 1 && (first || last)) {
+		return 2
+	}
+	return variations(upper, lower)
+}
+
+// l33tVariations counts the substitutions an attacker tries to find the
+// token's.
+func l33tVariations(token, word []rune) float64 {
+	total := 1.0
+	counts := map[[2]rune][2]int{}
+	for i, r := range token {
+		if r != word[i] {
+			c := counts[[2]rune{r, word[i]}]
+			c[0]++
+			counts[[2]rune{r, word[i]}] = c
+		}
+	}
+	for pair, c := range counts {
+		unsubbed := strings.Count(string(word), string(pair[1])) - c[0]
+		if unsubbed == 0 {
+			total *= 2
+		} else {
+			total *= variations(c[0], unsubbed)
+		}
+	}
+	return total
+}
+
+// variations sums the ways of choosing up to min(a, b) of a+b items.
+func variations(a, b int) float64 {
+	sum := 0.0
+	for i := 1; i <= min(a, b); i++ {
+		sum += binomial(a+b, i)
+	}
+	return max(sum, 1)
+}
+
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// keyboard is the position of every key of a layout, with the shifted
+// character of each.
+type keyboard struct {
+	name    string
+	keys    map[rune]keyPosition
+	shifted map[rune]bool
+	// starts and degree are the number of keys and the average number of
+	// neighbors, which bound the walks an attacker tries.
+	starts int
+	degree float64
+}
+
+type keyPosition struct {
+	row int
+	x   float64
+}
+
+var keyboards = sync.OnceValue(func() []*keyboard {
+	qwerty := newKeyboard("qwerty", []string{
+		"`~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+",
+		"qQ wW eE rR tT yY uU iI oO pP [{ ]} \\|",
+		"aA sS dD fF gG hH jJ kK lL ;: '\"",
+		"zZ xX cC vV bB nN mM ,< .> /?",
+	}, []float64{0, 1.5, 1.75, 2.25})
+	keypad := newKeyboard("keypad", []string{
+		"/ * -",
+		"7 8 9 +",
+		"4 5 6",
+		"1 2 3",
+		"0 .",
+	}, []float64{1, 0, 0, 0, 0})
+	return []*keyboard{qwerty, keypad}
+})
+
+func newKeyboard(name string, rows []string, offsets []float64) *keyboard {
+	kb := &keyboard{name: name, keys: map[rune]keyPosition{}, shifted: map[rune]bool{}}
+	for row, keys := range rows {
+		for col, key := range strings.Fields(keys) {
+			chars := []rune(key)
+			pos := keyPosition{row: row, x: float64(col) + offsets[row]}
+			kb.keys[chars[0]] = pos
+			if len(chars) > 1 {
+				kb.keys[chars[1]] = pos
+				kb.shifted[chars[1]] = true
+			}
+		}
+	}
+
+	var unshifted []rune
+	for r := range kb.keys {
+		if !kb.shifted[r] {
+			unshifted = append(unshifted, r)
+		}
+	}
+	neighbors := 0
+	for _, a := range unshifted {
+		for _, b := range unshifted {
+			if _, ok := kb.direction(a, b); ok {
+				neighbors++
+			}
+		}
+	}
+	kb.starts = len(kb.keys)
+	kb.degree = float64(neighbors) / float64(len(unshifted))
+	return kb
+}
+
+// direction returns which way b lies from a, when they are neighboring
+// keys.
+func (kb *keyboard) direction(a, b rune) ([2]int, bool) {
+	pa, okA := kb.keys[a]
+	pb, okB := kb.keys[b]
+	if !okA || !okB || pa == pb {
+		return [2]int{}, false
+	}
+	dx := pb.x - pa.x
+	dr := pb.row - pa.row
+	switch {
+	case dr == 0 && math.Abs(dx) == 1:
+	case (dr == 1 || dr == -1) && math.Abs(dx) < 1:
+	default:
+		return [2]int{}, false
+	}
+	side := 1
+	if dx < 0 {
+		side = -1
+	}
+	return [2]int{dr, side}, true
+}
+
+// spatialMatches finds walks of three or more neighboring keys.
+func spatialMatches(runes []rune) []Match {
+	var matches []Match
+	for _, kb := range keyboards() {
+		for i := 0; i < len(runes)-2; {
+			j, turns := i+1, 0
+			var last [2]int
+			for ; j < len(runes); j++ {
+				dir, ok := kb.direction(runes[j-1], runes[j])
+				if !ok {
+					break
+				}
+				if j == i+1 || dir != last {
+					turns++
+				}
+				last = dir
+			}
+			if j-i >= 3 {
+				shifted := 0
+				for _, r := range runes[i:j] {
+					if kb.shifted[r] {
+						shifted++
+					}
+				}
+				matches = append(matches, spatialMatch(kb, runes, i, j, turns, shifted))
+			}
+			i = max(j-1, i+1)
+		}
+	}
+	return matches
+}
+
+func spatialMatch(kb *keyboard, runes []rune, start, end, turns, shifted int) Match {
+	length := end - start
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * float64(kb.starts) * math.Pow(kb.degree, float64(j))
+		}
+	}
+	if unshifted := length - shifted; shifted > 0 {
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			guesses *= variations(shifted, unshifted)
+		}
+	}
+
+	warning := "Short keyboard patterns are easy to guess."
+	if turns == 1 {
+		warning = "Straight rows of keys are easy to guess."
+	}
+	return Match{
+		Pattern: "spatial",
+		Token:   string(runes[start:end]),
+		Start:   start,
+		End:     end,
+		Detail:  fmt.Sprintf("%s walk with %d turns", kb.name, turns),
+		Guesses: math.Log10(guesses),
+		warning: warning,
+	}
+}
+
+// repeatMatches finds tokens repeated two or more times, such as "aaa" or
+// "abcabc".
+func repeatMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes); {
+		bestLen, bestBase := 0, 0
+		for base := 1; i+2*base <= len(runes); base++ {
+			reps := 1
+			for i+(reps+1)*base <= len(runes) &&
+				slices.Equal(runes[i:i+base], runes[i+reps*base:i+(reps+1)*base]) {
+				reps++
+			}
+			if reps >= 2 && reps*base > bestLen {
+				bestLen, bestBase = reps*base, base
+			}
+		}
+		if bestLen == 0 {
+			i++
+			continue
+		}
+
+		baseGuesses, _ := mostGuessableSequence(runes[i : i+bestBase])
+		reps := bestLen / bestBase
+		warning := `Repeats like "abcabcabc" are only slightly harder to guess than "abc".`
+		if bestBase == 1 {
+			warning = `Repeats like "aaa" are easy to guess.`
+		}
+		matches = append(matches, Match{
+			Pattern: "repeat",
+			Token:   string(runes[i : i+bestLen]),
+			Start:   i,
+			End:     i + bestLen,
+			Detail:  fmt.Sprintf("%q %d times", string(runes[i:i+bestBase]), reps),
+			Guesses: baseGuesses + math.Log10(float64(reps)),
+			warning: warning,
+		})
+		i += bestLen
+	}
+	return matches
+}
+
+// sequenceMatches finds runs of letters or digits at a constant distance,
+// such as "abcd", "1357" or "9876".
+func sequenceMatches(runes []rune) []Match {
+	class := func(r rune) int {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 1
+		case r >= 'A' && r <= 'Z':
+			return 2
+		case r >= '0' && r <= '9':
+			return 3
+		}
+		return 0
+	}
+
+	var matches []Match
+	for i := 0; i < len(runes)-2; {
+		delta := runes[i+1] - runes[i]
+		j := i + 1
+		for j < len(runes) && runes[j]-runes[j-1] == delta && class(runes[j]) != 0 && class(runes[j]) == class(runes[i]) {
+			j++
+		}
+		if j-i < 3 || delta == 0 || delta > 5 || delta < -5 || class(runes[i]) == 0 {
+			i = max(j-1, i+1)
+			continue
+		}
+
+		base := 26.0
+		switch {
+		case strings.ContainsRune("aAzZ019", runes[i]):
+			base = 4
+		case class(runes[i]) == 3:
+			base = 10
+		}
+		if delta < 0 {
+			base *= 2
+		}
+		matches = append(matches, Match{
+			Pattern: "sequence",
+			Token:   string(runes[i:j]),
+			Start:   i,
+			End:     j,
+			Guesses: math.Log10(base * float64(j-i)),
+			warning: "Sequences like abc or 6543 are easy to guess.",
+		})
+		i = j - 1
+	}
+	return matches
+}
+
+var (
+	dateWithSeparator = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+	recentYear        = regexp.MustCompile(`^(19|20)\d\d$`)
+)
+
+// referenceYear is the year dates are assumed to be close to.
+var referenceYear = time.Now().Year()
+
+// dateMatches finds years and dates, with or without separators.
+func dateMatches(runes []rune) []Match {
+	var matches []Match
+	for i := range runes {
+		for j := i + 4; j <= min(len(runes), i+10); j++ {
+			token := string(runes[i:j])
+			if recentYear.MatchString(token) {
+				year, _ := strconv.Atoi(token)
+				matches = append(matches, Match{
+					Pattern: "date",
+					Token:   token,
+					Start:   i,
+					End:     j,
+					Detail:  "year",
+					Guesses: math.Log10(yearSpace(year)),
+					warning: "Recent years are easy to guess.",
+				})
+				continue
+			}
+
+			year, ok, separator := 0, false, false
+			if m := dateWithSeparator.FindStringSubmatch(token); m != nil && m[2] == m[4] {
+				year, ok = dateYear([]string{m[1], m[3], m[5]})
+				separator = true
+			} else if j-i <= 8 && isDigits(token) {
+				year, ok = splitDate(token)
+			}
+			if !ok {
+				continue
+			}
+			guesses := yearSpace(year) * 365
+			if separator {
+				guesses *= 4
+			}
+			matches = append(matches, Match{
+				Pattern: "date",
+				Token:   token,
+				Start:   i,
+				End:     j,
+				Detail:  fmt.Sprintf("date in %d", year),
+				Guesses: math.Log10(guesses),
+				warning: "Dates are often easy to guess.",
+			})
+		}
+	}
+	return matches
+}
+
+func yearSpace(year int) float64 {
+	return math.Max(math.Abs(float64(year-referenceYear)), 20)
+}
+
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// splitDate tries the ways of reading digits as day, month and year, such
+// as 1121990 or 19901201.
+func splitDate(digits string) (int, bool) {
+	for a := 1; a <= 4 && a < len(digits); a++ {
+		for b := a + 1; b <= a+4 && b < len(digits); b++ {
+			if year, ok := dateYear([]string{digits[:a], digits[a:b], digits[b:]}); ok {
+				return year, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// dateYear returns the year of three date parts when they make a valid date
+// in year-month-day, day-month-year or month-day-year order.
+func dateYear(parts []string) (int, bool) {
+	n := make([]int, 3)
+	for i, part := range parts {
+		if len(part) > 4 || len(part) == 3 {
+			return 0, false
+		}
+		n[i], _ = strconv.Atoi(part)
+	}
+	valid := func(year, month, day int, yearText string) (int, bool) {
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			return 0, false
+		}
+		switch len(yearText) {
+		case 4:
+			if year < 1000 || year > 2050 {
+				return 0, false
+			}
+		case 2, 1:
+			if year > 50 {
+				year += 1900
+			} else {
+				year += 2000
+			}
+		}
+		return year, true
+	}
+	if len(parts[1]) > 2 {
+		return 0, false
+	}
+	// The year comes first or last, never in the middle.
+	if year, ok := valid(n[0], n[1], n[2], parts[0]); ok && len(parts[2]) <= 2 && len(parts[0]) == 4 {
+		return year, true
+	}
+	if len(parts[0]) > 2 {
+		return 0, false
+	}
+	if year, ok := valid(n[2], n[1], n[0], parts[2]); ok {
+		return year, true
+	}
+	return valid(n[2], n[0], n[1], parts[2])
+}
+
+// displayTime rounds seconds to the largest fitting unit, as zxcvbn does.
+func displayTime(seconds float64) string {
+	units := []struct {
+		name    string
+		seconds float64
+	}{
+		{name: "year", seconds: 60 * 60 * 24 * 365},
+		{name: "month", seconds: 60 * 60 * 24 * 31},
+		{name: "day", seconds: 60 * 60 * 24},
+		{name: "hour", seconds: 60 * 60},
+		{name: "minute", seconds: 60},
+		{name: "second", seconds: 1},
+	}
+	switch {
+	case seconds < 1:
+		return "less than a second"
+	case seconds >= 100*units[0].seconds:
+		return "centuries"
+	}
+	for _, unit := range units {
+		if seconds >= unit.seconds {
+			n := int(math.Round(seconds / unit.seconds))
+			if n == 1 {
+				return "1 " + unit.name
+			}
+			return fmt.Sprintf("%d %ss", n, unit.name)
+		}
+	}
+	return "less than a second"
+}
+
+// feedback warns about the pattern that makes the password weakest and
+// suggests improvements.
+func feedback(report Report) []string {
+	if report.Length == 0 {
+		return []string{"Use a few words, avoid common phrases."}
+	}
+	if report.Strength > Fair {
+		return []string{}
+	}
+
+	var messages []string
+	var worst *Match
+	for i, m := range report.Matches {
+		if m.warning != "" && (worst == nil || m.End-m.Start > worst.End-worst.Start) {
+			worst = &report.Matches[i]
+		}
+	}
+	if worst != nil {
+		messages = append(messages, worst.warning)
+	}
+	messages = append(messages, "Add another word or two. Uncommon words are better.")
+	if worst != nil && worst.Pattern == "dictionary" && strings.ToLower(worst.Token) == worst.Token {
+		messages = append(messages, "Capitalization doesn't help very much, but all-lowercase helps even less.")
+	}
+	return messages
+}
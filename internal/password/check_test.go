@@ -0,0 +1,97 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		password string
+		strength Strength
+		patterns []string
+	}{
+		{password: "password", strength: VeryWeak, patterns: []string{"dictionary"}},
+		{password: "P@ssw0rd", strength: VeryWeak, patterns: []string{"dictionary"}},
+		{password: "drowssap", strength: VeryWeak, patterns: []string{"dictionary"}},
+		{password: "zxcvbn", strength: Weak, patterns: []string{"spatial"}},
+		{password: "aaaaaa", strength: VeryWeak, patterns: []string{"repeat"}},
+		{password: "abcdef", strength: VeryWeak, patterns: []string{"sequence"}},
+		{password: "19900101", strength: Weak, patterns: []string{"date"}},
+		{password: "sunshine1987", strength: Weak, patterns: []string{"dictionary", "date"}},
+		{password: "x7$Kp!2mQz#9", strength: VeryStrong, patterns: []string{"bruteforce"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			report := Check(tt.password)
+			if report.Strength != tt.strength {
+				t.Errorf("strength = %s, want %s (10^%.1f guesses)", report.Strength, tt.strength, report.Guesses)
+			}
+			var patterns []string
+			for _, m := range report.Matches {
+				patterns = append(patterns, m.Pattern)
+			}
+			if strings.Join(patterns, ",") != strings.Join(tt.patterns, ",") {
+				t.Errorf("patterns = %v, want %v", patterns, tt.patterns)
+			}
+			if len(report.CrackTimes) != len(scenarios) {
+				t.Errorf("crack times = %v", report.CrackTimes)
+			}
+			if tt.strength <= Fair && len(report.Feedback) == 0 {
+				t.Error("expected feedback for a weak password")
+			}
+		})
+	}
+}
+
+func TestCheckMatchesCoverPassword(t *testing.T) {
+	secret := "Tr0ub4dour&3qwerty2024"
+	report := Check(secret)
+
+	var b strings.Builder
+	end := 0
+	for _, m := range report.Matches {
+		if m.Start != end {
+			t.Fatalf("match %+v does not follow offset %d", m, end)
+		}
+		b.WriteString(m.Token)
+		end = m.End
+	}
+	if b.String() != secret {
+		t.Errorf("matches spell %q, want %q", b.String(), secret)
+	}
+}
+
+func TestCheckLongPassword(t *testing.T) {
+	report := Check(strings.Repeat("1|", 100))
+	if report.Length != 200 || report.Strength != VeryStrong {
+		t.Errorf("unexpected report for a long password: length %d, %s", report.Length, report.Strength)
+	}
+}
+
+func TestCheckCharsetEntropy(t *testing.T) {
+	report := Check("abc1")
+	if strings.Join(report.CharacterSets, ",") != "lowercase,numbers" {
+		t.Errorf("character sets = %v", report.CharacterSets)
+	}
+	if got := report.CharsetEntropy; got < 20.6 || got > 20.7 {
+		t.Errorf("charset entropy = %.2f, want 4*log2(36)", got)
+	}
+}
+
+func TestDisplayTime(t *testing.T) {
+	tests := map[float64]string{
+		0.5:        "less than a second",
+		1:          "1 second",
+		90:         "2 minutes",
+		7200:       "2 hours",
+		86400 * 3:  "3 days",
+		86400 * 62: "2 months",
+		1e12:       "centuries",
+	}
+	for seconds, want := range tests {
+		if got := displayTime(seconds); got != want {
+			t.Errorf("displayTime(%v) = %q, want %q", seconds, got, want)
+		}
+	}
+}
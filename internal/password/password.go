@@ -0,0 +1,253 @@
+// Package password generates passwords and diceware passphrases and
+// estimates how hard they are to guess.
+package password
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// CharacterSet is a group of characters a password can be drawn from.
+type CharacterSet struct {
+	ID    string
+	Label string
+	Chars string
+}
+
+var CharacterSets = []CharacterSet{
+	{ID: "lowercase", Label: "Lowercase (a-z)", Chars: "abcdefghijklmnopqrstuvwxyz"},
+	{ID: "uppercase", Label: "Uppercase (A-Z)", Chars: "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+	{ID: "numbers", Label: "Numbers (0-9)", Chars: "0123456789"},
+	{ID: "special", Label: "Special (!@#$%^&*)", Chars: "!@#$%^&*()_+-=[]{}|;:,.<>?"},
+}
+
+// DefaultSets are the IDs of the character sets used unless others are
+// chosen.
+var DefaultSets = []string{"lowercase", "uppercase", "numbers", "special"}
+
+// Ambiguous holds characters that are easily mistaken for one another.
+const Ambiguous = "Il1|O0o"
+
+// Options configure Generate.
+type Options struct {
+	Length int
+	// Sets are IDs of CharacterSets; every one of them is used at least
+	// once.
+	Sets             []string
+	ExcludeAmbiguous bool
+}
+
+// Generate returns a random password.
+func Generate(opts Options) (string, error) {
+	sets, err := opts.characterSets()
+	if err != nil {
+		return "", err
+	}
+	if opts.Length < len(sets) {
+		return "", fmt.Errorf("length must be at least %d to include every character set", len(sets))
+	}
+
+	pool := strings.Join(sets, "")
+	password := make([]byte, opts.Length)
+	for i := range password {
+		c, err := pick(pool)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Put a character of every set at a distinct random position.
+	positions, err := permutation(opts.Length)
+	if err != nil {
+		return "", err
+	}
+	for i, set := range sets {
+		c, err := pick(set)
+		if err != nil {
+			return "", err
+		}
+		password[positions[i]] = c
+	}
+	return string(password), nil
+}
+
+// Entropy returns the bits of entropy of the passwords Generate returns for
+// opts.
+func (opts Options) Entropy() float64 {
+	sets, err := opts.characterSets()
+	if err != nil {
+		return 0
+	}
+	return float64(opts.Length) * math.Log2(float64(len(strings.Join(sets, ""))))
+}
+
+// characterSets returns the characters of the chosen sets.
+func (opts Options) characterSets() ([]string, error) {
+	if len(opts.Sets) == 0 {
+		return nil, errors.New("no character sets selected")
+	}
+
+	var sets []string
+	for _, id := range opts.Sets {
+		i := slices.IndexFunc(CharacterSets, func(set CharacterSet) bool { return set.ID == id })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown character set %q", id)
+		}
+		chars := CharacterSets[i].Chars
+		if opts.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(Ambiguous, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		if !slices.Contains(sets, chars) {
+			sets = append(sets, chars)
+		}
+	}
+	return sets, nil
+}
+
+// PassphraseOptions configure GeneratePassphrase.
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+}
+
+// GeneratePassphrase returns words drawn at random from the EFF large
+// wordlist, as if by rolling five dice for each.
+func GeneratePassphrase(opts PassphraseOptions) (string, error) {
+	if opts.Words < 1 {
+		return "", errors.New("a passphrase needs at least one word")
+	}
+
+	list := Wordlist()
+	words := make([]string, opts.Words)
+	for i := range words {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(list))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random number: %w", err)
+		}
+		word := list[n.Int64()]
+		if opts.Capitalize {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, opts.Separator), nil
+}
+
+// Entropy returns the bits of entropy of the passphrases GeneratePassphrase
+// returns for opts.
+func (opts PassphraseOptions) Entropy() float64 {
+	return float64(opts.Words) * math.Log2(float64(len(Wordlist())))
+}
+
+//go:embed eff_large_wordlist.txt
+var effLargeWordlist string
+
+// Wordlist returns the 7776 words of the EFF large wordlist, see
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases.
+var Wordlist = sync.OnceValue(func() []string {
+	words := make([]string, 0, 7776)
+	scanner := bufio.NewScanner(strings.NewReader(effLargeWordlist))
+	for scanner.Scan() {
+		// Lines pair the dice roll with its word: "11111	abacus".
+		if _, word, ok := strings.Cut(scanner.Text(), "\t"); ok {
+			words = append(words, word)
+		}
+	}
+	return words
+})
+
+// pick returns a random character of chars.
+func pick(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return chars[n.Int64()], nil
+}
+
+// permutation returns 0..n-1 in random order.
+func permutation(n int) ([]int, error) {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate random number: %w", err)
+		}
+		p[i], p[j.Int64()] = p[j.Int64()], p[i]
+	}
+	return p, nil
+}
+
+// Strength rates how hard a secret is to guess, on zxcvbn's scale from 0
+// (too guessable) to 4 (very unguessable).
+type Strength int
+
+const (
+	VeryWeak Strength = iota
+	Weak
+	Fair
+	Strong
+	VeryStrong
+)
+
+var strengthNames = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+func (s Strength) String() string {
+	return strengthNames[s]
+}
+
+func (s Strength) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Strength) UnmarshalText(text []byte) error {
+	i := slices.Index(strengthNames, string(text))
+	if i < 0 {
+		return fmt.Errorf("unknown strength %q", text)
+	}
+	*s = Strength(i)
+	return nil
+}
+
+// StrengthOf rates a secret that takes 2^bits guesses to find, using
+// zxcvbn's thresholds of 10^3, 10^6, 10^8 and 10^10 guesses.
+func StrengthOf(bits float64) Strength {
+	return strengthOfGuesses(bits * math.Log10(2))
+}
+
+// strengthOfGuesses rates a secret that takes 10^log10Guesses guesses to
+// find.
+func strengthOfGuesses(log10Guesses float64) Strength {
+	switch {
+	case log10Guesses < 3:
+		return VeryWeak
+	case log10Guesses < 6:
+		return Weak
+	case log10Guesses < 8:
+		return Fair
+	case log10Guesses < 10:
+		return Strong
+	}
+	return VeryStrong
+}
@@ -0,0 +1,94 @@
+package password
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	opts := Options{Length: 4, Sets: DefaultSets}
+	for range 100 {
+		password, err := Generate(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(password) != 4 {
+			t.Fatalf("password %q has length %d", password, len(password))
+		}
+		// With one character per set, every set must still show up.
+		for _, set := range CharacterSets {
+			if !strings.ContainsAny(password, set.Chars) {
+				t.Fatalf("password %q lacks %s", password, set.ID)
+			}
+		}
+	}
+}
+
+func TestGenerateExcludeAmbiguous(t *testing.T) {
+	opts := Options{Length: 200, Sets: []string{"uppercase", "numbers"}, ExcludeAmbiguous: true}
+	password, err := Generate(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(password, Ambiguous) {
+		t.Errorf("password %q contains ambiguous characters", password)
+	}
+	if want := 200 * math.Log2(24+8); math.Abs(opts.Entropy()-want) > 1e-9 {
+		t.Errorf("Entropy() = %f, want %f", opts.Entropy(), want)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	for _, opts := range []Options{
+		{Length: 10},
+		{Length: 10, Sets: []string{"emoji"}},
+		{Length: 3, Sets: DefaultSets},
+	} {
+		if _, err := Generate(opts); err == nil {
+			t.Errorf("Generate(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	if n := len(Wordlist()); n != 7776 {
+		t.Fatalf("wordlist has %d words", n)
+	}
+
+	opts := PassphraseOptions{Words: 5, Separator: "-", Capitalize: true}
+	passphrase, err := GeneratePassphrase(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := strings.Split(passphrase, "-")
+	if len(words) != 5 {
+		t.Fatalf("passphrase %q doesn't have 5 words", passphrase)
+	}
+	for _, word := range words {
+		if word[0] < 'A' || word[0] > 'Z' {
+			t.Errorf("word %q isn't capitalized", word)
+		}
+	}
+	if got := opts.Entropy(); math.Abs(got-64.6) > 0.1 {
+		t.Errorf("Entropy() = %f, want about 64.6", got)
+	}
+}
+
+func TestStrengthOf(t *testing.T) {
+	tests := []struct {
+		bits float64
+		want Strength
+	}{
+		{bits: 0, want: VeryWeak},
+		{bits: 19, want: Weak},
+		{bits: 25, want: Fair},
+		{bits: 30, want: Strong},
+		{bits: 40, want: VeryStrong},
+	}
+	for _, tt := range tests {
+		if got := StrengthOf(tt.bits); got != tt.want {
+			t.Errorf("StrengthOf(%v) = %v, want %v", tt.bits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// builtin evaluates a function call. Arguments are unevaluated so that
+// functions like map and select can run them against each element.
+type builtin func(e *env, in any, args []node) ([]any, error)
+
+type builtinKey struct {
+	name  string
+	arity int
+}
+
+var builtins map[builtinKey]builtin
+
+func init() {
+	builtins = map[builtinKey]builtin{
+		{"empty", 0}:         func(*env, any, []node) ([]any, error) { return nil, nil },
+		{"not", 0}:           simple(func(in any) (any, error) { return !truthy(in), nil }),
+		{"length", 0}:        simple(length),
+		{"type", 0}:          simple(func(in any) (any, error) { return typeName(in), nil }),
+		{"keys", 0}:          simple(func(in any) (any, error) { return keys(in, true) }),
+		{"keys_unsorted", 0}: simple(func(in any) (any, error) { return keys(in, false) }),
+		{"values", 0}:        selectWhere(func(v any) bool { return v != nil }),
+		{"has", 1}:           withArg(has),
+		{"map", 1}:           mapBuiltin,
+		{"select", 1}:        selectBuiltin,
+		{"recurse", 0}:       func(e *env, in any, _ []node) ([]any, error) { return recurseNode{}.eval(e, in) },
+		{"first", 0}:         simple(func(in any) (any, error) { return index(in, int64(0)) }),
+		{"last", 0}:          simple(func(in any) (any, error) { return index(in, int64(-1)) }),
+		{"first", 1}:         firstOf,
+		{"reverse", 0}:       simple(reverse),
+		{"sort", 0}:          simple(func(in any) (any, error) { return sortBy(nil, in, nil) }),
+		{"sort_by", 1}:       func(e *env, in any, args []node) ([]any, error) { return one(sortBy(e, in, args[0])) },
+		{"unique", 0}:        simple(unique),
+		{"min", 0}:           simple(func(in any) (any, error) { return extreme(in, -1) }),
+		{"max", 0}:           simple(func(in any) (any, error) { return extreme(in, 1) }),
+		{"add", 0}:           simple(addAll),
+		{"any", 0}:           simple(func(in any) (any, error) { return quantify(in, true) }),
+		{"all", 0}:           simple(func(in any) (any, error) { return quantify(in, false) }),
+		{"flatten", 0}:       simple(func(in any) (any, error) { return flatten(in, math.MaxInt) }),
+		{"to_entries", 0}:    simple(toEntries),
+		{"from_entries", 0}:  simple(fromEntries),
+		{"with_entries", 1}:  withEntries,
+		{"tostring", 0}:      simple(toString),
+		{"tonumber", 0}:      simple(toNumber),
+		{"ascii_downcase", 0}: simple(func(in any) (any, error) {
+			return mapString(in, strings.ToLower)
+		}),
+		{"ascii_upcase", 0}: simple(func(in any) (any, error) {
+			return mapString(in, strings.ToUpper)
+		}),
+		{"join", 1}:       withArg(join),
+		{"split", 1}:      withArg(splitBuiltin),
+		{"startswith", 1}: withArg(stringPredicate(strings.HasPrefix)),
+		{"endswith", 1}:   withArg(stringPredicate(strings.HasSuffix)),
+		{"contains", 1}:   withArg(func(in, arg any) (any, error) { return contains(in, arg), nil }),
+		{"test", 1}:       withArg(test),
+	}
+}
+
+func lookupBuiltin(name string, arity int) (builtin, bool) {
+	fn, ok := builtins[builtinKey{name, arity}]
+	return fn, ok
+}
+
+func one(value any, err error) ([]any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []any{value}, nil
+}
+
+func simple(fn func(in any) (any, error)) builtin {
+	return func(_ *env, in any, _ []node) ([]any, error) {
+		return one(fn(in))
+	}
+}
+
+// withArg evaluates the single argument against the input and calls fn for
+// each of its outputs.
+func withArg(fn func(in, arg any) (any, error)) builtin {
+	return func(e *env, in any, args []node) ([]any, error) {
+		values, err := args[0].eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(values))
+		for _, value := range values {
+			result, err := fn(in, value)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, result)
+		}
+		return out, nil
+	}
+}
+
+func selectWhere(keep func(any) bool) builtin {
+	return func(_ *env, in any, _ []node) ([]any, error) {
+		values, err := iterate(in)
+		if err != nil {
+			return nil, err
+		}
+		var out []any
+		for _, value := range values {
+			if keep(value) {
+				out = append(out, value)
+			}
+		}
+		return out, nil
+	}
+}
+
+func length(in any) (any, error) {
+	switch v := in.(type) {
+	case nil:
+		return int64(0), nil
+	case bool:
+		return nil, errors.New("boolean has no length")
+	case int64:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case float64:
+		return math.Abs(v), nil
+	case json.Number:
+		return json.Number(strings.TrimPrefix(v.String(), "-")), nil
+	case []any:
+		return int64(len(v)), nil
+	case *converter.Object:
+		return int64(v.Len()), nil
+	}
+	if s, ok := stringValue(in); ok {
+		return int64(utf8.RuneCountInString(s)), nil
+	}
+	return nil, fmt.Errorf("%s has no length", typeName(in))
+}
+
+func keys(in any, sorted bool) (any, error) {
+	switch v := in.(type) {
+	case *converter.Object:
+		names := v.Keys()
+		if sorted {
+			slices.Sort(names)
+		}
+		return stringsToValues(names), nil
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = int64(i)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%s has no keys", typeName(in))
+	}
+}
+
+func has(in, key any) (any, error) {
+	switch v := in.(type) {
+	case *converter.Object:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot check whether object has a key of type %s", typeName(key))
+		}
+		_, exists := v.Get(k)
+		return exists, nil
+	case []any:
+		f, ok := toFloat(key)
+		if !ok {
+			return nil, fmt.Errorf("cannot check whether array has a key of type %s", typeName(key))
+		}
+		return f >= 0 && int(f) < len(v), nil
+	default:
+		return nil, fmt.Errorf("cannot check whether %s has a key", typeName(in))
+	}
+}
+
+func mapBuiltin(e *env, in any, args []node) ([]any, error) {
+	values, err := iterate(in)
+	if err != nil {
+		return nil, err
+	}
+	out := []any{}
+	for _, value := range values {
+		results, err := args[0].eval(e, value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, results...)
+	}
+	return []any{out}, nil
+}
+
+func selectBuiltin(e *env, in any, args []node) ([]any, error) {
+	conds, err := args[0].eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, cond := range conds {
+		if truthy(cond) {
+			out = append(out, in)
+		}
+	}
+	return out, nil
+}
+
+func firstOf(e *env, in any, args []node) ([]any, error) {
+	values, err := args[0].eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[:1], nil
+}
+
+func reverse(in any) (any, error) {
+	switch v := in.(type) {
+	case nil:
+		return []any{}, nil
+	case string:
+		runes := []rune(v)
+		slices.Reverse(runes)
+		return string(runes), nil
+	case []any:
+		out := slices.Clone(v)
+		slices.Reverse(out)
+		return out, nil
+	default:
+		return nil, fmt.Errorf("cannot reverse %s", typeName(in))
+	}
+}
+
+func sortBy(e *env, in any, key node) (any, error) {
+	items, ok := in.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be sorted, as it is not an array", typeName(in))
+	}
+	type keyed struct {
+		key   any
+		value any
+	}
+	pairs := make([]keyed, len(items))
+	for i, item := range items {
+		pairs[i] = keyed{key: item, value: item}
+		if key != nil {
+			values, err := key.eval(e, item)
+			if err != nil {
+				return nil, err
+			}
+			pairs[i].key = values
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b keyed) int { return compare(a.key, b.key) })
+
+	out := make([]any, len(pairs))
+	for i, pair := range pairs {
+		out[i] = pair.value
+	}
+	return out, nil
+}
+
+func unique(in any) (any, error) {
+	sorted, err := sortBy(nil, in, nil)
+	if err != nil {
+		return nil, err
+	}
+	return slices.CompactFunc(sorted.([]any), equal), nil
+}
+
+func extreme(in any, sign int) (any, error) {
+	items, ok := in.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s has no minimum or maximum", typeName(in))
+	}
+	var best any
+	for i, item := range items {
+		if i == 0 || compare(item, best)*sign > 0 {
+			best = item
+		}
+	}
+	return best, nil
+}
+
+func addAll(in any) (any, error) {
+	values, err := iterate(in)
+	if err != nil {
+		return nil, err
+	}
+	var sum any
+	for _, value := range values {
+		sum, err = add(sum, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+func quantify(in any, anyOf bool) (any, error) {
+	values, err := iterate(in)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		if truthy(value) == anyOf {
+			return anyOf, nil
+		}
+	}
+	return !anyOf, nil
+}
+
+func flatten(in any, depth int) (any, error) {
+	items, ok := in.([]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot flatten %s", typeName(in))
+	}
+	out := []any{}
+	for _, item := range items {
+		if nested, ok := item.([]any); ok && depth > 0 {
+			flat, err := flatten(nested, depth-1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, flat.([]any)...)
+			continue
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func toEntries(in any) (any, error) {
+	object, ok := in.(*converter.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s has no entries", typeName(in))
+	}
+	out := make([]any, len(object.Members))
+	for i, member := range object.Members {
+		entry := converter.NewObject()
+		entry.Set("key", member.Key)
+		entry.Set("value", member.Value)
+		out[i] = entry
+	}
+	return out, nil
+}
+
+func fromEntries(in any) (any, error) {
+	items, ok := in.([]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot build an object from %s", typeName(in))
+	}
+	out := converter.NewObject()
+	for _, item := range items {
+		entry, ok := item.(*converter.Object)
+		if !ok {
+			return nil, fmt.Errorf("entries must be objects, not %s", typeName(item))
+		}
+		var key any
+		for _, name := range []string{"key", "k", "name", "Key", "Name"} {
+			if value, ok := entry.Get(name); ok && value != nil {
+				key = value
+				break
+			}
+		}
+		var value any
+		for _, name := range []string{"value", "v", "Value"} {
+			if v, ok := entry.Get(name); ok {
+				value = v
+				break
+			}
+		}
+		switch k := key.(type) {
+		case string:
+			out.Set(k, value)
+		case int64, float64, json.Number, bool:
+			s, _ := toString(k)
+			out.Set(s.(string), value)
+		default:
+			return nil, fmt.Errorf("entry keys must be strings, not %s", typeName(key))
+		}
+	}
+	return out, nil
+}
+
+func withEntries(e *env, in any, args []node) ([]any, error) {
+	entries, err := toEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := mapBuiltin(e, entries, args)
+	if err != nil {
+		return nil, err
+	}
+	return one(fromEntries(mapped[0]))
+}
+
+func toString(in any) (any, error) {
+	if s, ok := stringValue(in); ok {
+		return s, nil
+	}
+	switch v := in.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	}
+	data, err := converter.Encode(in, converter.FormatJSONL)
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(data), nil
+}
+
+func toNumber(in any) (any, error) {
+	switch v := in.(type) {
+	case int64, float64, json.Number:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as a number", v)
+		}
+		return f, nil
+	default:
+		return nil, fmt.Errorf("%s cannot be parsed as a number", typeName(in))
+	}
+}
+
+func mapString(in any, fn func(string) string) (any, error) {
+	s, ok := stringValue(in)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a string", typeName(in))
+	}
+	return fn(s), nil
+}
+
+func join(in, sep any) (any, error) {
+	items, ok := in.([]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot join %s", typeName(in))
+	}
+	separator, ok := sep.(string)
+	if !ok {
+		return nil, errors.New("separator must be a string")
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		if _, nested := item.([]any); nested {
+			return nil, errors.New("cannot join nested arrays")
+		}
+		if _, nested := item.(*converter.Object); nested {
+			return nil, errors.New("cannot join objects")
+		}
+		s, err := toString(item)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = s.(string)
+	}
+	return strings.Join(parts, separator), nil
+}
+
+func splitBuiltin(in, sep any) (any, error) {
+	s, ok := stringValue(in)
+	if !ok {
+		return nil, fmt.Errorf("cannot split %s", typeName(in))
+	}
+	separator, ok := sep.(string)
+	if !ok {
+		return nil, errors.New("separator must be a string")
+	}
+	return split(s, separator), nil
+}
+
+func split(s, sep string) []any {
+	if s == "" {
+		return []any{}
+	}
+	return stringsToValues(strings.Split(s, sep))
+}
+
+func stringPredicate(fn func(s, arg string) bool) func(in, arg any) (any, error) {
+	return func(in, arg any) (any, error) {
+		s, ok := stringValue(in)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a string", typeName(in))
+		}
+		a, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument must be a string, not %s", typeName(arg))
+		}
+		return fn(s, a), nil
+	}
+}
+
+// contains follows jq: substrings for strings, subsets for arrays and
+// objects, equality otherwise.
+func contains(in, arg any) bool {
+	switch v := in.(type) {
+	case string:
+		a, ok := arg.(string)
+		return ok && strings.Contains(v, a)
+	case []any:
+		wanted, ok := arg.([]any)
+		if !ok {
+			return false
+		}
+		for _, w := range wanted {
+			if !slices.ContainsFunc(v, func(item any) bool { return contains(item, w) }) {
+				return false
+			}
+		}
+		return true
+	case *converter.Object:
+		wanted, ok := arg.(*converter.Object)
+		if !ok {
+			return false
+		}
+		for _, member := range wanted.Members {
+			value, exists := v.Get(member.Key)
+			if !exists || !contains(value, member.Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equal(in, arg)
+	}
+}
+
+func test(in, pattern any) (any, error) {
+	s, ok := stringValue(in)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be matched, as it is not a string", typeName(in))
+	}
+	p, ok := pattern.(string)
+	if !ok {
+		return nil, errors.New("pattern must be a string")
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(s), nil
+}
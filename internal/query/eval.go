@@ -0,0 +1,568 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+type env struct {
+	root any
+}
+
+type node interface {
+	eval(e *env, in any) ([]any, error)
+}
+
+type identityNode struct{}
+
+func (identityNode) eval(_ *env, in any) ([]any, error) {
+	return []any{in}, nil
+}
+
+type rootNode struct{}
+
+func (rootNode) eval(e *env, _ any) ([]any, error) {
+	return []any{e.root}, nil
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(_ *env, _ any) ([]any, error) {
+	return []any{n.value}, nil
+}
+
+// recurseNode yields its input and every value nested in it, depth first.
+type recurseNode struct{}
+
+func (recurseNode) eval(_ *env, in any) ([]any, error) {
+	var out []any
+	walk(in, func(value any) { out = append(out, value) })
+	return out, nil
+}
+
+func walk(value any, fn func(any)) {
+	fn(value)
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			walk(item, fn)
+		}
+	case *converter.Object:
+		for _, member := range v.Members {
+			walk(member.Value, fn)
+		}
+	}
+}
+
+type pipeNode struct {
+	left, right node
+}
+
+func (n pipeNode) eval(e *env, in any) ([]any, error) {
+	lefts, err := n.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, value := range lefts {
+		results, err := n.right.eval(e, value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, results...)
+	}
+	return out, nil
+}
+
+type commaNode struct {
+	left, right node
+}
+
+func (n commaNode) eval(e *env, in any) ([]any, error) {
+	lefts, err := n.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := n.right.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	return append(lefts, rights...), nil
+}
+
+// indexNode implements .[key], .[n] and .name on each output of target.
+type indexNode struct {
+	target node
+	key    node
+}
+
+func (n indexNode) eval(e *env, in any) ([]any, error) {
+	targets, err := n.target.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := n.key.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, target := range targets {
+		for _, key := range keys {
+			value, err := index(target, key)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, value)
+		}
+	}
+	return out, nil
+}
+
+func index(target, key any) (any, error) {
+	if target == nil {
+		return nil, nil
+	}
+	switch t := target.(type) {
+	case *converter.Object:
+		if k, ok := key.(string); ok {
+			value, _ := t.Get(k)
+			return value, nil
+		}
+	case []any:
+		if f, ok := toFloat(key); ok {
+			i := int(f)
+			if i < 0 {
+				i += len(t)
+			}
+			if i < 0 || i >= len(t) {
+				return nil, nil
+			}
+			return t[i], nil
+		}
+	}
+	return nil, fmt.Errorf("cannot index %s with %s", typeName(target), describe(key))
+}
+
+type sliceNode struct {
+	target   node
+	from, to node
+}
+
+func (n sliceNode) eval(e *env, in any) ([]any, error) {
+	targets, err := n.target.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	bound := func(bn node) (*int, error) {
+		if bn == nil {
+			return nil, nil
+		}
+		values, err := bn.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, errors.New("slice bounds must produce a single value")
+		}
+		if values[0] == nil {
+			return nil, nil
+		}
+		f, ok := toFloat(values[0])
+		if !ok {
+			return nil, fmt.Errorf("slice bounds must be numbers, not %s", typeName(values[0]))
+		}
+		i := int(f)
+		return &i, nil
+	}
+	from, err := bound(n.from)
+	if err != nil {
+		return nil, err
+	}
+	to, err := bound(n.to)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []any
+	for _, target := range targets {
+		value, err := slice(target, from, to, nil)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+// slice applies Python style bounds to arrays and strings.
+func slice(target any, from, to, step *int) (any, error) {
+	var length int
+	switch t := target.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		length = len(t)
+	case string:
+		length = len([]rune(t))
+	default:
+		return nil, fmt.Errorf("cannot slice %s", typeName(target))
+	}
+
+	s := 1
+	if step != nil {
+		s = *step
+	}
+	if s == 0 {
+		return []any{}, nil
+	}
+	normalize := func(p *int, def int) int {
+		if p == nil {
+			return def
+		}
+		i := *p
+		if i < 0 {
+			i += length
+		}
+		if s > 0 {
+			return max(0, min(i, length))
+		}
+		return max(-1, min(i, length-1))
+	}
+	var start, end int
+	if s > 0 {
+		start, end = normalize(from, 0), normalize(to, length)
+	} else {
+		start, end = normalize(from, length-1), normalize(to, -1)
+	}
+
+	var indexes []int
+	for i := start; (s > 0 && i < end) || (s < 0 && i > end); i += s {
+		indexes = append(indexes, i)
+	}
+
+	if str, ok := target.(string); ok {
+		runes := []rune(str)
+		out := make([]rune, 0, len(indexes))
+		for _, i := range indexes {
+			out = append(out, runes[i])
+		}
+		return string(out), nil
+	}
+	items := target.([]any)
+	out := make([]any, 0, len(indexes))
+	for _, i := range indexes {
+		out = append(out, items[i])
+	}
+	return out, nil
+}
+
+type iterateNode struct {
+	target node
+}
+
+func (n iterateNode) eval(e *env, in any) ([]any, error) {
+	targets, err := n.target.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, target := range targets {
+		values, err := iterate(target)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, values...)
+	}
+	return out, nil
+}
+
+func iterate(value any) ([]any, error) {
+	switch v := value.(type) {
+	case []any:
+		return v, nil
+	case *converter.Object:
+		out := make([]any, len(v.Members))
+		for i, member := range v.Members {
+			out[i] = member.Value
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("cannot iterate over %s", describe(value))
+	}
+}
+
+// tryNode implements the "?" suffix: errors produce no output.
+type tryNode struct {
+	body node
+}
+
+func (n tryNode) eval(e *env, in any) ([]any, error) {
+	out, err := n.body.eval(e, in)
+	if err != nil {
+		return nil, nil
+	}
+	return out, nil
+}
+
+type collectNode struct {
+	body node
+}
+
+func (n collectNode) eval(e *env, in any) ([]any, error) {
+	items := []any{}
+	if n.body != nil {
+		values, err := n.body.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, values...)
+	}
+	return []any{items}, nil
+}
+
+type objectEntry struct {
+	key   node
+	value node
+}
+
+type objectNode struct {
+	entries []objectEntry
+}
+
+// eval builds one object per combination of key and value outputs, as jq
+// does.
+func (n objectNode) eval(e *env, in any) ([]any, error) {
+	objects := []*converter.Object{converter.NewObject()}
+	for _, entry := range n.entries {
+		keys, err := entry.key.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		values, err := entry.value.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []*converter.Object
+		for _, object := range objects {
+			for _, key := range keys {
+				k, ok := stringValue(key)
+				if !ok {
+					return nil, fmt.Errorf("object keys must be strings, not %s", typeName(key))
+				}
+				for _, value := range values {
+					clone := converter.NewObject()
+					for _, member := range object.Members {
+						clone.Set(member.Key, member.Value)
+					}
+					clone.Set(k, value)
+					next = append(next, clone)
+				}
+			}
+		}
+		objects = next
+	}
+
+	out := make([]any, len(objects))
+	for i, object := range objects {
+		out[i] = object
+	}
+	return out, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n binaryNode) eval(e *env, in any) ([]any, error) {
+	rights, err := n.right.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	lefts, err := n.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []any
+	for _, r := range rights {
+		for _, l := range lefts {
+			var value any
+			switch n.op {
+			case "==":
+				value = equal(l, r)
+			case "!=":
+				value = !equal(l, r)
+			case "<":
+				value = compare(l, r) < 0
+			case "<=":
+				value = compare(l, r) <= 0
+			case ">":
+				value = compare(l, r) > 0
+			case ">=":
+				value = compare(l, r) >= 0
+			default:
+				value, err = arithmetic(n.op, l, r)
+				if err != nil {
+					return nil, err
+				}
+			}
+			out = append(out, value)
+		}
+	}
+	return out, nil
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(e *env, in any) ([]any, error) {
+	return logical(e, in, n.left, n.right, false)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(e *env, in any) ([]any, error) {
+	return logical(e, in, n.left, n.right, true)
+}
+
+// logical evaluates "and" and "or": when the left side is already decisive
+// the right side is not evaluated.
+func logical(e *env, in any, left, right node, isOr bool) ([]any, error) {
+	lefts, err := left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, l := range lefts {
+		if truthy(l) == isOr {
+			out = append(out, isOr)
+			continue
+		}
+		rights, err := right.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rights {
+			out = append(out, truthy(r))
+		}
+	}
+	return out, nil
+}
+
+type notNode struct {
+	body node
+}
+
+func (n notNode) eval(e *env, in any) ([]any, error) {
+	values, err := n.body.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, len(values))
+	for i, value := range values {
+		out[i] = !truthy(value)
+	}
+	return out, nil
+}
+
+type negateNode struct {
+	body node
+}
+
+func (n negateNode) eval(e *env, in any) ([]any, error) {
+	values, err := n.body.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case int64:
+			out[i] = -v
+		case float64:
+			out[i] = -v
+		case json.Number:
+			if s, ok := strings.CutPrefix(v.String(), "-"); ok {
+				out[i] = json.Number(s)
+			} else {
+				out[i] = json.Number("-" + s)
+			}
+		default:
+			return nil, fmt.Errorf("%s cannot be negated", describe(value))
+		}
+	}
+	return out, nil
+}
+
+// alternativeNode implements "a // b": the truthy outputs of a, or b when
+// there are none.
+type alternativeNode struct {
+	left, right node
+}
+
+func (n alternativeNode) eval(e *env, in any) ([]any, error) {
+	lefts, err := n.left.eval(e, in)
+	var out []any
+	if err == nil {
+		for _, value := range lefts {
+			if truthy(value) {
+				out = append(out, value)
+			}
+		}
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+	return n.right.eval(e, in)
+}
+
+type ifNode struct {
+	cond      node
+	then      node
+	otherwise node
+}
+
+func (n ifNode) eval(e *env, in any) ([]any, error) {
+	conds, err := n.cond.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, cond := range conds {
+		branch := n.otherwise
+		if truthy(cond) {
+			branch = n.then
+		}
+		values, err := branch.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, values...)
+	}
+	return out, nil
+}
+
+type callNode struct {
+	name string
+	args []node
+	fn   builtin
+}
+
+func (n callNode) eval(e *env, in any) ([]any, error) {
+	out, err := n.fn(e, in, n.args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,280 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// JSONPath queries select nodes from the document: unlike jq paths, missing
+// keys and out of range indexes produce nothing instead of null. Filter
+// expressions ("?(...)") use the jq expression syntax with "@" for the
+// current node, "$" for the root and "&&", "||" and "!" as logical
+// operators.
+
+type jsonPathSelector interface {
+	selectFrom(e *env, value any) ([]any, error)
+}
+
+type jsonPathNode struct {
+	target     node
+	descendant bool
+	selectors  []jsonPathSelector
+}
+
+func (n jsonPathNode) eval(e *env, in any) ([]any, error) {
+	targets, err := n.target.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, target := range targets {
+		candidates := []any{target}
+		if n.descendant {
+			candidates = nil
+			walk(target, func(value any) { candidates = append(candidates, value) })
+		}
+		for _, candidate := range candidates {
+			for _, selector := range n.selectors {
+				values, err := selector.selectFrom(e, candidate)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, values...)
+			}
+		}
+	}
+	return out, nil
+}
+
+type nameSelector struct {
+	name string
+}
+
+func (s nameSelector) selectFrom(_ *env, value any) ([]any, error) {
+	if object, ok := value.(*converter.Object); ok {
+		if v, exists := object.Get(s.name); exists {
+			return []any{v}, nil
+		}
+	}
+	return nil, nil
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(_ *env, value any) ([]any, error) {
+	switch value.(type) {
+	case []any, *converter.Object:
+		return iterate(value)
+	}
+	return nil, nil
+}
+
+type indexSelector struct {
+	index int
+}
+
+func (s indexSelector) selectFrom(_ *env, value any) ([]any, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, nil
+	}
+	i := s.index
+	if i < 0 {
+		i += len(items)
+	}
+	if i < 0 || i >= len(items) {
+		return nil, nil
+	}
+	return []any{items[i]}, nil
+}
+
+type sliceSelector struct {
+	from, to, step *int
+}
+
+func (s sliceSelector) selectFrom(_ *env, value any) ([]any, error) {
+	if _, ok := value.([]any); !ok {
+		return nil, nil
+	}
+	items, err := slice(value, s.from, s.to, s.step)
+	if err != nil {
+		return nil, err
+	}
+	return items.([]any), nil
+}
+
+type filterSelector struct {
+	expr node
+}
+
+func (s filterSelector) selectFrom(e *env, value any) ([]any, error) {
+	var children []any
+	switch value.(type) {
+	case []any, *converter.Object:
+		children, _ = iterate(value)
+	default:
+		return nil, nil
+	}
+
+	var out []any
+	for _, child := range children {
+		results, err := s.expr.eval(e, child)
+		if err != nil {
+			// A filter that does not apply to a node does not select it.
+			continue
+		}
+		for _, result := range results {
+			if truthy(result) {
+				out = append(out, child)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseJSONPath parses a complete JSONPath expression starting with "$".
+func (p *parser) parseJSONPath() (node, error) {
+	if err := p.expectPunct("$"); err != nil {
+		return nil, err
+	}
+	var current node = identityNode{}
+
+	for p.peek().kind != tokEOF {
+		t := p.peek()
+		switch {
+		case t.kind == tokField:
+			p.next()
+			current = jsonPathNode{target: current, selectors: []jsonPathSelector{nameSelector{name: t.text}}}
+		case t.kind == tokDot:
+			p.next()
+			if !p.isPunct("*") {
+				return nil, p.errorf("expected a member name or \"*\" after \".\"")
+			}
+			p.next()
+			current = jsonPathNode{target: current, selectors: []jsonPathSelector{wildcardSelector{}}}
+		case t.kind == tokDotDot:
+			p.next()
+			var selectors []jsonPathSelector
+			switch next := p.peek(); {
+			case next.kind == tokIdent:
+				p.next()
+				selectors = []jsonPathSelector{nameSelector{name: next.text}}
+			case p.isPunct("*"):
+				p.next()
+				selectors = []jsonPathSelector{wildcardSelector{}}
+			case p.isPunct("["):
+				var err error
+				selectors, err = p.parseJSONPathBracket()
+				if err != nil {
+					return nil, err
+				}
+			default:
+				return nil, p.errorf("expected a member name, \"*\" or \"[\" after \"..\"")
+			}
+			current = jsonPathNode{target: current, descendant: true, selectors: selectors}
+		case p.isPunct("["):
+			selectors, err := p.parseJSONPathBracket()
+			if err != nil {
+				return nil, err
+			}
+			current = jsonPathNode{target: current, selectors: selectors}
+		default:
+			return nil, p.errorf("unexpected token in JSONPath")
+		}
+	}
+	return current, nil
+}
+
+// parseJSONPathBracket parses a bracketed, comma separated selector list.
+func (p *parser) parseJSONPathBracket() ([]jsonPathSelector, error) {
+	if err := p.expectPunct("["); err != nil {
+		return nil, err
+	}
+	var selectors []jsonPathSelector
+	for {
+		selector, err := p.parseJSONPathSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+	return selectors, nil
+}
+
+func (p *parser) parseJSONPathSelector() (jsonPathSelector, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokString:
+		p.next()
+		return nameSelector{name: t.value.(string)}, nil
+	case p.isPunct("*"):
+		p.next()
+		return wildcardSelector{}, nil
+	case p.isPunct("?"):
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	}
+
+	from, err := p.parseJSONPathInt()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isPunct(":") {
+		if from == nil {
+			return nil, p.errorf("expected a selector")
+		}
+		return indexSelector{index: *from}, nil
+	}
+	p.next()
+	to, err := p.parseJSONPathInt()
+	if err != nil {
+		return nil, err
+	}
+	var step *int
+	if p.isPunct(":") {
+		p.next()
+		if step, err = p.parseJSONPathInt(); err != nil {
+			return nil, err
+		}
+	}
+	return sliceSelector{from: from, to: to, step: step}, nil
+}
+
+// parseJSONPathInt parses an optional, possibly negative integer.
+func (p *parser) parseJSONPathInt() (*int, error) {
+	negative := false
+	if p.isPunct("-") {
+		p.next()
+		negative = true
+	}
+	t := p.peek()
+	if t.kind != tokNumber {
+		if negative {
+			return nil, p.errorf("expected a number")
+		}
+		return nil, nil
+	}
+	i, ok := t.value.(int64)
+	if !ok {
+		return nil, fmt.Errorf("index %s at position %d must be an integer", t.text, t.pos+1)
+	}
+	p.next()
+	n := int(i)
+	if negative {
+		n = -n
+	}
+	return &n, nil
+}
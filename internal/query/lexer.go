@@ -0,0 +1,176 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokDot
+	tokDotDot
+	tokField
+	tokIdent
+	tokNumber
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+// punctuation is ordered so that longer operators are matched first.
+var punctuation = []string{
+	"==", "!=", "<=", ">=", "//", "&&", "||",
+	"|", ",", "(", ")", "[", "]", "{", "}", ":", ";", "?",
+	"<", ">", "+", "-", "*", "/", "%", "!", "$", "@",
+}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case c == '.':
+			start := i
+			if strings.HasPrefix(source[i:], "..") {
+				tokens = append(tokens, token{kind: tokDotDot, text: "..", pos: start})
+				i += 2
+				continue
+			}
+			i++
+			if i < len(source) && isIdentStart(rune(source[i])) {
+				j := i
+				for j < len(source) && isIdentPart(rune(source[j])) {
+					j++
+				}
+				tokens = append(tokens, token{kind: tokField, text: source[i:j], pos: start})
+				i = j
+				continue
+			}
+			if i < len(source) && source[i] >= '0' && source[i] <= '9' {
+				return nil, fmt.Errorf("unexpected number after '.' at position %d", start+1)
+			}
+			tokens = append(tokens, token{kind: tokDot, text: ".", pos: start})
+		case c == '"' || c == '\'':
+			text, n, err := lexString(source[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w at position %d", err, i+1)
+			}
+			tokens = append(tokens, token{kind: tokString, text: source[i : i+n], value: text, pos: i})
+			i += n
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(source) && (isDigit(source[j]) || source[j] == '.' ||
+				source[j] == 'e' || source[j] == 'E' ||
+				((source[j] == '+' || source[j] == '-') && (source[j-1] == 'e' || source[j-1] == 'E'))) {
+				j++
+			}
+			text := source[i:j]
+			var value any
+			if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+				value = n
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				value = f
+			} else {
+				return nil, fmt.Errorf("invalid number %q at position %d", text, i+1)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text, value: value, pos: i})
+			i = j
+		case isIdentStart(rune(c)):
+			j := i
+			for j < len(source) && isIdentPart(rune(source[j])) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: source[i:j], pos: i})
+			i = j
+		default:
+			matched := false
+			for _, p := range punctuation {
+				if strings.HasPrefix(source[i:], p) {
+					tokens = append(tokens, token{kind: tokPunct, text: p, pos: i})
+					i += len(p)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i+1)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(source)}), nil
+}
+
+// lexString reads a double or single quoted string with JSON escapes and
+// returns its value and length in the source.
+func lexString(source string) (string, int, error) {
+	quote := source[0]
+	var b strings.Builder
+	for i := 1; i < len(source); i++ {
+		c := source[i]
+		switch c {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i >= len(source) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			switch source[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if i+4 >= len(source) {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(source[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				b.WriteByte(source[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Identifiers are ASCII only; other keys can be written as ."key".
+func isIdentStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// OutputOptions controls how results are rendered.
+type OutputOptions struct {
+	// Raw writes string results without quotes, like jq -r.
+	Raw bool
+	// Compact writes JSON results on a single line each.
+	Compact bool
+}
+
+// Format renders query results in a converter format. JSON and JSON Lines
+// write one document per result, as jq does; other formats have no notion
+// of a document stream, so several results are rendered as one array.
+func Format(results []any, to converter.Format, opts OutputOptions) (string, error) {
+	if to != converter.FormatJSON && to != converter.FormatJSONL {
+		if opts.Raw && len(results) == 1 {
+			if s, ok := stringValue(results[0]); ok {
+				return s + "\n", nil
+			}
+		}
+		var value any = results
+		if len(results) == 1 {
+			value = results[0]
+		}
+		return converter.Encode(value, to)
+	}
+
+	var b strings.Builder
+	for _, result := range results {
+		if opts.Raw {
+			if s, ok := stringValue(result); ok {
+				b.WriteString(s + "\n")
+				continue
+			}
+		}
+		format := to
+		if opts.Compact {
+			format = converter.FormatJSONL
+		}
+		if format == converter.FormatJSONL {
+			// JSON Lines would spread an array result over several lines.
+			result = []any{result}
+		}
+		out, err := converter.Encode(result, format)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(out)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,536 @@
+package query
+
+import "fmt"
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(source string) (*parser, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(text string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == text
+}
+
+func (p *parser) isKeyword(text string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.text == text
+}
+
+func (p *parser) expectPunct(text string) error {
+	if !p.isPunct(text) {
+		return p.errorf("expected %q", text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectKeyword(text string) error {
+	if !p.isKeyword(text) {
+		return p.errorf("expected %q", text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	t := p.peek()
+	found := "end of input"
+	if t.kind != tokEOF {
+		found = fmt.Sprintf("%q", tokenText(t))
+	}
+	return fmt.Errorf("%s at position %d, found %s", fmt.Sprintf(format, args...), t.pos+1, found)
+}
+
+func tokenText(t token) string {
+	switch t.kind {
+	case tokField:
+		return "." + t.text
+	default:
+		return t.text
+	}
+}
+
+// parseProgram parses a complete jq expression.
+func (p *parser) parseProgram() (node, error) {
+	n, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected token")
+	}
+	return n, nil
+}
+
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("|") {
+		p.next()
+		right, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return pipeNode{left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseComma() (node, error) {
+	left, err := p.parseAlternative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(",") {
+		p.next()
+		right, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		left = commaNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAlternative() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("//") {
+		p.next()
+		right, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		return alternativeNode{left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") || p.isPunct("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") || p.isPunct("&&") {
+		p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isPunct(op) {
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return binaryNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch {
+	case p.isPunct("-"):
+		p.next()
+		body, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if lit, ok := body.(literalNode); ok {
+			switch v := lit.value.(type) {
+			case int64:
+				return literalNode{value: -v}, nil
+			case float64:
+				return literalNode{value: -v}, nil
+			}
+		}
+		return negateNode{body: body}, nil
+	case p.isPunct("!"):
+		p.next()
+		body, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{body: body}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	term, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseSuffixes(term)
+}
+
+func (p *parser) parseSuffixes(term node) (node, error) {
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokField:
+			p.next()
+			term = indexNode{target: term, key: literalNode{value: t.text}}
+		case t.kind == tokDot && p.peekAt(1).kind == tokString:
+			p.next()
+			key := p.next()
+			term = indexNode{target: term, key: literalNode{value: key.value}}
+		case t.kind == tokDot && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "[":
+			p.next()
+		case p.isPunct("["):
+			var err error
+			term, err = p.parseBracket(term)
+			if err != nil {
+				return nil, err
+			}
+		case p.isPunct("?"):
+			p.next()
+			term = tryNode{body: term}
+		default:
+			return term, nil
+		}
+	}
+}
+
+// parseBracket parses [], [expr] and [from:to] after a term.
+func (p *parser) parseBracket(term node) (node, error) {
+	if err := p.expectPunct("["); err != nil {
+		return nil, err
+	}
+	if p.isPunct("]") {
+		p.next()
+		return iterateNode{target: term}, nil
+	}
+
+	var from node
+	if !p.isPunct(":") {
+		var err error
+		from, err = p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.isPunct(":") {
+		p.next()
+		var to node
+		if !p.isPunct("]") {
+			var err error
+			to, err = p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		return sliceNode{target: term, from: from, to: to}, nil
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+	return indexNode{target: term, key: from}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokField:
+		p.next()
+		return indexNode{target: identityNode{}, key: literalNode{value: t.text}}, nil
+	case tokDot:
+		p.next()
+		if p.peek().kind == tokString {
+			key := p.next()
+			return indexNode{target: identityNode{}, key: literalNode{value: key.value}}, nil
+		}
+		return identityNode{}, nil
+	case tokDotDot:
+		p.next()
+		return recurseNode{}, nil
+	case tokNumber, tokString:
+		p.next()
+		return literalNode{value: t.value}, nil
+	case tokIdent:
+		return p.parseIdent()
+	case tokPunct:
+		switch t.text {
+		case "(":
+			p.next()
+			body, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			return body, nil
+		case "[":
+			p.next()
+			if p.isPunct("]") {
+				p.next()
+				return collectNode{}, nil
+			}
+			body, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+			return collectNode{body: body}, nil
+		case "{":
+			return p.parseObject()
+		case "$":
+			p.next()
+			if p.peek().kind == tokIdent && p.peek().pos == t.pos+1 {
+				return nil, p.errorf("variables are not supported")
+			}
+			return rootNode{}, nil
+		case "@":
+			// "@" is the current node in JSONPath filters.
+			p.next()
+			return identityNode{}, nil
+		}
+	}
+	return nil, p.errorf("unexpected token")
+}
+
+func (p *parser) parseIdent() (node, error) {
+	t := p.next()
+	switch t.text {
+	case "true":
+		return literalNode{value: true}, nil
+	case "false":
+		return literalNode{value: false}, nil
+	case "null":
+		return literalNode{value: nil}, nil
+	case "if":
+		return p.parseIf()
+	case "and", "or", "then", "elif", "else", "end":
+		p.pos--
+		return nil, p.errorf("unexpected keyword")
+	}
+
+	var args []node
+	if p.isPunct("(") {
+		p.next()
+		for {
+			arg, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.isPunct(";") {
+				p.next()
+				continue
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	fn, ok := lookupBuiltin(t.text, len(args))
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s/%d at position %d", t.text, len(args), t.pos+1)
+	}
+	return callNode{name: t.text, args: args, fn: fn}, nil
+}
+
+func (p *parser) parseIf() (node, error) {
+	cond, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("then"); err != nil {
+		return nil, err
+	}
+	then, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+
+	n := ifNode{cond: cond, then: then, otherwise: identityNode{}}
+	switch {
+	case p.isKeyword("elif"):
+		p.next()
+		n.otherwise, err = p.parseIf()
+		return n, err
+	case p.isKeyword("else"):
+		p.next()
+		n.otherwise, err = p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectKeyword("end"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// parseObject parses {a, "b": .x, (.k): .v}.
+func (p *parser) parseObject() (node, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var n objectNode
+	for !p.isPunct("}") {
+		var entry objectEntry
+		t := p.peek()
+		switch {
+		case t.kind == tokIdent || t.kind == tokString:
+			p.next()
+			key := t.text
+			if t.kind == tokString {
+				key = t.value.(string)
+			}
+			entry.key = literalNode{value: key}
+			entry.value = indexNode{target: identityNode{}, key: literalNode{value: key}}
+		case p.isPunct("("):
+			p.next()
+			key, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			entry.key = key
+		default:
+			return nil, p.errorf("expected an object key")
+		}
+
+		if p.isPunct(":") {
+			p.next()
+			value, err := p.parseObjectValue()
+			if err != nil {
+				return nil, err
+			}
+			entry.value = value
+		} else if entry.value == nil {
+			return nil, p.errorf("expected \":\"")
+		}
+		n.entries = append(n.entries, entry)
+
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectPunct("}"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// parseObjectValue parses an object value, which may contain pipes but not
+// a top-level comma.
+func (p *parser) parseObjectValue() (node, error) {
+	left, err := p.parseAlternative()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("|") {
+		p.next()
+		right, err := p.parseObjectValue()
+		if err != nil {
+			return nil, err
+		}
+		return pipeNode{left: left, right: right}, nil
+	}
+	return left, nil
+}
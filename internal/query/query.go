@@ -0,0 +1,62 @@
+// Package query evaluates jq-style and JSONPath expressions against the
+// ordered trees produced by internal/converter.
+//
+// Expressions starting with "$" are JSONPath (for example
+// "$.store.book[?(@.price < 10)].title"); everything else is read as a subset
+// of jq: paths, iteration, pipes, comma, comparison and arithmetic
+// operators, "//", if/then/else, array and object construction and a set of
+// builtins such as map, select, keys and length.
+package query
+
+import (
+	"strings"
+)
+
+// Query is a compiled expression.
+type Query struct {
+	source string
+	root   node
+}
+
+// Compile parses an expression.
+func Compile(expr string) (*Query, error) {
+	source := strings.TrimSpace(expr)
+	if source == "" {
+		source = "."
+	}
+
+	p, err := newParser(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var root node
+	if strings.HasPrefix(source, "$") {
+		root, err = p.parseJSONPath()
+	} else {
+		root, err = p.parseProgram()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Query{source: source, root: root}, nil
+}
+
+// String returns the source of the query.
+func (q *Query) String() string {
+	return q.source
+}
+
+// Run evaluates the query against value and returns every result.
+func (q *Query) Run(value any) ([]any, error) {
+	return q.root.eval(&env{root: value}, value)
+}
+
+// Run compiles expr and evaluates it against value.
+func Run(expr string, value any) ([]any, error) {
+	q, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Run(value)
+}
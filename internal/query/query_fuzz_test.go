@@ -0,0 +1,31 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+func FuzzRunDoesNotPanic(f *testing.F) {
+	seeds := []string{
+		".", ".a.b", ".[] | select(.x > 1)", "map(.a) | add", "{a, b: .c}",
+		"$..price", "$.a[?(@.b == 'x')]", "$.a[1:3:-1]", "if . then 1 else 2 end",
+		".a // .b", "[.[] | tostring] | join(\",\")", "..",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	value, err := converter.Decode(`{"a":{"b":[1,2,{"c":"x"}]},"n":[3,1,2],"s":"text","x":null}`, converter.FormatJSON)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		q, err := Compile(expr)
+		if err != nil {
+			return
+		}
+		_, _ = q.Run(value)
+	})
+}
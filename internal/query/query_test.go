@@ -0,0 +1,147 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+const store = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 19.95}
+  },
+  "expensive": 10
+}`
+
+func runJSON(t *testing.T, expr, doc string) string {
+	t.Helper()
+	value, err := converter.Decode(doc, converter.FormatJSON)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	results, err := Run(expr, value)
+	if err != nil {
+		t.Fatalf("Run(%q) error = %v", expr, err)
+	}
+	out, err := converter.Encode(results, converter.FormatJSONL)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return strings.TrimSpace(out)
+}
+
+func TestJQ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr string
+		doc  string
+		want string
+	}{
+		{".", `{"b":1,"a":2}`, `{"b":1,"a":2}`},
+		{".a.b", `{"a":{"b":3}}`, `3`},
+		{".missing", `{"a":1}`, `null`},
+		{`."key with space"`, `{"key with space":1}`, `1`},
+		{".[1]", `[1,2,3]`, `2`},
+		{".[-1]", `[1,2,3]`, `3`},
+		{".[1:]", `[1,2,3]`, `[2,3]`},
+		{".[:2]", `"hello"`, `"he"`},
+		{".[]", `{"a":1,"b":2}`, "1\n2"},
+		{".items[].id", `{"items":[{"id":1},{"id":2}]}`, "1\n2"},
+		{".a, .b", `{"a":1,"b":2}`, "1\n2"},
+		{"[.[] | . * 2]", `[1,2,3]`, `[2,4,6]`},
+		{"map(.price)", `[{"price":1},{"price":2.5}]`, `[1,2.5]`},
+		{"map(select(.ok)) | length", `[{"ok":true},{"ok":false},{"ok":true}]`, `2`},
+		{".[] | select(.age > 30 and .name != \"x\") | .name", `[{"name":"a","age":31},{"name":"b","age":20}]`, `"a"`},
+		{"keys", `{"b":1,"a":2}`, `["a","b"]`},
+		{"keys_unsorted", `{"b":1,"a":2}`, `["b","a"]`},
+		{"length", `"héllo"`, `5`},
+		{"has(\"a\")", `{"a":null}`, `true`},
+		{"{name, total: (.a + .b)}", `{"name":"n","a":1,"b":2}`, `{"name":"n","total":3}`},
+		{"{(.k): .v}", `{"k":"x","v":1}`, `{"x":1}`},
+		{".a // \"default\"", `{"a":null}`, `"default"`},
+		{"if .n > 1 then \"big\" elif .n == 1 then \"one\" else \"small\" end", `{"n":1}`, `"one"`},
+		{"[.[] | tostring] | join(\",\")", `[1,"a",true]`, `"1,a,true"`},
+		{"sort_by(.n) | map(.n)", `[{"n":3},{"n":1},{"n":2}]`, `[1,2,3]`},
+		{"unique", `[3,1,3,2]`, `[1,2,3]`},
+		{"to_entries | map(.key)", `{"z":1,"a":2}`, `["z","a"]`},
+		{"with_entries(select(.value > 1))", `{"a":1,"b":2}`, `{"b":2}`},
+		{"[.. | select(type == \"number\")]", `{"a":[1,{"b":2}]}`, `[1,2]`},
+		{"add", `[1,2,3.5]`, `6.5`},
+		{".[] | select(test(\"^a\"))", `["ab","ba"]`, `"ab"`},
+		{"10 / 4, 10 / 5, 7 % 3", `null`, "2.5\n2\n1"},
+		{"[.[] | type]", `[null,true,1,"s",[],{}]`, `["null","boolean","number","string","array","object"]`},
+		{".a?", `[1]`, ``},
+		{".id, (.id | type), -.id", `{"id":12345678901234567890}`, "12345678901234567890\n\"number\"\n-12345678901234567890"},
+		{".[] | tostring", `[12345678901234567890]`, `"12345678901234567890"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			if got := runJSON(t, tt.expr, tt.doc); got != tt.want {
+				t.Fatalf("Run(%q) = %s, want %s", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"$.store.bicycle.color", `"red"`},
+		{"$.store.book[*].author", `"Nigel Rees"` + "\n" + `"Evelyn Waugh"` + "\n" + `"Herman Melville"` + "\n" + `"J. R. R. Tolkien"`},
+		{"$..price", "8.95\n12.99\n8.99\n22.99\n19.95"},
+		{"$.store.book[2].title", `"Moby Dick"`},
+		{"$.store.book[-1].title", `"The Lord of the Rings"`},
+		{"$.store.book[0,1].price", "8.95\n12.99"},
+		{"$.store.book[:2].price", "8.95\n12.99"},
+		{"$.store.book[?(@.isbn)].title", `"Moby Dick"` + "\n" + `"The Lord of the Rings"`},
+		{"$.store.book[?(@.price < $.expensive)].title", `"Sayings of the Century"` + "\n" + `"Moby Dick"`},
+		{"$..book[?@.category == 'fiction' && @.price > 20].author", `"J. R. R. Tolkien"`},
+		{"$['store']['bicycle']['price']", `19.95`},
+		{"$.store.missing", ``},
+		{"$.store.*.color", `"red"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			if got := runJSON(t, tt.expr, store); got != tt.want {
+				t.Fatalf("Run(%q) = %s, want %s", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{".a |", "map(", "unknownfn", ".[1", "{a:}", "$.a[", "$.", "if . then 1", ".a += 1", "$x"} {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Compile(%q) expected an error", expr)
+		}
+	}
+}
+
+func TestRuntimeErrors(t *testing.T) {
+	t.Parallel()
+
+	value, _ := converter.Decode(`{"a":[1,2]}`, converter.FormatJSON)
+	for _, expr := range []string{".a.b", ".a[] | .[]", "keys | .[0] + 1"} {
+		if _, err := Run(expr, value); err == nil {
+			t.Errorf("Run(%q) expected an error", expr)
+		}
+	}
+}
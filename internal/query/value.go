@@ -0,0 +1,289 @@
+package query
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// Values follow the converter tree: nil, bool, int64, float64, string,
+// time.Time, []any and *converter.Object, with json.Number for integers
+// beyond int64. Dates behave as strings.
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int64, float64, json.Number:
+		return "number"
+	case string, time.Time:
+		return "string"
+	case []any:
+		return "array"
+	case *converter.Object:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func stringValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	default:
+		return "", false
+	}
+}
+
+// number returns f as an int64 when it is integral, matching how the
+// converter decodes numbers.
+func number(f float64) any {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	return f
+}
+
+// typeOrder ranks types the way jq sorts them.
+func typeOrder(value any) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case int64, float64, json.Number:
+		return 3
+	case string, time.Time:
+		return 4
+	case []any:
+		return 5
+	default:
+		return 6
+	}
+}
+
+func compare(a, b any) int {
+	if c := cmp.Compare(typeOrder(a), typeOrder(b)); c != 0 {
+		return c
+	}
+
+	switch av := a.(type) {
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return cmp.Compare(av, bv)
+		}
+	case []any:
+		bv := b.([]any)
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := compare(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(av), len(bv))
+	case *converter.Object:
+		bv := b.(*converter.Object)
+		ak, bk := sortedKeys(av), sortedKeys(bv)
+		if c := compare(stringsToValues(ak), stringsToValues(bk)); c != 0 {
+			return c
+		}
+		for _, key := range ak {
+			x, _ := av.Get(key)
+			y, _ := bv.Get(key)
+			if c := compare(x, y); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+
+	if af, ok := toFloat(a); ok {
+		bf, _ := toFloat(b)
+		return cmp.Compare(af, bf)
+	}
+	if as, ok := stringValue(a); ok {
+		bs, _ := stringValue(b)
+		return cmp.Compare(as, bs)
+	}
+	return 0
+}
+
+func equal(a, b any) bool {
+	return compare(a, b) == 0
+}
+
+func sortedKeys(object *converter.Object) []string {
+	keys := object.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
+func stringsToValues(items []string) []any {
+	values := make([]any, len(items))
+	for i, item := range items {
+		values[i] = item
+	}
+	return values
+}
+
+func describe(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return typeName(v) + " " + strconv.Quote(v)
+	default:
+		return typeName(v)
+	}
+}
+
+func arithmetic(op string, a, b any) (any, error) {
+	switch op {
+	case "+":
+		return add(a, b)
+	case "-":
+		switch av := a.(type) {
+		case []any:
+			bv, ok := b.([]any)
+			if !ok {
+				break
+			}
+			out := []any{}
+			for _, item := range av {
+				if !slices.ContainsFunc(bv, func(other any) bool { return equal(item, other) }) {
+					out = append(out, item)
+				}
+			}
+			return out, nil
+		}
+	case "/":
+		if as, ok := a.(string); ok {
+			if bs, ok := b.(string); ok {
+				return split(as, bs), nil
+			}
+		}
+	}
+
+	if ai, ok := a.(int64); ok {
+		if bi, ok := b.(int64); ok {
+			switch op {
+			case "-":
+				return ai - bi, nil
+			case "*":
+				return ai * bi, nil
+			case "/":
+				if bi == 0 {
+					return nil, fmt.Errorf("%d and %d cannot be divided because the divisor is zero", ai, bi)
+				}
+				if ai%bi == 0 {
+					return ai / bi, nil
+				}
+			case "%":
+				if bi == 0 {
+					return nil, fmt.Errorf("%d and %d cannot be divided because the divisor is zero", ai, bi)
+				}
+				return ai % bi, nil
+			}
+		}
+	}
+
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		switch op {
+		case "-":
+			return af - bf, nil
+		case "*":
+			return af * bf, nil
+		case "/":
+			if bf == 0 {
+				return nil, fmt.Errorf("%v and %v cannot be divided because the divisor is zero", af, bf)
+			}
+			return af / bf, nil
+		case "%":
+			if int64(bf) == 0 {
+				return nil, fmt.Errorf("%v and %v cannot be divided because the divisor is zero", af, bf)
+			}
+			return int64(af) % int64(bf), nil
+		}
+	}
+	return nil, fmt.Errorf("%s and %s cannot be combined with %q", describe(a), describe(b), op)
+}
+
+func add(a, b any) (any, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+	switch av := a.(type) {
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return av + bv, nil
+		}
+	case string:
+		if bs, ok := stringValue(b); ok {
+			return av + bs, nil
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			return append(slices.Clone(av), bv...), nil
+		}
+	case *converter.Object:
+		if bv, ok := b.(*converter.Object); ok {
+			out := converter.NewObject()
+			for _, member := range av.Members {
+				out.Set(member.Key, member.Value)
+			}
+			for _, member := range bv.Members {
+				out.Set(member.Key, member.Value)
+			}
+			return out, nil
+		}
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af + bf, nil
+	}
+	return nil, fmt.Errorf("%s and %s cannot be added", describe(a), describe(b))
+}
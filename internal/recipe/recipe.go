@@ -0,0 +1,174 @@
+// Package recipe stores named pipelines of devtui commands. Each recipe is a
+// TOML file in the recipes directory under the devtui config directory:
+//
+//	description = "Repair JSON and convert it to YAML"
+//
+//	[[steps]]
+//	command = "jsonrepair"
+//
+//	[[steps]]
+//	command = "query"
+//	args = [".items[]"]
+//
+//	[[steps]]
+//	command = "json2yaml"
+package recipe
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Extension is the file extension of recipe files.
+const Extension = ".toml"
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Step runs one devtui command. Command may include a subcommand, such as
+// "jsonschema infer", and Args holds its flags and arguments.
+type Step struct {
+	Command string   `toml:"command"`
+	Args    []string `toml:"args,omitempty"`
+}
+
+// String renders the step as it would be typed after "devtui".
+func (s Step) String() string {
+	return strings.Join(append([]string{s.Command}, s.Args...), " ")
+}
+
+// Recipe is an ordered list of steps; the output of each step is the input
+// of the next.
+type Recipe struct {
+	Name        string `toml:"-"`
+	Description string `toml:"description,omitempty"`
+	Steps       []Step `toml:"steps"`
+}
+
+// Summary describes the recipe by its description, or by its steps when it
+// has none.
+func (r Recipe) Summary() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	steps := make([]string, len(r.Steps))
+	for i, step := range r.Steps {
+		steps[i] = step.String()
+	}
+	return strings.Join(steps, " → ")
+}
+
+// StepFunc runs a single step on its input and returns the output.
+type StepFunc func(step Step, input []byte) ([]byte, error)
+
+// Run feeds input through every step in order.
+func (r Recipe) Run(input []byte, run StepFunc) ([]byte, error) {
+	if len(r.Steps) == 0 {
+		return nil, fmt.Errorf("recipe %q has no steps", r.Name)
+	}
+	output := input
+	for i, step := range r.Steps {
+		var err error
+		output, err = run(step, output)
+		if err != nil {
+			return nil, fmt.Errorf("step %d (%s): %w", i+1, step, err)
+		}
+	}
+	return output, nil
+}
+
+// Dir returns the directory recipes are stored in.
+func Dir() string {
+	return filepath.Join(xdg.ConfigHome, "devtui", "recipes")
+}
+
+// Path returns the file of the named recipe.
+func Path(name string) string {
+	return filepath.Join(Dir(), name+Extension)
+}
+
+// ValidateName reports whether name can be used as a recipe name.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid recipe name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// Load reads the named recipe.
+func Load(name string) (Recipe, error) {
+	if err := ValidateName(name); err != nil {
+		return Recipe{}, err
+	}
+	data, err := os.ReadFile(Path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Recipe{}, fmt.Errorf("recipe %q not found in %s", name, Dir())
+	} else if err != nil {
+		return Recipe{}, err
+	}
+	return Parse(name, data)
+}
+
+// Parse decodes a recipe file.
+func Parse(name string, data []byte) (Recipe, error) {
+	var r Recipe
+	if err := toml.Unmarshal(data, &r); err != nil {
+		return Recipe{}, fmt.Errorf("recipe %q: %w", name, err)
+	}
+	r.Name = name
+	for i, step := range r.Steps {
+		if strings.TrimSpace(step.Command) == "" {
+			return Recipe{}, fmt.Errorf("recipe %q: step %d has no command", name, i+1)
+		}
+	}
+	return r, nil
+}
+
+// List reads every recipe, sorted by name. A missing recipes directory means
+// there are none.
+func List() ([]Recipe, error) {
+	entries, err := os.ReadDir(Dir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var recipes []Recipe
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), Extension)
+		if entry.IsDir() || !ok || ValidateName(name) != nil {
+			continue
+		}
+		r, err := Load(name)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, r)
+	}
+	slices.SortFunc(recipes, func(a, b Recipe) int { return strings.Compare(a.Name, b.Name) })
+	return recipes, nil
+}
+
+// Save writes the recipe to its file, replacing an existing one.
+func Save(r Recipe) error {
+	if err := ValidateName(r.Name); err != nil {
+		return err
+	}
+	data, err := toml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir(), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(r.Name), data, 0o600)
+}
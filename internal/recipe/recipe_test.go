@@ -0,0 +1,99 @@
+package recipe
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+func useRecipeDir(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(t.TempDir(), "xdg"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	r := Recipe{Name: "shout", Steps: []Step{{Command: "upper"}, {Command: "suffix", Args: []string{"!"}}}}
+	run := func(step Step, input []byte) ([]byte, error) {
+		switch step.Command {
+		case "upper":
+			return []byte(strings.ToUpper(string(input))), nil
+		case "suffix":
+			return append(input, step.Args[0]...), nil
+		}
+		return nil, errors.New("unknown command")
+	}
+
+	got, err := r.Run([]byte("hi"), run)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if string(got) != "HI!" {
+		t.Errorf("Run() = %q, want %q", got, "HI!")
+	}
+
+	r.Steps = append(r.Steps, Step{Command: "missing"})
+	if _, err := r.Run([]byte("hi"), run); err == nil || !strings.Contains(err.Error(), "step 3 (missing)") {
+		t.Errorf("Run() error = %v, want it to name step 3", err)
+	}
+}
+
+func TestSaveLoadList(t *testing.T) {
+	useRecipeDir(t)
+
+	if recipes, err := List(); err != nil || len(recipes) != 0 {
+		t.Fatalf("List() = %v, %v; want no recipes", recipes, err)
+	}
+
+	want := Recipe{
+		Name:        "tidy",
+		Description: "Repair and format JSON",
+		Steps:       []Step{{Command: "jsonrepair"}, {Command: "query", Args: []string{".items[]"}}},
+	}
+	if err := Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := Save(Recipe{Name: "another", Steps: []Step{{Command: "jsonfmt"}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := Load("tidy")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Description != want.Description || len(got.Steps) != 2 || got.Steps[1].String() != "query .items[]" {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	recipes, err := List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(recipes) != 2 || recipes[0].Name != "another" || recipes[1].Name != "tidy" {
+		t.Errorf("List() = %+v, want another and tidy", recipes)
+	}
+	if got := recipes[0].Summary(); got != "jsonfmt" {
+		t.Errorf("Summary() = %q, want the steps", got)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	useRecipeDir(t)
+
+	if _, err := Load("../etc/passwd"); err == nil {
+		t.Error("Load() accepted a path as name")
+	}
+	if _, err := Load("missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Load() error = %v, want not found", err)
+	}
+	if _, err := Parse("bad", []byte("[[steps]]\nargs = [\"x\"]\n")); err == nil {
+		t.Error("Parse() accepted a step without command")
+	}
+}
@@ -0,0 +1,83 @@
+package structgen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var kotlinKeywords = []string{
+	"as", "break", "class", "continue", "do", "else", "false", "for", "fun", "if", "in",
+	"interface", "is", "null", "object", "package", "return", "super", "this", "throw",
+	"true", "try", "typealias", "typeof", "val", "var", "when", "while",
+}
+
+// renderKotlin writes data classes for kotlinx.serialization.
+func renderKotlin(b *bytes.Buffer, m *model) {
+	var body bytes.Buffer
+	usesJSON, usesSerialName := false, false
+	for _, s := range m.types {
+		fmt.Fprintf(&body, "\n@Serializable\ndata class %s(\n", s.name)
+		used := map[string]bool{}
+		for _, f := range s.fields {
+			name := unique(used, camelCase(f.key, "field"))
+			if name != f.key {
+				usesSerialName = true
+				fmt.Fprintf(&body, "    @SerialName(%s)\n", strconv.Quote(f.key))
+			}
+			if slices.Contains(kotlinKeywords, name) {
+				name = "`" + name + "`"
+			}
+
+			t := kotlinType(f.shape, &usesJSON)
+			if f.optional(s) {
+				if !strings.HasSuffix(t, "?") {
+					t += "?"
+				}
+				t += " = null"
+			}
+			fmt.Fprintf(&body, "    val %s: %s,\n", name, t)
+		}
+		body.WriteString(")\n")
+	}
+	if m.root.kind() != kindObject {
+		fmt.Fprintf(&body, "\ntypealias Root = %s\n", kotlinType(m.root, &usesJSON))
+	}
+
+	if usesSerialName {
+		b.WriteString("import kotlinx.serialization.SerialName\n")
+	}
+	b.WriteString("import kotlinx.serialization.Serializable\n")
+	if usesJSON {
+		b.WriteString("import kotlinx.serialization.json.JsonElement\n")
+	}
+	b.Write(body.Bytes())
+}
+
+// kotlinType renders the type of s, noting whether JsonElement is needed.
+func kotlinType(s *shape, usesJSON *bool) string {
+	var t string
+	switch s.kind() {
+	case kindBool:
+		t = "Boolean"
+	case kindInt:
+		t = "Long"
+	case kindFloat:
+		t = "Double"
+	case kindString:
+		t = "String"
+	case kindArray:
+		t = "List<" + kotlinType(s.elem, usesJSON) + ">"
+	case kindObject:
+		t = s.name
+	default:
+		*usesJSON = true
+		t = "JsonElement"
+	}
+	if s.nullable() || (s.kind() == kindUnknown && s.null) {
+		t += "?"
+	}
+	return t
+}
@@ -0,0 +1,266 @@
+package structgen
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/skatkov/devtui/internal/converter"
+)
+
+// kind is the type a shape resolves to once every sample is observed.
+type kind int
+
+const (
+	// kindUnknown means only nulls, or nothing at all, were observed.
+	kindUnknown kind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+	kindArray
+	kindObject
+	// kindAny means incompatible types were observed at the same place.
+	kindAny
+)
+
+// shape accumulates the values observed at one place in the samples.
+type shape struct {
+	null, boolean, integer, float, str, array, object bool
+
+	fields  []*field
+	byKey   map[string]*field
+	objects int
+
+	elem *shape
+
+	// name is assigned to object shapes before rendering.
+	name string
+}
+
+// field is an object property. It is optional when some objects lacked it.
+type field struct {
+	key   string
+	shape *shape
+	seen  int
+}
+
+func newShape() *shape {
+	return &shape{}
+}
+
+// observe merges a converter tree into the shape.
+func (s *shape) observe(value any) {
+	switch v := value.(type) {
+	case nil:
+		s.null = true
+	case bool:
+		s.boolean = true
+	case int64:
+		s.integer = true
+	case float64, json.Number:
+		s.float = true
+	case string, time.Time:
+		s.str = true
+	case []any:
+		s.array = true
+		if s.elem == nil {
+			s.elem = newShape()
+		}
+		for _, item := range v {
+			s.elem.observe(item)
+		}
+	case *converter.Object:
+		s.object = true
+		if s.byKey == nil {
+			s.byKey = map[string]*field{}
+		}
+		s.objects++
+		for _, member := range v.Members {
+			f, ok := s.byKey[member.Key]
+			if !ok {
+				f = &field{key: member.Key, shape: newShape()}
+				s.byKey[member.Key] = f
+				s.fields = append(s.fields, f)
+			}
+			f.seen++
+			f.shape.observe(member.Value)
+		}
+	}
+}
+
+func (s *shape) kind() kind {
+	kinds := 0
+	k := kindUnknown
+	for _, candidate := range []struct {
+		seen bool
+		kind kind
+	}{
+		{s.boolean, kindBool},
+		{s.integer || s.float, kindInt},
+		{s.str, kindString},
+		{s.array, kindArray},
+		{s.object, kindObject},
+	} {
+		if candidate.seen {
+			kinds++
+			k = candidate.kind
+		}
+	}
+	switch {
+	case kinds > 1:
+		return kindAny
+	case k == kindInt && s.float:
+		return kindFloat
+	}
+	return k
+}
+
+// nullable reports whether null was observed alongside a concrete type.
+func (s *shape) nullable() bool {
+	return s.null && s.kind() != kindUnknown
+}
+
+func (f *field) optional(parent *shape) bool {
+	return f.seen < parent.objects
+}
+
+// model is the result of observing every sample: the root shape and the
+// named object types in dependency order, so that a type is declared before
+// the types that use it.
+type model struct {
+	root  *shape
+	types []*shape
+}
+
+func newModel(samples []any) *model {
+	root := newShape()
+	for _, sample := range samples {
+		root.observe(sample)
+	}
+
+	m := &model{root: root}
+	names := map[string]int{}
+	m.name(root, "Root", names)
+	m.collect(root, map[*shape]bool{})
+	return m
+}
+
+// name assigns unique type names to the object shapes below s, deriving
+// them from the keys leading to each object.
+func (m *model) name(s *shape, name string, names map[string]int) {
+	switch s.kind() {
+	case kindObject:
+		names[name]++
+		if n := names[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+		s.name = name
+		for _, f := range s.fields {
+			m.name(f.shape, pascalCase(f.key, "Field"), names)
+		}
+	case kindArray:
+		m.name(s.elem, singular(name), names)
+	}
+}
+
+func (m *model) collect(s *shape, done map[*shape]bool) {
+	if done[s] {
+		return
+	}
+	done[s] = true
+	switch s.kind() {
+	case kindObject:
+		for _, f := range s.fields {
+			m.collect(f.shape, done)
+		}
+		m.types = append(m.types, s)
+	case kindArray:
+		m.collect(s.elem, done)
+	}
+}
+
+// singular derives the name of an array element type from the array name.
+func singular(name string) string {
+	switch {
+	case name == "Root":
+		return "RootItem"
+	case strings.HasSuffix(name, "ies") && len(name) > 4:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ses"), strings.HasSuffix(name, "xes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 3:
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
+
+// words splits a key into words at separators and case changes, so that
+// "firstName", "first_name" and "first-name" all yield [first name].
+func words(key string) []string {
+	var (
+		result  []string
+		current []rune
+	)
+	runes := []rune(key)
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return result
+}
+
+// pascalCase converts a key to PascalCase, falling back to fallback when the
+// key has no letters or digits, and prefixing names that start with a digit.
+func pascalCase(key, fallback string) string {
+	var b strings.Builder
+	for _, word := range words(key) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	name := b.String()
+	if name == "" {
+		return fallback
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		return fallback + name
+	}
+	return name
+}
+
+func camelCase(key, fallback string) string {
+	name := []rune(pascalCase(key, fallback))
+	name[0] = unicode.ToLower(name[0])
+	return string(name)
+}
+
+func snakeCase(key, fallback string) string {
+	name := strings.Join(words(key), "_")
+	if name == "" {
+		return fallback
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		return fallback + "_" + name
+	}
+	return name
+}
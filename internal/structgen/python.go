@@ -0,0 +1,141 @@
+package structgen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var pythonKeywords = []string{
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class",
+	"continue", "def", "del", "elif", "else", "except", "finally", "for", "from", "global",
+	"if", "import", "in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise", "return",
+	"try", "while", "with", "yield",
+}
+
+// renderPython writes dataclasses, or Pydantic models when pydantic is set.
+// Dataclass fields with defaults must follow those without, so optional
+// fields are moved to the end; Pydantic keeps the document order and maps
+// renamed fields with aliases.
+func renderPython(b *bytes.Buffer, m *model, pydantic bool) {
+	var body bytes.Buffer
+	usesAny, usesField := false, false
+	for _, s := range m.types {
+		if pydantic {
+			fmt.Fprintf(&body, "\n\nclass %s(BaseModel):\n", s.name)
+		} else {
+			fmt.Fprintf(&body, "\n\n@dataclass\nclass %s:\n", s.name)
+		}
+
+		fields := s.fields
+		if !pydantic {
+			fields = slices.Clone(fields)
+			slices.SortStableFunc(fields, func(a, b *field) int {
+				return boolOrder(a.optional(s)) - boolOrder(b.optional(s))
+			})
+		}
+
+		used := map[string]bool{}
+		for _, f := range fields {
+			name := snakeCase(f.key, "field")
+			if slices.Contains(pythonKeywords, name) {
+				name += "_"
+			}
+			name = unique(used, name)
+
+			t := pythonType(f.shape, &usesAny)
+			optional := f.optional(s)
+			if optional && !strings.HasSuffix(t, " | None") && t != "Any" && t != "None" {
+				t += " | None"
+			}
+
+			line := "    " + name + ": " + t
+			switch {
+			case pydantic && name != f.key:
+				usesField = true
+				if optional {
+					line += " = Field(default=None, alias=" + strconv.Quote(f.key) + ")"
+				} else {
+					line += " = Field(alias=" + strconv.Quote(f.key) + ")"
+				}
+			case optional:
+				line += " = None"
+			}
+			if !pydantic && name != f.key {
+				line += "  # " + strconv.Quote(f.key)
+			}
+			body.WriteString(line + "\n")
+		}
+		if len(fields) == 0 {
+			body.WriteString("    pass\n")
+		}
+	}
+	if m.root.kind() != kindObject {
+		fmt.Fprintf(&body, "\n\nRoot = %s\n", pythonType(m.root, &usesAny))
+	}
+
+	if !pydantic {
+		b.WriteString("from dataclasses import dataclass\n")
+	}
+	if usesAny {
+		b.WriteString("from typing import Any\n")
+	}
+	switch {
+	case pydantic && usesField:
+		b.WriteString(pythonSeparator(usesAny) + "from pydantic import BaseModel, Field\n")
+	case pydantic:
+		b.WriteString(pythonSeparator(usesAny) + "from pydantic import BaseModel\n")
+	}
+	b.Write(body.Bytes())
+}
+
+// pythonType renders the annotation for s, noting whether typing.Any is
+// needed.
+func pythonType(s *shape, usesAny *bool) string {
+	var t string
+	switch s.kind() {
+	case kindUnknown:
+		if s.null {
+			return "None"
+		}
+		*usesAny = true
+		return "Any"
+	case kindBool:
+		t = "bool"
+	case kindInt:
+		t = "int"
+	case kindFloat:
+		t = "float"
+	case kindString:
+		t = "str"
+	case kindArray:
+		t = "list[" + pythonType(s.elem, usesAny) + "]"
+	case kindObject:
+		t = s.name
+	default:
+		*usesAny = true
+		return "Any"
+	}
+	if s.nullable() {
+		t += " | None"
+	}
+	return t
+}
+
+func boolOrder(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// pythonSeparator returns the blank line between standard library and
+// third-party imports, when there are standard library imports.
+func pythonSeparator(stdlib bool) string {
+	if stdlib {
+		return "\n"
+	}
+	return ""
+}
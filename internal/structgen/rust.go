@@ -0,0 +1,91 @@
+package structgen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var rustKeywords = []string{
+	"abstract", "as", "async", "await", "become", "box", "break", "const", "continue", "crate",
+	"do", "dyn", "else", "enum", "extern", "false", "final", "fn", "for", "if", "impl", "in",
+	"let", "loop", "macro", "match", "mod", "move", "mut", "override", "priv", "pub", "ref",
+	"return", "static", "struct", "super", "trait", "true", "try", "type", "typeof", "unsafe",
+	"unsized", "use", "virtual", "where", "while", "yield",
+}
+
+func renderRust(b *bytes.Buffer, m *model) {
+	b.WriteString("use serde::{Deserialize, Serialize};\n\n")
+	for _, s := range m.types {
+		b.WriteString("#[derive(Debug, Clone, Serialize, Deserialize)]\n")
+		fmt.Fprintf(b, "pub struct %s {\n", s.name)
+		used := map[string]bool{}
+		for _, f := range s.fields {
+			name := unique(used, snakeCase(f.key, "field"))
+			t := rustType(f.shape)
+
+			var attributes []string
+			if name != f.key {
+				attributes = append(attributes, "rename = "+strconv.Quote(f.key))
+			}
+			if f.optional(s) {
+				attributes = append(attributes, "default")
+				// A missing Value defaults to null and needs no Option.
+				if t != "serde_json::Value" {
+					if !strings.HasPrefix(t, "Option<") {
+						t = "Option<" + t + ">"
+					}
+					attributes = append(attributes, `skip_serializing_if = "Option::is_none"`)
+				}
+			}
+			if len(attributes) > 0 {
+				fmt.Fprintf(b, "    #[serde(%s)]\n", strings.Join(attributes, ", "))
+			}
+			if slices.Contains(rustKeywords, name) {
+				name = "r#" + name
+			}
+			fmt.Fprintf(b, "    pub %s: %s,\n", name, t)
+		}
+		b.WriteString("}\n\n")
+	}
+	if m.root.kind() != kindObject {
+		fmt.Fprintf(b, "pub type Root = %s;\n", rustType(m.root))
+	}
+}
+
+func rustType(s *shape) string {
+	var t string
+	switch s.kind() {
+	case kindBool:
+		t = "bool"
+	case kindInt:
+		t = "i64"
+	case kindFloat:
+		t = "f64"
+	case kindString:
+		t = "String"
+	case kindArray:
+		t = "Vec<" + rustType(s.elem) + ">"
+	case kindObject:
+		t = s.name
+	default:
+		// serde_json::Value already represents null.
+		return "serde_json::Value"
+	}
+	if s.nullable() {
+		t = "Option<" + t + ">"
+	}
+	return t
+}
+
+// unique returns name, or name with a numeric suffix when it is taken.
+func unique(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
@@ -1,33 +1,201 @@
+// Package structgen generates type definitions from sample JSON and YAML
+// documents. Go structs come from go-jsonstruct; the other languages are
+// rendered from a model built by observing every sample, where a property
+// missing from some objects is optional and one that was null is nullable.
 package structgen
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"slices"
+	"strings"
 
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/jsonschema"
 	"github.com/twpayne/go-jsonstruct/v3"
+	"gopkg.in/yaml.v3"
 )
 
-// JSONToGoStruct converts JSON input into a Go struct definition.
-func JSONToGoStruct(input io.Reader) (string, error) {
-	generator := newGenerator()
-	if err := generator.ObserveJSONReader(input); err != nil {
-		return "", err
+// Language identifies the language of the generated types.
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguageTypeScript Language = "typescript"
+	LanguageZod        Language = "zod"
+	LanguageRust       Language = "rust"
+	LanguagePython     Language = "python"
+	LanguagePydantic   Language = "pydantic"
+	LanguageKotlin     Language = "kotlin"
+	LanguageJSONSchema Language = "jsonschema"
+)
+
+var languages = []Language{
+	LanguageGo,
+	LanguageTypeScript,
+	LanguageZod,
+	LanguageRust,
+	LanguagePython,
+	LanguagePydantic,
+	LanguageKotlin,
+	LanguageJSONSchema,
+}
+
+var languageAliases = map[string]Language{
+	"ts":     LanguageTypeScript,
+	"rs":     LanguageRust,
+	"py":     LanguagePython,
+	"kt":     LanguageKotlin,
+	"golang": LanguageGo,
+}
+
+// Languages returns the supported languages in display order.
+func Languages() []Language {
+	return slices.Clone(languages)
+}
+
+// ParseLanguage resolves a language name or alias such as "ts".
+func ParseLanguage(name string) (Language, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if lang, ok := languageAliases[name]; ok {
+		return lang, nil
+	}
+	if slices.Contains(languages, Language(name)) {
+		return Language(name), nil
 	}
+	names := make([]string, len(languages))
+	for i, lang := range languages {
+		names[i] = string(lang)
+	}
+	return "", fmt.Errorf("unknown language %q; use one of: %s", name, strings.Join(names, ", "))
+}
 
-	bytes, err := generator.Generate()
-	if err != nil {
-		return "", err
+// Lexer returns the chroma lexer name for highlighting code in lang.
+func (lang Language) Lexer() string {
+	switch lang {
+	case LanguageZod:
+		return "typescript"
+	case LanguagePydantic:
+		return "python"
+	case LanguageJSONSchema:
+		return "json"
+	default:
+		return string(lang)
 	}
+}
 
-	return string(bytes), nil
+// JSONToGoStruct converts JSON input into a Go struct definition.
+func JSONToGoStruct(input io.Reader) (string, error) {
+	return JSONToStruct(input, LanguageGo)
 }
 
 // YAMLToGoStruct converts YAML input into a Go struct definition.
 func YAMLToGoStruct(input io.Reader) (string, error) {
-	generator := newGenerator()
-	if err := generator.ObserveYAMLReader(input); err != nil {
+	return YAMLToStruct(input, LanguageGo)
+}
+
+// JSONToStruct converts a stream of one or more JSON documents into type
+// definitions in lang.
+func JSONToStruct(input io.Reader, lang Language) (string, error) {
+	if lang == LanguageGo {
+		generator := newGenerator()
+		if err := generator.ObserveJSONReader(input); err != nil {
+			return "", err
+		}
+		return generateGo(generator)
+	}
+
+	var samples []any
+	decoder := json.NewDecoder(input)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		value, err := converter.Decode(string(raw), converter.FormatJSON)
+		if err != nil {
+			return "", err
+		}
+		samples = append(samples, value)
+	}
+	return generate(samples, lang)
+}
+
+// YAMLToStruct converts a stream of one or more YAML documents into type
+// definitions in lang.
+func YAMLToStruct(input io.Reader, lang Language) (string, error) {
+	if lang == LanguageGo {
+		generator := newGenerator()
+		if err := generator.ObserveYAMLReader(input); err != nil {
+			return "", err
+		}
+		return generateGo(generator)
+	}
+
+	var samples []any
+	decoder := yaml.NewDecoder(input)
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		document, err := yaml.Marshal(&node)
+		if err != nil {
+			return "", err
+		}
+		value, err := converter.Decode(string(document), converter.FormatYAML)
+		if err != nil {
+			return "", err
+		}
+		samples = append(samples, value)
+	}
+	return generate(samples, lang)
+}
+
+func generate(samples []any, lang Language) (string, error) {
+	if len(samples) == 0 {
+		return "", errors.New("no samples found")
+	}
+
+	var (
+		b   bytes.Buffer
+		err error
+	)
+	m := newModel(samples)
+	switch lang {
+	case LanguageTypeScript:
+		renderTypeScript(&b, m)
+	case LanguageZod:
+		renderZod(&b, m)
+	case LanguageRust:
+		renderRust(&b, m)
+	case LanguagePython:
+		renderPython(&b, m, false)
+	case LanguagePydantic:
+		renderPython(&b, m, true)
+	case LanguageKotlin:
+		renderKotlin(&b, m)
+	case LanguageJSONSchema:
+		var out string
+		out, err = converter.Encode(jsonschema.Infer(samples...), converter.FormatJSON)
+		b.WriteString(out)
+	default:
+		_, err = ParseLanguage(string(lang))
+	}
+	if err != nil {
 		return "", err
 	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
 
+func generateGo(generator *jsonstruct.Generator) (string, error) {
 	bytes, err := generator.Generate()
 	if err != nil {
 		return "", err
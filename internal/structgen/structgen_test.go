@@ -0,0 +1,129 @@
+package structgen
+
+import (
+	"strings"
+	"testing"
+)
+
+const samples = `{"id":1,"firstName":"Ada","type":"admin","address":{"zip":null},"items":[{"sku":"a","qty":1},{"sku":"b"}]}
+{"id":2,"firstName":"Bob","type":"user","address":{"zip":"10115"},"items":[],"active":true}`
+
+func TestJSONToStruct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lang Language
+		want []string
+	}{
+		{LanguageGo, []string{"type Root struct", "FirstName string", "Zip *string"}},
+		{LanguageTypeScript, []string{
+			"export interface Root {",
+			"  firstName: string;",
+			"  active?: boolean;",
+			"  zip: string | null;",
+			"  items: Item[];",
+			"export interface Item {\n  sku: string;\n  qty?: number;\n}",
+		}},
+		{LanguageZod, []string{
+			`import { z } from "zod";`,
+			"  qty: z.number().int().optional(),",
+			"  zip: z.string().nullable(),",
+			"export type Root = z.infer<typeof RootSchema>;",
+		}},
+		{LanguageRust, []string{
+			"pub struct Root {",
+			"    #[serde(rename = \"firstName\")]\n    pub first_name: String,",
+			"    pub r#type: String,",
+			"    pub zip: Option<String>,",
+			"    #[serde(default, skip_serializing_if = \"Option::is_none\")]\n    pub active: Option<bool>,",
+		}},
+		{LanguagePython, []string{
+			"@dataclass\nclass Root:",
+			"    first_name: str  # \"firstName\"",
+			"    items: list[Item]\n    active: bool | None = None",
+		}},
+		{LanguagePydantic, []string{
+			"from pydantic import BaseModel, Field",
+			"class Root(BaseModel):",
+			"    first_name: str = Field(alias=\"firstName\")",
+		}},
+		{LanguageKotlin, []string{
+			"@Serializable\ndata class Root(",
+			"    val firstName: String,",
+			"    val active: Boolean? = null,",
+			"    val zip: String?,",
+		}},
+		{LanguageJSONSchema, []string{`"$schema": "https://json-schema.org/draft/2020-12/schema"`, `"required": [`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := JSONToStruct(strings.NewReader(samples), tt.lang)
+			if err != nil {
+				t.Fatalf("JSONToStruct() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestYAMLToStructMultipleDocuments(t *testing.T) {
+	t.Parallel()
+
+	input := "name: api\nport: 8080\n---\nname: web\nreplicas: 2\n"
+	got, err := YAMLToStruct(strings.NewReader(input), LanguageTypeScript)
+	if err != nil {
+		t.Fatalf("YAMLToStruct() error = %v", err)
+	}
+	want := "export interface Root {\n  name: string;\n  port?: number;\n  replicas?: number;\n}"
+	if got != want {
+		t.Errorf("YAMLToStruct() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNonObjectRoot(t *testing.T) {
+	t.Parallel()
+
+	got, err := JSONToStruct(strings.NewReader(`[{"a":1},{"a":null}]`), LanguageTypeScript)
+	if err != nil {
+		t.Fatalf("JSONToStruct() error = %v", err)
+	}
+	if !strings.Contains(got, "export type Root = RootItem[];") {
+		t.Errorf("output missing root alias:\n%s", got)
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    Language
+		wantErr bool
+	}{
+		{"go", LanguageGo, false},
+		{"TS", LanguageTypeScript, false},
+		{"rs", LanguageRust, false},
+		{"py", LanguagePython, false},
+		{"kt", LanguageKotlin, false},
+		{"pydantic", LanguagePydantic, false},
+		{"cobol", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLanguage(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLanguage(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLanguage(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package structgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var jsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsKey renders an object key, quoting it when it is not an identifier.
+func jsKey(key string) string {
+	if jsIdentifier.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+func renderTypeScript(b *bytes.Buffer, m *model) {
+	for _, s := range m.types {
+		fmt.Fprintf(b, "export interface %s {\n", s.name)
+		for _, f := range s.fields {
+			optional := ""
+			if f.optional(s) {
+				optional = "?"
+			}
+			fmt.Fprintf(b, "  %s%s: %s;\n", jsKey(f.key), optional, tsType(f.shape))
+		}
+		b.WriteString("}\n\n")
+	}
+	if m.root.kind() != kindObject {
+		fmt.Fprintf(b, "export type Root = %s;\n", tsType(m.root))
+	}
+}
+
+func tsType(s *shape) string {
+	var t string
+	switch s.kind() {
+	case kindUnknown:
+		if s.null {
+			return "null"
+		}
+		return "unknown"
+	case kindBool:
+		t = "boolean"
+	case kindInt, kindFloat:
+		t = "number"
+	case kindString:
+		t = "string"
+	case kindArray:
+		elem := tsType(s.elem)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		t = elem + "[]"
+	case kindObject:
+		t = s.name
+	default:
+		return "unknown"
+	}
+	if s.nullable() {
+		t += " | null"
+	}
+	return t
+}
+
+func renderZod(b *bytes.Buffer, m *model) {
+	b.WriteString("import { z } from \"zod\";\n\n")
+	for _, s := range m.types {
+		fmt.Fprintf(b, "export const %sSchema = z.object({\n", s.name)
+		for _, f := range s.fields {
+			t := zodType(f.shape)
+			if f.optional(s) {
+				t += ".optional()"
+			}
+			fmt.Fprintf(b, "  %s: %s,\n", jsKey(f.key), t)
+		}
+		b.WriteString("});\n")
+		fmt.Fprintf(b, "export type %s = z.infer<typeof %sSchema>;\n\n", s.name, s.name)
+	}
+	if m.root.kind() != kindObject {
+		fmt.Fprintf(b, "export const RootSchema = %s;\n", zodType(m.root))
+		b.WriteString("export type Root = z.infer<typeof RootSchema>;\n")
+	}
+}
+
+func zodType(s *shape) string {
+	var t string
+	switch s.kind() {
+	case kindUnknown:
+		if s.null {
+			return "z.null()"
+		}
+		return "z.unknown()"
+	case kindBool:
+		t = "z.boolean()"
+	case kindInt:
+		t = "z.number().int()"
+	case kindFloat:
+		t = "z.number()"
+	case kindString:
+		t = "z.string()"
+	case kindArray:
+		t = "z.array(" + zodType(s.elem) + ")"
+	case kindObject:
+		t = s.name + "Schema"
+	default:
+		return "z.unknown()"
+	}
+	if s.nullable() {
+		t += ".nullable()"
+	}
+	return t
+}
@@ -0,0 +1,378 @@
+package timestamp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unit describes the resolution of a numeric epoch value.
+type Unit string
+
+const (
+	UnitAuto         Unit = "auto"
+	UnitSeconds      Unit = "s"
+	UnitMilliseconds Unit = "ms"
+	UnitMicroseconds Unit = "us"
+	UnitNanoseconds  Unit = "ns"
+)
+
+// Zone is an instant rendered in a named IANA time zone.
+type Zone struct {
+	Name string `json:"name"`
+	Time string `json:"time"`
+}
+
+// Result contains every representation of a parsed instant.
+type Result struct {
+	Input     string `json:"input"`
+	Format    string `json:"format"`
+	Unix      int64  `json:"unix"`
+	UnixMilli int64  `json:"unix_milli"`
+	UnixMicro int64  `json:"unix_micro"`
+	UnixNano  string `json:"unix_nano"`
+	UTC       string `json:"utc"`
+	Local     string `json:"local"`
+	Zones     []Zone `json:"zones"`
+	Relative  string `json:"relative"`
+}
+
+// DisplayLayout is used for human-readable times in results.
+const DisplayLayout = "2006-01-02 15:04:05.000 MST (Mon)"
+
+// DefaultZones lists the zones shown when none are requested.
+var DefaultZones = []string{
+	"America/Los_Angeles",
+	"America/New_York",
+	"Europe/London",
+	"Europe/Berlin",
+	"Asia/Kolkata",
+	"Asia/Tokyo",
+	"Australia/Sydney",
+}
+
+type layout struct {
+	name   string
+	layout string
+}
+
+// layouts are tried in order; the first that parses wins. Layouts without a
+// zone are interpreted in the local time zone.
+var layouts = []layout{
+	{"RFC3339", time.RFC3339Nano},
+	{"ISO-8601", "2006-01-02T15:04:05.999999999Z0700"},
+	{"ISO-8601", "2006-01-02T15:04:05.999999999"},
+	{"ISO-8601", "2006-01-02T15:04Z07:00"},
+	{"ISO-8601", "2006-01-02T15:04"},
+	{"ISO-8601", "20060102T150405Z0700"},
+	{"ISO-8601", "20060102T150405Z"},
+	{"ISO-8601", "20060102T150405"},
+	{"ISO-8601", "2006-01-02 15:04:05.999999999Z07:00"},
+	{"ISO-8601", "2006-01-02 15:04:05.999999999 Z0700"},
+	{"ISO-8601", "2006-01-02 15:04:05.999999999 MST"},
+	{"ISO-8601", "2006-01-02 15:04:05.999999999"},
+	{"ISO-8601", "2006-01-02 15:04"},
+	{"ISO-8601", "2006-01-02"},
+	{"RFC1123", time.RFC1123},
+	{"RFC1123Z", time.RFC1123Z},
+	{"RFC850", time.RFC850},
+	{"RFC822", time.RFC822},
+	{"RFC822Z", time.RFC822Z},
+	{"ANSIC", time.ANSIC},
+	{"UnixDate", time.UnixDate},
+	{"RubyDate", time.RubyDate},
+	{"Human", "January 2, 2006 3:04:05 PM"},
+	{"Human", "January 2, 2006 3:04 PM"},
+	{"Human", "January 2, 2006 15:04:05"},
+	{"Human", "January 2, 2006 15:04"},
+	{"Human", "January 2, 2006"},
+	{"Human", "Jan 2, 2006 3:04:05 PM"},
+	{"Human", "Jan 2, 2006 3:04 PM"},
+	{"Human", "Jan 2, 2006 15:04:05"},
+	{"Human", "Jan 2, 2006 15:04"},
+	{"Human", "Jan 2, 2006"},
+	{"Human", "Jan 2 2006 15:04:05"},
+	{"Human", "Jan 2 2006"},
+	{"Human", "2 January 2006 15:04:05"},
+	{"Human", "2 January 2006 15:04"},
+	{"Human", "2 January 2006"},
+	{"Human", "2 Jan 2006 15:04:05"},
+	{"Human", "2 Jan 2006 15:04"},
+	{"Human", "2 Jan 2006"},
+	{"Human", "Monday, January 2, 2006"},
+	{"Human", "Mon, Jan 2, 2006"},
+	{"Human", "2006/01/02 15:04:05"},
+	{"Human", "2006/01/02"},
+	{"Human", "01/02/2006 15:04:05"},
+	{"Human", "01/02/2006"},
+}
+
+// ParseUnit validates a unit name.
+func ParseUnit(name string) (Unit, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return UnitAuto, nil
+	case "s", "sec", "secs", "second", "seconds":
+		return UnitSeconds, nil
+	case "ms", "milli", "millis", "millisecond", "milliseconds":
+		return UnitMilliseconds, nil
+	case "us", "µs", "micro", "micros", "microsecond", "microseconds":
+		return UnitMicroseconds, nil
+	case "ns", "nano", "nanos", "nanosecond", "nanoseconds":
+		return UnitNanoseconds, nil
+	default:
+		return "", fmt.Errorf("unsupported unit: %s (supported: auto, s, ms, us, ns)", name)
+	}
+}
+
+// DetectUnit guesses the resolution of an integer epoch value from its
+// magnitude. Seconds cover dates up to the year 5138; anything larger is
+// assumed to be a finer resolution.
+func DetectUnit(value int64) Unit {
+	digits := len(strconv.FormatUint(absInt64(value), 10))
+	switch {
+	case digits <= 11:
+		return UnitSeconds
+	case digits <= 14:
+		return UnitMilliseconds
+	case digits <= 17:
+		return UnitMicroseconds
+	default:
+		return UnitNanoseconds
+	}
+}
+
+// Parse parses an epoch value or a formatted date. unit only applies to
+// numeric input; UnitAuto detects it from the value's magnitude. The returned
+// string names the detected unit or format.
+func Parse(input string, unit Unit, now time.Time) (time.Time, string, error) {
+	value := strings.TrimSpace(input)
+	if value == "" {
+		return time.Time{}, "", errors.New("empty timestamp")
+	}
+
+	switch strings.ToLower(value) {
+	case "now":
+		return now, "now", nil
+	case "today":
+		return startOfDay(now), "today", nil
+	case "yesterday":
+		return startOfDay(now).AddDate(0, 0, -1), "yesterday", nil
+	case "tomorrow":
+		return startOfDay(now).AddDate(0, 0, 1), "tomorrow", nil
+	}
+
+	if isNumeric(value) {
+		return parseEpoch(value, unit)
+	}
+
+	for _, l := range layouts {
+		t, err := time.ParseInLocation(l.layout, value, now.Location())
+		if err == nil {
+			return t, l.name, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("unrecognized timestamp: %q", value)
+}
+
+// Convert parses the input and renders it in UTC, local time and each zone.
+func Convert(input string, unit Unit, zones []string, now time.Time) (Result, error) {
+	t, format, err := Parse(input, unit, now)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Describe(t, strings.TrimSpace(input), format, zones, now)
+}
+
+// Describe renders an instant in UTC, local time and each named zone.
+func Describe(t time.Time, input, format string, zones []string, now time.Time) (Result, error) {
+	result := Result{
+		Input:     input,
+		Format:    format,
+		Unix:      t.Unix(),
+		UnixMilli: t.UnixMilli(),
+		UnixMicro: t.UnixMicro(),
+		UnixNano:  unixNano(t),
+		UTC:       t.UTC().Format(DisplayLayout),
+		Local:     t.In(now.Location()).Format(DisplayLayout),
+		Relative:  Relative(t, now),
+	}
+
+	result.Zones = make([]Zone, 0, len(zones))
+	for _, name := range zones {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return Result{}, fmt.Errorf("unknown time zone: %s", name)
+		}
+		result.Zones = append(result.Zones, Zone{Name: name, Time: t.In(loc).Format(DisplayLayout)})
+	}
+
+	return result, nil
+}
+
+// ResultToRows converts a result into label/value table rows.
+func ResultToRows(result Result) [][]string {
+	rows := [][]string{
+		{"Input", result.Input},
+		{"Detected format", result.Format},
+		{"Unix seconds", strconv.FormatInt(result.Unix, 10)},
+		{"Unix milliseconds", strconv.FormatInt(result.UnixMilli, 10)},
+		{"Unix microseconds", strconv.FormatInt(result.UnixMicro, 10)},
+		{"Unix nanoseconds", result.UnixNano},
+		{"UTC", result.UTC},
+		{"Local", result.Local},
+	}
+	for _, zone := range result.Zones {
+		rows = append(rows, []string{zone.Name, zone.Time})
+	}
+	rows = append(rows, []string{"Relative", result.Relative})
+	return rows
+}
+
+// Relative describes t relative to now, e.g. "3 days ago" or "in 2 hours".
+func Relative(t, now time.Time) string {
+	diff := t.Sub(now)
+	future := diff > 0
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if diff < time.Second {
+		return "just now"
+	}
+
+	var amount int64
+	var unit string
+	switch {
+	case diff < time.Minute:
+		amount, unit = int64(diff/time.Second), "second"
+	case diff < time.Hour:
+		amount, unit = int64(diff/time.Minute), "minute"
+	case diff < 24*time.Hour:
+		amount, unit = int64(diff/time.Hour), "hour"
+	case diff < 30*24*time.Hour:
+		amount, unit = int64(diff/(24*time.Hour)), "day"
+	case diff < 365*24*time.Hour:
+		amount, unit = int64(diff/(30*24*time.Hour)), "month"
+	default:
+		amount, unit = int64(diff/(365*24*time.Hour)), "year"
+	}
+
+	if amount != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", amount, unit)
+	}
+	return fmt.Sprintf("%d %s ago", amount, unit)
+}
+
+func parseEpoch(value string, unit Unit) (time.Time, string, error) {
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	seconds, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid epoch value: %q", value)
+	}
+
+	if unit == UnitAuto {
+		unit = DetectUnit(seconds)
+	}
+
+	var t time.Time
+	switch unit {
+	case UnitSeconds:
+		t = time.Unix(seconds, 0)
+	case UnitMilliseconds:
+		t = time.UnixMilli(seconds)
+	case UnitMicroseconds:
+		t = time.UnixMicro(seconds)
+	case UnitNanoseconds:
+		t = time.Unix(0, seconds)
+	default:
+		return time.Time{}, "", fmt.Errorf("unsupported unit: %s", unit)
+	}
+
+	if hasFraction {
+		frac, err := strconv.ParseFloat("0."+fraction, 64)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("invalid epoch value: %q", value)
+		}
+		offset := time.Duration(math.Round(frac * float64(unitDuration(unit))))
+		if strings.HasPrefix(whole, "-") {
+			offset = -offset
+		}
+		t = t.Add(offset)
+	}
+
+	return t, unitName(unit), nil
+}
+
+func unitDuration(unit Unit) time.Duration {
+	switch unit {
+	case UnitMilliseconds:
+		return time.Millisecond
+	case UnitMicroseconds:
+		return time.Microsecond
+	case UnitNanoseconds:
+		return time.Nanosecond
+	default:
+		return time.Second
+	}
+}
+
+func unitName(unit Unit) string {
+	switch unit {
+	case UnitMilliseconds:
+		return "epoch milliseconds"
+	case UnitMicroseconds:
+		return "epoch microseconds"
+	case UnitNanoseconds:
+		return "epoch nanoseconds"
+	default:
+		return "epoch seconds"
+	}
+}
+
+// unixNano is returned as a string because nanosecond values outside of
+// 1678-2262 overflow int64.
+func unixNano(t time.Time) string {
+	n := big.NewInt(t.Unix())
+	n.Mul(n, big.NewInt(int64(time.Second)))
+	n.Add(n, big.NewInt(int64(t.Nanosecond())))
+	return n.String()
+}
+
+func isNumeric(value string) bool {
+	value = strings.TrimPrefix(value, "-")
+	if value == "" {
+		return false
+	}
+	dot := false
+	for _, r := range value {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r < '0' || r > '9':
+			return false
+		}
+	}
+	return true
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func absInt64(value int64) uint64 {
+	if value < 0 {
+		return uint64(-(value + 1)) + 1
+	}
+	return uint64(value)
+}
@@ -0,0 +1,155 @@
+package timestamp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDetectsEpochUnit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+
+	tests := []struct {
+		input  string
+		format string
+	}{
+		{"1700000000", "epoch seconds"},
+		{"1700000000000", "epoch milliseconds"},
+		{"1700000000000000", "epoch microseconds"},
+		{"1700000000000000000", "epoch nanoseconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, format, err := Parse(tt.input, UnitAuto, now)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, want)
+			}
+			if format != tt.format {
+				t.Errorf("Parse(%q) format = %q, want %q", tt.input, format, tt.format)
+			}
+		})
+	}
+}
+
+func TestParseExplicitUnitAndFraction(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got, _, err := Parse("1700000000", UnitMilliseconds, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Unix() != 1700000 {
+		t.Errorf("expected 1700000 seconds, got %d", got.Unix())
+	}
+
+	got, _, err = Parse("1700000000.5", UnitAuto, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UnixMilli() != 1700000000500 {
+		t.Errorf("expected fractional seconds to be kept, got %d ms", got.UnixMilli())
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input  string
+		want   time.Time
+		format string
+	}{
+		{"2024-02-29T12:00:00Z", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), "RFC3339"},
+		{"2024-02-29T12:00:00+02:00", time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), "RFC3339"},
+		{"2024-02-29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), "ISO-8601"},
+		{"20240229T120000Z", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), "ISO-8601"},
+		{"Thu, 29 Feb 2024 12:00:00 UTC", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), "RFC1123"},
+		{"February 29, 2024", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), "Human"},
+		{"29 Feb 2024 12:00", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), "Human"},
+		{"yesterday", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), "yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, format, err := Parse(tt.input, UnitAuto, now)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if format != tt.format {
+				t.Errorf("Parse(%q) format = %q, want %q", tt.input, format, tt.format)
+			}
+		})
+	}
+}
+
+func TestParseRejectsGarbage(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := Parse("not a date", UnitAuto, time.Now()); err == nil {
+		t.Fatal("expected error for unparseable input")
+	}
+}
+
+func TestRelative(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now, "just now"},
+		{now.Add(-3 * 24 * time.Hour), "3 days ago"},
+		{now.Add(2 * time.Hour), "in 2 hours"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.AddDate(-2, 0, 0), "2 years ago"},
+	}
+
+	for _, tt := range tests {
+		if got := Relative(tt.t, now); got != tt.want {
+			t.Errorf("Relative(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestConvertZones(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := Convert("1700000000", UnitAuto, []string{"Asia/Tokyo"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.UTC != "2023-11-14 22:13:20.000 UTC (Tue)" {
+		t.Errorf("unexpected UTC rendering: %s", result.UTC)
+	}
+	if len(result.Zones) != 1 || result.Zones[0].Time != "2023-11-15 07:13:20.000 JST (Wed)" {
+		t.Errorf("unexpected zone rendering: %+v", result.Zones)
+	}
+	if result.UnixNano != "1700000000000000000" {
+		t.Errorf("unexpected nanoseconds: %s", result.UnixNano)
+	}
+
+	if _, err := Convert("1700000000", UnitAuto, []string{"Mars/Olympus_Mons"}, now); err == nil {
+		t.Error("expected error for unknown zone")
+	}
+}
@@ -4,6 +4,7 @@ package ui
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/muesli/ansi"
 	"github.com/muesli/reflow/truncate"
 	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/history"
 )
 
 // BasePagerModel provides common functionality for pager-based TUI views. There is a lot of common
@@ -28,6 +30,10 @@ type BasePagerModel struct {
 	StatusMessage      string
 	StatusMessageTimer *time.Timer
 	HelpHeight         int
+
+	// History keeps the inputs given to the tool; nil keeps none.
+	History *history.Log
+	history *historyBrowser
 }
 
 func NewBasePagerModel(common *CommonModel, title string) BasePagerModel {
@@ -46,11 +52,24 @@ func (m BasePagerModel) Init() tea.Cmd {
 	return nil
 }
 
+// SetHistory sets the log the tool keeps its inputs in.
+func (m *BasePagerModel) SetHistory(log *history.Log) {
+	m.History = log
+}
+
 func (m *BasePagerModel) HandleCommonKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if m.history != nil {
+		return m.handleHistoryKeys(msg), true
+	}
+
 	switch msg.String() {
 	case "ctrl+c", "q":
+		// History is best effort; failing to save it mustn't keep the
+		// tool from closing.
+		_ = m.SaveHistory()
 		return tea.Quit, true
 	case "esc":
+		_ = m.SaveHistory()
 		return func() tea.Msg {
 			return ReturnToListMsg{
 				Common: m.Common,
@@ -59,12 +78,26 @@ func (m *BasePagerModel) HandleCommonKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
 	case "?":
 		m.ToggleHelp()
 		return nil, true
+	case "H":
+		return m.openHistory(), true
 	case "c":
 		err := clipboard.Copy(m.FormattedContent)
 		if err != nil {
 			return m.ShowErrorMessage(err.Error()), true
 		}
 		return m.ShowStatusMessage("Copied."), true
+	case "|":
+		if m.FormattedContent == "" {
+			return m.ShowErrorMessage("Nothing to pipe yet."), true
+		}
+		_ = m.SaveHistory()
+		return func() tea.Msg {
+			return PipeMsg{
+				Common:  m.Common,
+				From:    m.Title,
+				Content: m.FormattedContent,
+			}
+		}, true
 	}
 	return nil, false
 }
@@ -156,7 +189,7 @@ func (m *BasePagerModel) StatusBarView() string {
 	)
 	showStatusMessage := m.State == PagerStateStatusMessage
 	showErrorMessage := m.State == PagerStateErrorMessage
-	appName := AppNameStyle(" " + m.Title + " ")
+	appName := AppNameStyle(" " + m.Breadcrumb() + " ")
 
 	// Scroll percent
 	scrollPercent := ""
@@ -178,8 +211,12 @@ func (m *BasePagerModel) StatusBarView() string {
 	var note string
 	if showStatusMessage || showErrorMessage {
 		note = m.StatusMessage
+	} else if m.history != nil {
+		note = m.historyNote()
 	} else if m.Content == "" {
 		note = "Press 'v' to paste"
+	} else if m.FormattedContent != "" {
+		note = "Press '|' to pipe to another tool"
 	}
 
 	note = truncate.StringWithTail(" "+note+" ", uint(max(0,
@@ -225,6 +262,12 @@ func (m *BasePagerModel) StatusBarView() string {
 	return b.String()
 }
 
+// Breadcrumb returns the title, preceded by the tools whose output was piped
+// into this one, such as "CSV to JSON → JSON to TOON".
+func (m *BasePagerModel) Breadcrumb() string {
+	return strings.Join(append(slices.Clone(m.Common.Chain), m.Title), " → ")
+}
+
 // FormatHelpColumns formats the help view with columns
 func (m *BasePagerModel) FormatHelpColumns(col1 []string) string {
 	s := "\n"
@@ -1,6 +1,17 @@
 package ui
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+	"github.com/skatkov/devtui/internal/config"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/history"
+)
 
 func TestFormatHelpColumnsHandlesShortColumnList(t *testing.T) {
 	t.Parallel()
@@ -25,3 +36,104 @@ func TestHelpColumnValueBounds(t *testing.T) {
 		t.Fatalf("expected out-of-range value to be empty, got %q", got)
 	}
 }
+
+func TestPipeKeySendsFormattedContent(t *testing.T) {
+	t.Parallel()
+
+	m := NewBasePagerModel(&CommonModel{Width: 80, Height: 24}, "CSV to JSON")
+	m.FormattedContent = `[{"a":"1"}]`
+
+	cmd, handled := m.HandleCommonKeys(tea.KeyPressMsg{Code: '|', Text: "|"})
+	if !handled || cmd == nil {
+		t.Fatal("expected '|' to be handled")
+	}
+	msg, ok := cmd().(PipeMsg)
+	if !ok {
+		t.Fatalf("expected PipeMsg, got %T", cmd())
+	}
+	if msg.From != "CSV to JSON" || msg.Content != m.FormattedContent {
+		t.Fatalf("unexpected pipe message: %+v", msg)
+	}
+}
+
+func TestBreadcrumb(t *testing.T) {
+	t.Parallel()
+
+	m := BasePagerModel{Common: &CommonModel{}, Title: "JSON to TOON"}
+	if got := m.Breadcrumb(); got != "JSON to TOON" {
+		t.Fatalf("expected plain title, got %q", got)
+	}
+
+	m.Common.Chain = []string{"CSV to JSON"}
+	if got, want := m.Breadcrumb(), "CSV to JSON → JSON to TOON"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestHistoryBrowserRestoresEntry(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", filepath.Join(t.TempDir(), "data"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	cfg, err := config.LoadFiles(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := history.ForTool(cfg, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Add(history.Entry{Input: `{"old":true}`}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewBasePagerModel(&CommonModel{Width: 80, Height: 24}, "JSON")
+	m.SetHistory(log)
+	m.Content = `{"new":true}`
+	m.Viewport.SetContent("output")
+
+	if _, handled := m.HandleCommonKeys(tea.KeyPressMsg{Code: 'H', Text: "H"}); !handled || m.history == nil {
+		t.Fatal("expected 'H' to open the history browser")
+	}
+	if got := len(m.history.entries); got != 2 {
+		t.Fatalf("expected the current input to be saved, got %d entries", got)
+	}
+
+	m.HandleCommonKeys(tea.KeyPressMsg{Code: tea.KeyDown})
+	cmd, _ := m.HandleCommonKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.history != nil || m.Viewport.GetContent() != "output" {
+		t.Fatal("expected enter to close the browser and give back the output")
+	}
+	msg, ok := cmd().(editor.EditorFinishedMsg)
+	if !ok || msg.Content != `{"old":true}` {
+		t.Fatalf("expected the older input to be restored, got %#v", cmd())
+	}
+}
+
+func TestHistoryOff(t *testing.T) {
+	t.Parallel()
+
+	m := NewBasePagerModel(&CommonModel{Width: 80, Height: 24}, "JSON")
+	m.Content = "input"
+	m.HandleCommonKeys(tea.KeyPressMsg{Code: 'H', Text: "H"})
+	if m.history != nil || m.State != PagerStateErrorMessage {
+		t.Fatal("expected an error when the tool keeps no history")
+	}
+}
+
+func TestCommonKeysLeaveViewportKeys(t *testing.T) {
+	t.Parallel()
+
+	m := NewBasePagerModel(&CommonModel{Width: 80, Height: 24}, "JSON")
+	keys := viewport.DefaultKeyMap()
+	for _, binding := range []key.Binding{keys.Left, keys.Right, keys.Up, keys.Down} {
+		for _, k := range binding.Keys() {
+			if len(k) != 1 {
+				continue
+			}
+			if _, handled := m.HandleCommonKeys(tea.KeyPressMsg{Code: rune(k[0]), Text: k}); handled {
+				t.Errorf("%q is handled by the pager instead of scrolling", k)
+			}
+		}
+	}
+}
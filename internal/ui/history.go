@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/muesli/reflow/truncate"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/history"
+)
+
+// historyBrowser lists the history of a pager tool in place of its output.
+type historyBrowser struct {
+	entries []history.Entry
+	cursor  int
+
+	// The viewport is borrowed to show the list; its content and position
+	// are given back when the browser closes.
+	content string
+	yOffset int
+}
+
+// SaveHistory records the current input and output in the tool's history.
+func (m *BasePagerModel) SaveHistory() error {
+	if strings.TrimSpace(m.Content) == "" {
+		return nil
+	}
+	return m.History.Add(history.Entry{
+		Time:   time.Now(),
+		Input:  m.Content,
+		Output: m.FormattedContent,
+	})
+}
+
+// openHistory shows the history browser.
+func (m *BasePagerModel) openHistory() tea.Cmd {
+	if m.History == nil {
+		return m.ShowErrorMessage("History is off for this tool.")
+	}
+	// The current input is the newest entry, so that it can be picked again
+	// after looking at older ones.
+	if err := m.SaveHistory(); err != nil {
+		return m.ShowErrorMessage(err.Error())
+	}
+	entries, err := m.History.Entries()
+	if err != nil {
+		return m.ShowErrorMessage(err.Error())
+	}
+	if len(entries) == 0 {
+		return m.ShowErrorMessage("No history yet.")
+	}
+
+	m.history = &historyBrowser{
+		entries: entries,
+		content: m.Viewport.GetContent(),
+		yOffset: m.Viewport.YOffset(),
+	}
+	m.State = PagerStateBrowse
+	m.renderHistory()
+	return nil
+}
+
+// closeHistory gives the viewport back to the tool.
+func (m *BasePagerModel) closeHistory() {
+	m.Viewport.SetContent(m.history.content)
+	m.Viewport.SetYOffset(m.history.yOffset)
+	m.history = nil
+}
+
+// handleHistoryKeys handles all keys while the history browser is open.
+func (m *BasePagerModel) handleHistoryKeys(msg tea.KeyPressMsg) tea.Cmd {
+	b := m.history
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "esc", "H":
+		m.closeHistory()
+	case "k", "up":
+		b.cursor = max(b.cursor-1, 0)
+	case "j", "down":
+		b.cursor = min(b.cursor+1, len(b.entries)-1)
+	case "g", "home":
+		b.cursor = 0
+	case "G", "end":
+		b.cursor = len(b.entries) - 1
+	case "d", "x", "delete":
+		if err := m.History.Remove(b.cursor); err != nil {
+			return m.ShowErrorMessage(err.Error())
+		}
+		b.entries = append(b.entries[:b.cursor], b.entries[b.cursor+1:]...)
+		if len(b.entries) == 0 {
+			m.closeHistory()
+			return m.ShowStatusMessage("History cleared.")
+		}
+		b.cursor = min(b.cursor, len(b.entries)-1)
+	case "enter":
+		input := b.entries[b.cursor].Input
+		m.closeHistory()
+		// Every pager tool takes edited input through EditorFinishedMsg,
+		// which is exactly what restoring an entry needs.
+		return func() tea.Msg {
+			return editor.EditorFinishedMsg{Content: input}
+		}
+	}
+	if m.history != nil {
+		m.renderHistory()
+	}
+	return nil
+}
+
+// renderHistory shows the entries in the viewport, one line each, keeping
+// the selected entry visible.
+func (m *BasePagerModel) renderHistory() {
+	b := m.history
+	width := max(m.Common.Width, 20)
+
+	lines := make([]string, len(b.entries))
+	for i, entry := range b.entries {
+		cursor := "  "
+		if i == b.cursor {
+			cursor = "> "
+		}
+		when := entry.Time.Local().Format(time.DateTime)
+		input := strings.Join(strings.Fields(entry.Input), " ")
+		line := fmt.Sprintf("%s%s  %s", cursor, when, input)
+		line = truncate.StringWithTail(line, uint(width), Ellipsis)
+		if i == b.cursor {
+			line = HistorySelectedStyle(line)
+		} else {
+			line = strings.Replace(line, when, LineNumberStyle(when), 1)
+		}
+		lines[i] = line
+	}
+
+	m.Viewport.SetContent(strings.Join(lines, "\n"))
+	m.Viewport.EnsureVisible(b.cursor, 0, 0)
+}
+
+// historyNote is the status bar note while the history browser is open.
+func (m *BasePagerModel) historyNote() string {
+	name := "History"
+	if m.History.Encrypted() {
+		name = "Encrypted history"
+	}
+	return fmt.Sprintf("%s %d/%d: enter restore · d delete · esc close", name, m.history.cursor+1, len(m.history.entries))
+}
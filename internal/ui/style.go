@@ -55,6 +55,11 @@ var (
 	LineNumberStyle = lipgloss.NewStyle().
 			Foreground(lineNumberFg).
 			Render
+
+	HistorySelectedStyle = lipgloss.NewStyle().
+				Foreground(green).
+				Bold(true).
+				Render
 )
 
 // Use huh/examples/dynamic-bubbletea as an example how to style an application with a huh form in it.
@@ -7,6 +7,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/skatkov/devtui/internal/config"
 )
 
 const AppTitle = "DevTUI"
@@ -15,6 +16,14 @@ type ReturnToListMsg struct {
 	Common *CommonModel
 }
 
+// PipeMsg asks the root screen to pick another tool and open it with
+// Content, the output of the tool titled From.
+type PipeMsg struct {
+	Common  *CommonModel
+	From    string
+	Content string
+}
+
 type CommonModel struct {
 	Width  int
 	Height int
@@ -22,6 +31,13 @@ type CommonModel struct {
 	LastSelectedItem int
 
 	Styles *Styles
+
+	// Config provides defaults for tool settings; it may be nil.
+	Config *config.Config
+
+	// Chain holds the titles of the tools whose output was piped into the
+	// current one, oldest first.
+	Chain []string
 }
 
 var PagePaddingStyle = lipgloss.NewStyle().Padding(2)
@@ -0,0 +1,388 @@
+package uuidutil
+
+import (
+	"crypto/rand"
+	"crypto/sha3"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of identifier a Generator produces.
+const (
+	KindUUID      = "uuid"
+	KindULID      = "ulid"
+	KindKSUID     = "ksuid"
+	KindNanoID    = "nanoid"
+	KindCUID2     = "cuid2"
+	KindSnowflake = "snowflake"
+)
+
+var Kinds = []string{KindUUID, KindULID, KindKSUID, KindNanoID, KindCUID2, KindSnowflake}
+
+// Styles change how a UUID is written; upper and no-hyphen combine with
+// either braces or urn.
+var Styles = []string{"upper", "no-hyphen", "braces", "urn"}
+
+// Output formats of FormatIDs.
+var Formats = []string{"plain", "json", "csv", "sql"}
+
+const (
+	// NanoIDAlphabet is the URL-safe alphabet of NanoID.
+	NanoIDAlphabet    = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	DefaultNanoIDSize = 21
+	DefaultCUID2Size  = 24
+	// SnowflakeEpoch is the Twitter epoch, 2010-11-04 01:42:54.657 UTC, in
+	// Unix milliseconds.
+	SnowflakeEpoch   = 1288834974657
+	MaxSnowflakeNode = 1<<10 - 1
+
+	crockford     = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62        = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidEpoch    = 1400000000
+	maxCUID2Size  = 32
+	snowflakeSeqs = 1 << 12
+)
+
+// Options configure a Generator.
+type Options struct {
+	Kind string
+	// Version and Namespace select the UUID; Styles change how it is
+	// written.
+	Version   int
+	Namespace string
+	Styles    []string
+	// Alphabet applies to NanoIDs, Length to NanoIDs and CUID2s. Zero
+	// values select the defaults.
+	Alphabet string
+	Length   int
+	// Node is the worker ID of Snowflake IDs, 0-1023.
+	Node int64
+}
+
+// Generator produces identifiers of one kind. It keeps the state that
+// orders the ULIDs and Snowflake IDs it generates within one millisecond.
+type Generator struct {
+	opts Options
+	now  func() time.Time
+
+	// lastMillis and lastULID make ULIDs of the same millisecond monotonic.
+	lastMillis int64
+	lastULID   [16]byte
+	// sequence numbers Snowflake IDs of the same millisecond.
+	sequence int64
+	// counter and fingerprint are mixed into every CUID2.
+	counter     int64
+	fingerprint string
+}
+
+// NewGenerator checks opts and returns a Generator for them.
+func NewGenerator(opts Options) (*Generator, error) {
+	if opts.Kind == "" {
+		opts.Kind = KindUUID
+	}
+	if !slices.Contains(Kinds, opts.Kind) {
+		return nil, fmt.Errorf("unsupported id kind %q (want one of %s)", opts.Kind, strings.Join(Kinds, ", "))
+	}
+	if err := checkStyles(opts.Styles); err != nil {
+		return nil, err
+	}
+	if len(opts.Styles) > 0 && opts.Kind != KindUUID {
+		return nil, errors.New("styles apply to UUIDs only")
+	}
+	if opts.Namespace != "" && opts.Kind != KindUUID {
+		return nil, errors.New("namespaces apply to UUIDs only")
+	}
+
+	g := &Generator{opts: opts, now: time.Now}
+	switch opts.Kind {
+	case KindNanoID:
+		if g.opts.Alphabet == "" {
+			g.opts.Alphabet = NanoIDAlphabet
+		}
+		if g.opts.Length == 0 {
+			g.opts.Length = DefaultNanoIDSize
+		}
+		chars := []rune(g.opts.Alphabet)
+		if len(chars) < 2 || len(chars) > 256 {
+			return nil, errors.New("nanoid alphabet must have 2 to 256 characters")
+		}
+		slices.Sort(chars)
+		if len(slices.Compact(chars)) != len([]rune(g.opts.Alphabet)) {
+			return nil, errors.New("nanoid alphabet must not repeat characters")
+		}
+		if g.opts.Length < 1 {
+			return nil, errors.New("nanoid length must be at least 1")
+		}
+	case KindCUID2:
+		if g.opts.Length == 0 {
+			g.opts.Length = DefaultCUID2Size
+		}
+		if g.opts.Length < 2 || g.opts.Length > maxCUID2Size {
+			return nil, fmt.Errorf("cuid2 length must be between 2 and %d", maxCUID2Size)
+		}
+		counter, err := randomInt(476782367)
+		if err != nil {
+			return nil, err
+		}
+		g.counter = counter
+		g.fingerprint, err = cuid2Fingerprint()
+		if err != nil {
+			return nil, err
+		}
+	case KindSnowflake:
+		if opts.Node < 0 || opts.Node > MaxSnowflakeNode {
+			return nil, fmt.Errorf("snowflake node must be between 0 and %d", MaxSnowflakeNode)
+		}
+	}
+	return g, nil
+}
+
+// Next returns a new identifier.
+func (g *Generator) Next() (string, error) {
+	switch g.opts.Kind {
+	case KindULID:
+		return g.ulid()
+	case KindKSUID:
+		return g.ksuid()
+	case KindNanoID:
+		return nanoID(g.opts.Alphabet, g.opts.Length)
+	case KindCUID2:
+		return g.cuid2()
+	case KindSnowflake:
+		return g.snowflake(), nil
+	}
+
+	id, err := Generate(g.opts.Version, g.opts.Namespace)
+	if err != nil {
+		return "", err
+	}
+	return styleUUID(id.String(), g.opts.Styles), nil
+}
+
+// GenerateN returns count identifiers generated with opts.
+func GenerateN(opts Options, count int) ([]string, error) {
+	if count < 1 {
+		return nil, errors.New("count must be at least 1")
+	}
+	g, err := NewGenerator(opts)
+	if err != nil {
+		return nil, err
+	}
+	if count > 1 && g.opts.Kind == KindUUID && (opts.Version == 3 || opts.Version == 5) {
+		return nil, fmt.Errorf("version %d UUIDs are derived from the namespace, so every id would be the same", opts.Version)
+	}
+	ids := make([]string, count)
+	for i := range ids {
+		if ids[i], err = g.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func checkStyles(styles []string) error {
+	for _, style := range styles {
+		if !slices.Contains(Styles, style) {
+			return fmt.Errorf("unsupported style %q (want one of %s)", style, strings.Join(Styles, ", "))
+		}
+	}
+	if slices.Contains(styles, "braces") && slices.Contains(styles, "urn") {
+		return errors.New("braces and urn styles can't be combined")
+	}
+	return nil
+}
+
+func styleUUID(id string, styles []string) string {
+	if slices.Contains(styles, "no-hyphen") {
+		id = strings.ReplaceAll(id, "-", "")
+	}
+	if slices.Contains(styles, "upper") {
+		id = strings.ToUpper(id)
+	}
+	switch {
+	case slices.Contains(styles, "braces"):
+		id = "{" + id + "}"
+	case slices.Contains(styles, "urn"):
+		id = "urn:uuid:" + id
+	}
+	return id
+}
+
+// ulid returns a ULID: 48 bits of Unix milliseconds and 80 random bits in
+// Crockford's base32. Within a millisecond, the random part is incremented
+// so ULIDs sort in the order they were generated.
+func (g *Generator) ulid() (string, error) {
+	millis := g.now().UnixMilli()
+	var id [16]byte
+	if millis == g.lastMillis {
+		id = g.lastULID
+		// Increment the 80 random bits, failing when they overflow.
+		i := 15
+		for ; i >= 6; i-- {
+			id[i]++
+			if id[i] != 0 {
+				break
+			}
+		}
+		if i < 6 {
+			return "", errors.New("ulid random part overflowed within a millisecond")
+		}
+	} else {
+		if _, err := rand.Read(id[6:]); err != nil {
+			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+		for i := range 6 {
+			id[i] = byte(millis >> (8 * (5 - i)))
+		}
+	}
+	g.lastMillis, g.lastULID = millis, id
+
+	// 128 bits make 26 characters of 5 bits, the first holding only 3.
+	value := new(big.Int).SetBytes(id[:])
+	out := make([]byte, 26)
+	mask := big.NewInt(31)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[new(big.Int).And(value, mask).Int64()]
+		value.Rsh(value, 5)
+	}
+	return string(out), nil
+}
+
+// ksuid returns a KSUID: seconds since 2014-05-13 and 128 random bits, in
+// 27 characters of base62.
+func (g *Generator) ksuid() (string, error) {
+	var id [20]byte
+	binary.BigEndian.PutUint32(id[:4], uint32(g.now().Unix()-ksuidEpoch))
+	if _, err := rand.Read(id[4:]); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	encoded := baseEncode(new(big.Int).SetBytes(id[:]), base62)
+	return strings.Repeat("0", 27-len(encoded)) + encoded, nil
+}
+
+// nanoID returns size characters of alphabet, drawn uniformly.
+func nanoID(alphabet string, size int) (string, error) {
+	chars := []rune(alphabet)
+	id := make([]rune, size)
+	for i := range id {
+		n, err := randomInt(int64(len(chars)))
+		if err != nil {
+			return "", err
+		}
+		id[i] = chars[n]
+	}
+	return string(id), nil
+}
+
+// cuid2 returns a CUID2: a random letter followed by the base36 SHA3-512
+// hash of the time, random salt, a counter and a host fingerprint.
+func (g *Generator) cuid2() (string, error) {
+	first, err := randomInt(26)
+	if err != nil {
+		return "", err
+	}
+	salt, err := nanoID("0123456789abcdefghijklmnopqrstuvwxyz", g.opts.Length)
+	if err != nil {
+		return "", err
+	}
+	g.counter++
+	input := strconv.FormatInt(g.now().UnixMilli(), 36) + salt + strconv.FormatInt(g.counter, 36) + g.fingerprint
+	return string(rune('a'+first)) + cuid2Hash(input)[:g.opts.Length-1], nil
+}
+
+// cuid2Hash hashes input to base36, dropping the first character, which is
+// biased.
+func cuid2Hash(input string) string {
+	sum := sha3.Sum512([]byte(input))
+	return baseEncode(new(big.Int).SetBytes(sum[:]), "0123456789abcdefghijklmnopqrstuvwxyz")[1:]
+}
+
+func cuid2Fingerprint() (string, error) {
+	entropy, err := nanoID("0123456789abcdefghijklmnopqrstuvwxyz", maxCUID2Size)
+	if err != nil {
+		return "", err
+	}
+	host, _ := os.Hostname()
+	return cuid2Hash(host + strconv.Itoa(os.Getpid()) + entropy)[:maxCUID2Size], nil
+}
+
+// snowflake returns a Snowflake ID: 41 bits of milliseconds since the
+// Twitter epoch, the 10 bit node and a 12 bit sequence, as a decimal.
+func (g *Generator) snowflake() string {
+	millis := g.now().UnixMilli()
+	if millis <= g.lastMillis {
+		millis = g.lastMillis
+		g.sequence++
+		if g.sequence == snowflakeSeqs {
+			// The sequence is exhausted; borrow the next millisecond.
+			millis++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMillis = millis
+	id := (millis-SnowflakeEpoch)<<22 | g.opts.Node<<12 | g.sequence
+	return strconv.FormatInt(id, 10)
+}
+
+func baseEncode(value *big.Int, alphabet string) string {
+	if value.Sign() == 0 {
+		return alphabet[:1]
+	}
+	base := big.NewInt(int64(len(alphabet)))
+	var out []byte
+	mod := new(big.Int)
+	for value.Sign() > 0 {
+		value.DivMod(value, base, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	slices.Reverse(out)
+	return string(out)
+}
+
+func randomInt(n int64) (int64, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random number: %w", err)
+	}
+	return i.Int64(), nil
+}
+
+// FormatIDs writes identifiers one per line, as a JSON array, as CSV with an
+// "id" header, or as SQL VALUES rows.
+func FormatIDs(ids []string, format string) (string, error) {
+	switch format {
+	case "", "plain":
+		return strings.Join(ids, "\n"), nil
+	case "json":
+		bytes, err := json.MarshalIndent(ids, "", "  ")
+		return string(bytes), err
+	case "csv":
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		_ = w.Write([]string{"id"})
+		for _, id := range ids {
+			_ = w.Write([]string{id})
+		}
+		w.Flush()
+		return strings.TrimSuffix(b.String(), "\n"), w.Error()
+	case "sql":
+		rows := make([]string, len(ids))
+		for i, id := range ids {
+			rows[i] = "  ('" + strings.ReplaceAll(id, "'", "''") + "')"
+		}
+		return "VALUES\n" + strings.Join(rows, ",\n") + ";", nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q (want one of %s)", format, strings.Join(Formats, ", "))
+	}
+}
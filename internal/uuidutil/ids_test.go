@@ -0,0 +1,171 @@
+package uuidutil
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerateNKinds(t *testing.T) {
+	tests := []struct {
+		opts    Options
+		pattern string
+	}{
+		{opts: Options{Kind: KindUUID, Version: 4}, pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{opts: Options{Kind: KindULID}, pattern: `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`},
+		{opts: Options{Kind: KindKSUID}, pattern: `^[0-9A-Za-z]{27}$`},
+		{opts: Options{Kind: KindNanoID}, pattern: `^[A-Za-z0-9_-]{21}$`},
+		{opts: Options{Kind: KindNanoID, Alphabet: "abc", Length: 8}, pattern: `^[abc]{8}$`},
+		{opts: Options{Kind: KindCUID2}, pattern: `^[a-z][0-9a-z]{23}$`},
+		{opts: Options{Kind: KindCUID2, Length: 10}, pattern: `^[a-z][0-9a-z]{9}$`},
+		{opts: Options{Kind: KindSnowflake, Node: 5}, pattern: `^[0-9]{18,19}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.opts.Kind, func(t *testing.T) {
+			ids, err := GenerateN(tt.opts, 50)
+			if err != nil {
+				t.Fatalf("GenerateN failed: %v", err)
+			}
+			re := regexp.MustCompile(tt.pattern)
+			for _, id := range ids {
+				if !re.MatchString(id) {
+					t.Errorf("id %q does not match %s", id, tt.pattern)
+				}
+			}
+			if len(slices.Compact(slices.Sorted(slices.Values(ids)))) != len(ids) {
+				t.Errorf("ids are not unique: %v", ids)
+			}
+		})
+	}
+}
+
+func TestGenerateNNameBased(t *testing.T) {
+	opts := Options{Version: 5, Namespace: "example.com"}
+	ids, err := GenerateN(opts, 1)
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("GenerateN(1) = %v, %v", ids, err)
+	}
+	if _, err := GenerateN(opts, 2); err == nil {
+		t.Error("GenerateN(2) should fail, the ids would repeat")
+	}
+}
+
+func TestULIDMonotonic(t *testing.T) {
+	g, err := NewGenerator(Options{Kind: KindULID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := time.UnixMilli(1700000000000)
+	g.now = func() time.Time { return fixed }
+
+	var ids []string
+	for range 100 {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if !slices.IsSorted(ids) {
+		t.Errorf("ULIDs of one millisecond are not sorted: %v", ids)
+	}
+	// 1700000000000 ms in Crockford base32.
+	if !strings.HasPrefix(ids[0], "01HF7YAT00") {
+		t.Errorf("unexpected timestamp part in %q", ids[0])
+	}
+}
+
+func TestSnowflakeLayout(t *testing.T) {
+	g, err := NewGenerator(Options{Kind: KindSnowflake, Node: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := time.UnixMilli(SnowflakeEpoch + 1000)
+	g.now = func() time.Time { return fixed }
+
+	for seq := range int64(3) {
+		id, _ := g.Next()
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n>>22 != 1000 || (n>>12)&MaxSnowflakeNode != 7 || n&(snowflakeSeqs-1) != seq {
+			t.Errorf("id %d: want time 1000, node 7, sequence %d", n, seq)
+		}
+	}
+}
+
+func TestUUIDStyles(t *testing.T) {
+	tests := map[string][]string{
+		`^[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}$`: {"upper"},
+		`^[0-9a-f]{32}$`:                       {"no-hyphen"},
+		`^\{[0-9a-f]{8}-[0-9a-f-]{27}\}$`:      {"braces"},
+		`^urn:uuid:[0-9a-f]{8}-[0-9a-f-]{27}$`: {"urn"},
+		`^\{[0-9A-F]{32}\}$`:                   {"upper", "no-hyphen", "braces"},
+	}
+	for pattern, styles := range tests {
+		ids, err := GenerateN(Options{Kind: KindUUID, Version: 7, Styles: styles}, 1)
+		if err != nil {
+			t.Fatalf("%v: %v", styles, err)
+		}
+		if !regexp.MustCompile(pattern).MatchString(ids[0]) {
+			t.Errorf("%v: %q does not match %s", styles, ids[0], pattern)
+		}
+		if _, err := uuid.Parse(strings.Trim(ids[0], "{}")); err != nil {
+			t.Errorf("%v: %q is not a UUID: %v", styles, ids[0], err)
+		}
+	}
+}
+
+func TestNewGeneratorInvalid(t *testing.T) {
+	for _, opts := range []Options{
+		{Kind: "guid"},
+		{Kind: KindUUID, Styles: []string{"lower"}},
+		{Kind: KindUUID, Styles: []string{"braces", "urn"}},
+		{Kind: KindULID, Styles: []string{"upper"}},
+		{Kind: KindULID, Namespace: "example.com"},
+		{Kind: KindNanoID, Alphabet: "a"},
+		{Kind: KindNanoID, Alphabet: "abca"},
+		{Kind: KindNanoID, Length: -1},
+		{Kind: KindCUID2, Length: 40},
+		{Kind: KindSnowflake, Node: 1024},
+	} {
+		if _, err := NewGenerator(opts); err == nil {
+			t.Errorf("%+v should be rejected", opts)
+		}
+	}
+}
+
+func TestFormatIDs(t *testing.T) {
+	ids := []string{"a", "b'c"}
+	tests := map[string]string{
+		"plain": "a\nb'c",
+		"csv":   "id\na\nb'c",
+		"sql":   "VALUES\n  ('a'),\n  ('b''c');",
+	}
+	for format, want := range tests {
+		got, err := FormatIDs(ids, format)
+		if err != nil || got != want {
+			t.Errorf("FormatIDs(%s) = %q, %v; want %q", format, got, err, want)
+		}
+	}
+
+	got, err := FormatIDs(ids, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []string
+	if err := json.Unmarshal([]byte(got), &decoded); err != nil || !slices.Equal(decoded, ids) {
+		t.Errorf("FormatIDs(json) = %q", got)
+	}
+
+	if _, err := FormatIDs(ids, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,121 @@
+package cron
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/huh/v2"
+)
+
+var weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// builder composes a cron expression from a constraint per field.
+type builder struct {
+	form     *huh.Form
+	minute   string
+	hour     string
+	day      string
+	month    string
+	weekdays []int
+}
+
+func newBuilder() *builder {
+	b := &builder{minute: "0", hour: "*", day: "*", month: "*"}
+
+	minutes := []huh.Option[string]{
+		huh.NewOption("Every minute", "*"),
+		huh.NewOption("Every 5 minutes", "*/5"),
+		huh.NewOption("Every 10 minutes", "*/10"),
+		huh.NewOption("Every 15 minutes", "*/15"),
+		huh.NewOption("Every 30 minutes", "*/30"),
+	}
+	for m := 0; m < 60; m += 5 {
+		minutes = append(minutes, huh.NewOption(fmt.Sprintf("At minute %02d", m), strconv.Itoa(m)))
+	}
+
+	hours := []huh.Option[string]{
+		huh.NewOption("Every hour", "*"),
+		huh.NewOption("Every 2 hours", "*/2"),
+		huh.NewOption("Every 3 hours", "*/3"),
+		huh.NewOption("Every 6 hours", "*/6"),
+		huh.NewOption("Every 12 hours", "*/12"),
+		huh.NewOption("Working hours (09-17)", "9-17"),
+	}
+	for h := range 24 {
+		hours = append(hours, huh.NewOption(fmt.Sprintf("At %02d", h), strconv.Itoa(h)))
+	}
+
+	days := []huh.Option[string]{
+		huh.NewOption("Every day", "*"),
+		huh.NewOption("Every other day", "*/2"),
+		huh.NewOption("Last day of the month", "L"),
+	}
+	for d := 1; d <= 31; d++ {
+		days = append(days, huh.NewOption(fmt.Sprintf("On day %d", d), strconv.Itoa(d)))
+	}
+
+	months := []huh.Option[string]{
+		huh.NewOption("Every month", "*"),
+		huh.NewOption("Every 2 months", "*/2"),
+		huh.NewOption("Every quarter", "*/3"),
+		huh.NewOption("Every 6 months", "*/6"),
+	}
+	for i, name := range []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"} {
+		months = append(months, huh.NewOption("In "+name, strconv.Itoa(i+1)))
+	}
+
+	weekdays := make([]huh.Option[int], len(weekdayNames))
+	for i, name := range weekdayNames {
+		weekdays[i] = huh.NewOption(name, i)
+	}
+
+	b.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().Title("Minute").Options(minutes...).Inline(true).Value(&b.minute),
+			huh.NewSelect[string]().Title("Hour").Options(hours...).Inline(true).Value(&b.hour),
+			huh.NewSelect[string]().Title("Day of month").Options(days...).Inline(true).Value(&b.day),
+			huh.NewSelect[string]().Title("Month").Options(months...).Inline(true).Value(&b.month),
+			huh.NewMultiSelect[int]().
+				Title("Days of week").
+				Description("None selected runs on every day").
+				Options(weekdays...).
+				Value(&b.weekdays),
+		),
+	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithShowHelp(false)
+
+	return b
+}
+
+// Expression returns the cron expression of the selected constraints.
+func (b *builder) Expression() string {
+	return strings.Join([]string{b.minute, b.hour, b.day, b.month, weekdayList(b.weekdays)}, " ")
+}
+
+// weekdayList joins days of the week by name, collapsing runs of three or
+// more into ranges such as MON-FRI.
+func weekdayList(days []int) string {
+	if len(days) == 0 || len(days) == len(weekdayNames) {
+		return "*"
+	}
+	days = slices.Sorted(slices.Values(days))
+
+	var parts []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == days[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, weekdayNames[days[i]]+"-"+weekdayNames[days[j]])
+		default:
+			for _, d := range days[i : j+1] {
+				parts = append(parts, weekdayNames[d])
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
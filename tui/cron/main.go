@@ -1,14 +1,18 @@
 package cron
 
 import (
-	"errors"
 	"fmt"
-	"regexp"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
 	"charm.land/huh/v2"
 	"charm.land/lipgloss/v2"
-	"github.com/lnquy/cron"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/cronexpr"
 	"github.com/skatkov/devtui/internal/ui"
 
 	tea "charm.land/bubbletea/v2"
@@ -16,10 +20,49 @@ import (
 
 const Title = "Cron Job Parser"
 
+const (
+	// nextRuns is how many upcoming run times are listed.
+	nextRuns   = 5
+	timeLayout = "2006-01-02 15:04:05 MST (Mon)"
+)
+
+type mode int
+
+const (
+	modeExpression mode = iota
+	modeBuilder
+	modeCrontab
+)
+
+var (
+	builderKey = key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "builder"))
+	crontabKey = key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "open crontab"))
+	backKey    = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back"))
+
+	titleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF69B4")).
+			Bold(true)
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#87CEEB"))
+)
+
 type CronModel struct {
 	common         *ui.CommonModel
+	mode           mode
 	form           *huh.Form
+	input          *huh.Input
 	cronExpression string
+
+	builder *builder
+
+	// crontabForm asks for the crontab to review; once it is read,
+	// crontabLoaded is set and the viewport lists its entries.
+	crontabForm   *huh.Form
+	crontabPath   string
+	crontabLoaded bool
+	crontabErr    error
+	entries       []cronexpr.CrontabEntry
+	viewport      viewport.Model
 }
 
 func NewCronModel(common *ui.CommonModel) *CronModel {
@@ -27,61 +70,129 @@ func NewCronModel(common *ui.CommonModel) *CronModel {
 		common:         common,
 		cronExpression: "*/5 * * * *",
 	}
+	m.newExpressionForm()
+
+	return m
+}
+
+func (m *CronModel) newExpressionForm() {
+	m.input = huh.NewInput().
+		Title("Cron Expression").
+		Value(&m.cronExpression).
+		Placeholder("*/5 * * * *").
+		Validate(func(str string) error {
+			schedule, err := cronexpr.Parse(str)
+			if err != nil {
+				return fmt.Errorf("invalid cron expression: %v", err)
+			}
+			if _, err := schedule.Describe("en"); err != nil {
+				return fmt.Errorf("invalid cron expression: %v", err)
+			}
+			return nil
+		}).
+		DescriptionFunc(func() string {
+			schedule, err := cronexpr.Parse(m.cronExpression)
+			if err != nil {
+				return ""
+			}
+			desc, err := schedule.Describe("en")
+			if err != nil {
+				return ""
+			}
 
-	// @see https://gist.github.com/Aterfax/401875eb3d45c9c114bbef69364dd045
-	// @see https://regexr.com/4jp54
-	// TODO: Invalid "*/5 * * * *." format is being allowed. Fix it.
-	cronRegex := `^((((\d+,)+\d+|(\d+(\/|-|#)\d+)|\d+L?|\*(\/\d+)?|L(-\d+)?|\?|[A-Z]{3}(-[A-Z]{3})?) ?){5,7})|(@(annually|yearly|monthly|weekly|daily|hourly|reboot))|(@every (\d+(ns|us|µs|ms|s|m|h))+)$`
+			return valueStyle.PaddingLeft(20).Render(desc)
+		}, &m.cronExpression)
 	m.form = huh.NewForm(
+		huh.NewGroup(m.input),
+	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithShowHelp(false)
+}
+
+func (m *CronModel) newCrontabForm() {
+	m.crontabLoaded = false
+	m.crontabForm = huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
-				Title("Cron Expression").
-				Value(&m.cronExpression).
-				Placeholder("*/5 * * * *").
-				Validate(func(str string) error {
-					// First validate with regexp
-					matched, err := regexp.MatchString(cronRegex, str)
+				Title("Crontab File").
+				Placeholder("/etc/crontab").
+				Value(&m.crontabPath).
+				Validate(func(path string) error {
+					info, err := os.Stat(strings.TrimSpace(path))
 					if err != nil {
-						return fmt.Errorf("validation error: %v", err)
+						return err
 					}
-					if !matched {
-						return errors.New("invalid cron expression format")
-					}
-
-					// Then validate with cron descriptor
-					expr, err := cron.NewDescriptor()
-					if err != nil {
-						return fmt.Errorf("invalid cron expression: %v", err)
-					}
-					_, err = expr.ToDescription(str, cron.Locale_en)
-					if err != nil {
-						return fmt.Errorf("invalid cron expression: %v", err)
+					if info.IsDir() {
+						return fmt.Errorf("%s is a directory", path)
 					}
 					return nil
-				}).
-				DescriptionFunc(func() string {
-					expr, err := cron.NewDescriptor(
-						cron.Use24HourTimeFormat(true),
-						cron.DayOfWeekStartsAtOne(true),
-					)
-					if err != nil {
-						return ""
-					}
+				}),
+		),
+	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithShowHelp(false)
+}
 
-					desc, err := expr.ToDescription(m.cronExpression, cron.Locale_en)
-					if err != nil {
-						return ""
-					}
+// SetContent fills in the cron expression, so the form opens with it. Text
+// of several lines is reviewed as a crontab instead.
+func (m *CronModel) SetContent(content string) error {
+	content = strings.TrimSpace(content)
+	if strings.Contains(content, "\n") {
+		m.newCrontabForm()
+		m.loadCrontab(content, nil)
+		return nil
+	}
 
-					valueStyle := lipgloss.NewStyle().
-						Foreground(lipgloss.Color("#87CEEB")).PaddingLeft(20)
+	m.cronExpression = content
+	m.input.Value(&m.cronExpression)
+	return nil
+}
 
-					return valueStyle.Render(desc)
-				}, &m.cronExpression),
-		),
-	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithShowHelp(false)
+func (m *CronModel) loadCrontab(content string, err error) {
+	m.mode = modeCrontab
+	m.crontabLoaded = true
+	m.crontabErr = err
+	m.entries = cronexpr.ParseCrontab(content)
+	m.viewport = viewport.New(
+		viewport.WithWidth(m.common.Width),
+		viewport.WithHeight(m.crontabHeight()),
+	)
+	m.renderCrontab()
+}
 
-	return m
+func (m *CronModel) crontabHeight() int {
+	// Header, its margin and the help line.
+	return max(m.common.Height-4, 1)
+}
+
+// renderCrontab lists every entry with its description and next run.
+func (m *CronModel) renderCrontab() {
+	if m.crontabErr != nil {
+		m.viewport.SetContent(fmt.Sprintf("Error reading crontab: %v", m.crontabErr))
+		return
+	}
+	if len(m.entries) == 0 {
+		m.viewport.SetContent("No scheduled lines found.")
+		return
+	}
+
+	now := time.Now()
+	rows := make([][]string, 0, len(m.entries))
+	for _, entry := range m.entries {
+		var desc, next string
+		if entry.Err != nil {
+			desc = "Invalid: " + entry.Err.Error()
+		} else {
+			desc, _ = entry.Schedule.Describe("en")
+			if t, ok := entry.Schedule.Next(now); ok {
+				next = t.Format(timeLayout)
+			}
+		}
+		rows = append(rows, []string{strconv.Itoa(entry.Line), entry.Expression, entry.Command, desc, next})
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		Width(m.common.Width).
+		Headers("Line", "Schedule", "Command", "Description", "Next run").
+		Rows(rows...)
+	m.viewport.SetContent(t.String())
 }
 
 func (m *CronModel) Init() tea.Cmd {
@@ -94,19 +205,74 @@ func (m *CronModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.common.Width = msg.Width
 		m.common.Height = msg.Height
+		if m.crontabLoaded {
+			m.viewport.SetWidth(msg.Width)
+			m.viewport.SetHeight(m.crontabHeight())
+			m.renderCrontab()
+		}
 	case tea.KeyPressMsg:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case key.Matches(msg, backKey):
+			if m.mode != modeExpression {
+				m.mode = modeExpression
+				return m, nil
+			}
 			return m, func() tea.Msg {
 				return ui.ReturnToListMsg{
 					Common: m.common,
 				}
 			}
-		case "q", "ctrl+c":
+		case key.Matches(msg, builderKey):
+			if m.mode == modeBuilder {
+				m.mode = modeExpression
+				return m, nil
+			}
+			m.mode = modeBuilder
+			m.builder = newBuilder()
+			return m, m.builder.form.Init()
+		case key.Matches(msg, crontabKey):
+			m.mode = modeCrontab
+			m.newCrontabForm()
+			return m, m.crontabForm.Init()
+		case msg.String() == "ctrl+c":
+			return m, tea.Quit
+		// The crontab path may contain a q.
+		case msg.String() == "q" && (m.mode != modeCrontab || m.crontabLoaded):
 			return m, tea.Quit
 		}
 	}
 
+	switch m.mode {
+	case modeBuilder:
+		form, cmd := m.builder.form.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.builder.form = f
+		}
+		if m.builder.form.State == huh.StateCompleted {
+			// Hand the expression over for editing and review.
+			m.mode = modeExpression
+			m.cronExpression = m.builder.Expression()
+			m.newExpressionForm()
+			return m, m.form.Init()
+		}
+		return m, cmd
+	case modeCrontab:
+		if m.crontabLoaded {
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		form, cmd := m.crontabForm.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.crontabForm = f
+		}
+		if m.crontabForm.State == huh.StateCompleted {
+			content, err := os.ReadFile(strings.TrimSpace(m.crontabPath))
+			m.loadCrontab(string(content), err)
+		}
+		return m, cmd
+	}
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
@@ -116,49 +282,70 @@ func (m *CronModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *CronModel) View() tea.View {
 	s := m.common.Styles
-	switch m.form.State {
-	case huh.StateCompleted:
-		expr, err := cron.NewDescriptor(
-			cron.Use24HourTimeFormat(true),
-			cron.DayOfWeekStartsAtOne(true),
-		)
-		if err != nil {
-			return ui.AltScreenView(lipgloss.NewStyle().Padding(2).
-				Render(fmt.Sprintf("Error parsing cron expression: %v", err)))
-		}
+	header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
+		ui.AppTitle,
+		" :: ",
+		lipgloss.NewStyle().Bold(true).Render(Title),
+	))
 
-		desc, err := expr.ToDescription(m.cronExpression, cron.Locale_en)
-		if err != nil {
-			return ui.AltScreenView(lipgloss.NewStyle().Padding(2).
-				Render(fmt.Sprintf("Error parsing cron expression: %v", err)))
+	switch m.mode {
+	case modeBuilder:
+		form := lipgloss.NewStyle().Margin(1, 0).Render(strings.TrimSuffix(m.builder.form.View(), "\n\n"))
+		body := lipgloss.JoinVertical(lipgloss.Top, form, summary(m.builder.Expression()))
+		return ui.AltScreenView(s.Base.Render(header + "\n" + m.placeHelp(header, body, m.builder.form, backKey)))
+	case modeCrontab:
+		if m.crontabLoaded {
+			help := m.form.Help().ShortHelpView([]key.Binding{backKey, crontabKey, builderKey})
+			return ui.AltScreenView(s.Base.Render(header + "\n" + m.viewport.View() + "\n" + help))
 		}
-		titleStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF69B4")).
-			Bold(true)
-
-		valueStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#87CEEB"))
-
-		output := titleStyle.Render(m.cronExpression) + " \n\n" + valueStyle.Render(desc)
+		form := lipgloss.NewStyle().Margin(1, 0).Render(strings.TrimSuffix(m.crontabForm.View(), "\n\n"))
+		return ui.AltScreenView(s.Base.Render(header + "\n" + m.placeHelp(header, form, m.crontabForm, backKey)))
+	}
 
-		return ui.AltScreenView(s.Base.Render(output))
+	switch m.form.State {
+	case huh.StateCompleted:
+		return ui.AltScreenView(s.Base.Render(summary(m.cronExpression)))
 	default:
-		header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
-			ui.AppTitle,
-			" :: ",
-			lipgloss.NewStyle().Bold(true).Render(Title),
-		))
 		v := strings.TrimSuffix(m.form.View(), "\n\n")
 		form := lipgloss.NewStyle().Margin(1, 0).Render(v)
-		body := lipgloss.JoinVertical(
-			lipgloss.Top,
-			form,
-			lipgloss.PlaceVertical(
-				m.common.Height-lipgloss.Height(header)-lipgloss.Height(form)-2,
-				lipgloss.Bottom,
-				m.form.Help().ShortHelpView(m.form.KeyBinds()),
-			),
-		)
-		return ui.AltScreenView(s.Base.Render(header + "\n" + body))
+		return ui.AltScreenView(s.Base.Render(header + "\n" + m.placeHelp(header, form, m.form, builderKey, crontabKey)))
+	}
+}
+
+// placeHelp puts the help of the form and the extra keys at the bottom of
+// the screen, below body.
+func (m *CronModel) placeHelp(header, body string, form *huh.Form, keys ...key.Binding) string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		body,
+		lipgloss.PlaceVertical(
+			m.common.Height-lipgloss.Height(header)-lipgloss.Height(body)-2,
+			lipgloss.Bottom,
+			form.Help().ShortHelpView(append(form.KeyBinds(), keys...)),
+		),
+	)
+}
+
+// summary shows the expression with its description and next run times,
+// or why it is invalid.
+func summary(expression string) string {
+	output := titleStyle.Render(expression) + " \n\n"
+	schedule, err := cronexpr.Parse(expression)
+	if err != nil {
+		return output + fmt.Sprintf("Error parsing cron expression: %v", err)
+	}
+	desc, err := schedule.Describe("en")
+	if err != nil {
+		return output + fmt.Sprintf("Error parsing cron expression: %v", err)
+	}
+
+	output += valueStyle.Render(desc)
+	if next := schedule.NextN(time.Now(), nextRuns); len(next) > 0 {
+		runs := make([]string, len(next))
+		for i, t := range next {
+			runs[i] = t.Format(timeLayout)
+		}
+		output += "\n\n" + titleStyle.Render("Next runs") + "\n" + strings.Join(runs, "\n")
 	}
+	return output
 }
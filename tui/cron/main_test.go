@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func newTestModel() *CronModel {
+	common := &ui.CommonModel{Width: 120, Height: 40}
+	common.Styles = ui.NewStyle()
+	return NewCronModel(common)
+}
+
+func TestWeekdayList(t *testing.T) {
+	tests := []struct {
+		days []int
+		want string
+	}{
+		{days: nil, want: "*"},
+		{days: []int{0, 1, 2, 3, 4, 5, 6}, want: "*"},
+		{days: []int{5, 1, 2, 3, 4}, want: "MON-FRI"},
+		{days: []int{0, 6}, want: "SUN,SAT"},
+		{days: []int{1, 2, 4, 5, 6}, want: "MON,TUE,THU-SAT"},
+	}
+	for _, tt := range tests {
+		if got := weekdayList(tt.days); got != tt.want {
+			t.Errorf("weekdayList(%v) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestBuilderHandsOverExpression(t *testing.T) {
+	model := newTestModel()
+	model.Init()
+
+	next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: 't', Mod: tea.ModCtrl}))
+	model = next.(*CronModel)
+	if model.mode != modeBuilder {
+		t.Fatalf("expected ctrl+t to open the builder, mode = %v", model.mode)
+	}
+
+	model.builder.hour = "9"
+	model.builder.weekdays = []int{1, 2, 3, 4, 5}
+	if got := model.builder.Expression(); got != "0 9 * * MON-FRI" {
+		t.Fatalf("Expression() = %q", got)
+	}
+	view := model.View().Content
+	if !strings.Contains(view, "At 09:00, Monday through Friday") || !strings.Contains(view, "Next runs") {
+		t.Errorf("builder view lacks the live preview:\n%s", view)
+	}
+}
+
+func TestSetContentReviewsCrontab(t *testing.T) {
+	model := newTestModel()
+	if err := model.SetContent("MAILTO=root\n0 3 * * * /usr/bin/backup\n61 * * * * broken\n"); err != nil {
+		t.Fatal(err)
+	}
+	if model.mode != modeCrontab || !model.crontabLoaded || len(model.entries) != 2 {
+		t.Fatalf("expected a crontab with two entries, got mode %v and %+v", model.mode, model.entries)
+	}
+
+	view := model.View().Content
+	for _, want := range []string{"/usr/bin/backup", "At 03:00", "Invalid: minute field"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("crontab view lacks %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestRejectsTrailingGarbage(t *testing.T) {
+	model := newTestModel()
+	if err := model.SetContent("*/5 * * * *."); err != nil {
+		t.Fatal(err)
+	}
+	model.Init()
+
+	next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	model = next.(*CronModel)
+	if model.input.Error() == nil {
+		t.Fatal("expected \"*/5 * * * *.\" to be rejected")
+	}
+}
@@ -105,9 +105,16 @@ func (m *CSSFormatterModel) SetContent(content string) error {
 	reader := strings.NewReader(content)
 	var outputBuf bytes.Buffer
 
-	// Create CSS formatter with 2 spaces for indentation and always including semicolons
-	cssformat := csstool.NewCSSFormat(2, false, nil)
-	cssformat.AlwaysSemicolon = true
+	// Indent with 2 spaces and always include semicolons unless configured
+	// otherwise, matching the cssfmt command.
+	cfg := m.Common.Config
+	tab := cfg.Bool("cssfmt.tab", false)
+	indent := cfg.Int("cssfmt.indent", 2)
+	if tab {
+		indent = 1
+	}
+	cssformat := csstool.NewCSSFormat(indent, tab, nil)
+	cssformat.AlwaysSemicolon = cfg.Bool("cssfmt.semicolon", true)
 
 	// Format the CSS
 	err := cssformat.Format(reader, &outputBuf)
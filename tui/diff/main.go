@@ -0,0 +1,232 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/muesli/reflow/truncate"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/diff"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+const Title = "Structural Diff"
+
+// separator divides the two columns.
+const separator = " │ "
+
+var (
+	addedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	changedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	sideStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D7D7D"))
+)
+
+// DiffModel shows two documents side by side as formatted JSON, with the
+// lines that differ highlighted. Copying yields the tree report of the
+// changes.
+type DiffModel struct {
+	ui.BasePagerModel
+	left, right any
+	rows        []diff.Row
+	hunks       []int
+	changes     int
+}
+
+func NewDiffModel(common *ui.CommonModel) *DiffModel {
+	return &DiffModel{
+		BasePagerModel: ui.NewBasePagerModel(common, Title),
+	}
+}
+
+func (m *DiffModel) Init() tea.Cmd {
+	return m.BasePagerModel.Init()
+}
+
+func (m *DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			return m, cmd
+		}
+		switch msg.String() {
+		case "n":
+			m.jump(1)
+		case "p":
+			m.jump(-1)
+		case "v", "V":
+			content, err := clipboard.Paste()
+			if err == nil {
+				err = m.paste(content, msg.String() == "v")
+			}
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else if m.left == nil || m.right == nil {
+				cmds = append(cmds, m.ShowStatusMessage("Pasted. Press 'v'/'V' to paste the other document"))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage(fmt.Sprintf("%d changes. Press 'n'/'p' to jump between them", m.changes)))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+	case tea.WindowSizeMsg:
+		cmds = append(cmds, m.HandleWindowSizeMsg(msg))
+		m.render()
+	}
+
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *DiffModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+// SetDocuments compares two decoded documents.
+func (m *DiffModel) SetDocuments(left, right any) error {
+	m.left, m.right = left, right
+	return m.compare()
+}
+
+// paste decodes pasted content as the left or right document.
+func (m *DiffModel) paste(content string, left bool) error {
+	format, err := converter.Detect(content)
+	if err != nil {
+		return err
+	}
+	value, err := converter.Decode(content, format)
+	if err != nil {
+		return err
+	}
+
+	if left {
+		m.left = value
+	} else {
+		m.right = value
+	}
+	return m.compare()
+}
+
+// compare aligns the documents and collects the changes. Until both
+// documents are known, the missing side is compared as an empty object.
+func (m *DiffModel) compare() error {
+	left, right := m.left, m.right
+	if left == nil {
+		left = converter.NewObject()
+	}
+	if right == nil {
+		right = converter.NewObject()
+	}
+
+	leftJSON, err := converter.Encode(left, converter.FormatJSON)
+	if err != nil {
+		return err
+	}
+	rightJSON, err := converter.Encode(right, converter.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	m.rows = diff.Lines(splitLines(leftJSON), splitLines(rightJSON))
+	m.hunks = m.hunks[:0]
+	for i, row := range m.rows {
+		if row.Kind != diff.RowEqual && (i == 0 || m.rows[i-1].Kind == diff.RowEqual) {
+			m.hunks = append(m.hunks, i)
+		}
+	}
+
+	changes := diff.Compare(left, right)
+	m.changes = len(changes)
+	m.FormattedContent = diff.Tree(changes, diff.TreeStyles{})
+	m.Title = fmt.Sprintf("%s (%d changes)", Title, m.changes)
+	// Content gates the scroll indicator and paste hint of the status bar.
+	m.Content = " "
+	m.render()
+	m.Viewport.GotoTop()
+	return nil
+}
+
+// jump scrolls to the next or previous block of differing lines.
+func (m *DiffModel) jump(direction int) {
+	offset := m.Viewport.YOffset()
+	if direction > 0 {
+		for _, hunk := range m.hunks {
+			if hunk > offset {
+				m.Viewport.SetYOffset(hunk)
+				return
+			}
+		}
+		return
+	}
+	for i := len(m.hunks) - 1; i >= 0; i-- {
+		if m.hunks[i] < offset {
+			m.Viewport.SetYOffset(m.hunks[i])
+			return
+		}
+	}
+}
+
+func (m *DiffModel) render() {
+	if len(m.rows) == 0 {
+		return
+	}
+	width := max(1, (m.Common.Width-lipgloss.Width(separator))/2)
+
+	var b strings.Builder
+	for i, row := range m.rows {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		left, right := cell(row.Left, width), cell(row.Right, width)
+		switch row.Kind {
+		case diff.RowAdded:
+			right = addedStyle.Render(right)
+		case diff.RowRemoved:
+			left = removedStyle.Render(left)
+		case diff.RowChanged:
+			left, right = changedStyle.Render(left), changedStyle.Render(right)
+		}
+		b.WriteString(left + sideStyle.Render(separator) + right)
+	}
+	m.Viewport.SetContent(b.String())
+}
+
+// cell truncates and pads a line to the column width.
+func cell(line string, width int) string {
+	line = truncate.StringWithTail(line, uint(width), ui.Ellipsis)
+	return line + strings.Repeat(" ", max(0, width-lipgloss.Width(line)))
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
+
+func (m *DiffModel) helpView() string {
+	col1 := []string{
+		"n/p            next/previous change",
+		"c              copy change report",
+		"v/V            paste left/right document",
+		"esc            back to menu",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func TestSideBySide(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	left, err := converter.Decode(`{"a":1,"b":2,"c":3}`, converter.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := converter.Decode("a: 1\nb: 5\nc: 3\n", converter.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := NewDiffModel(common)
+	next, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	model = next.(*DiffModel)
+	if err := model.SetDocuments(left, right); err != nil {
+		t.Fatalf("SetDocuments() error = %v", err)
+	}
+
+	if model.FormattedContent != "~ b: 2 → 5\n" {
+		t.Fatalf("FormattedContent = %q", model.FormattedContent)
+	}
+	if model.Title != Title+" (1 changes)" {
+		t.Fatalf("Title = %q", model.Title)
+	}
+	if len(model.hunks) != 1 || model.hunks[0] != 2 {
+		t.Fatalf("hunks = %v, want [2]", model.hunks)
+	}
+
+	view := model.Viewport.View()
+	if !strings.Contains(view, `"b": 2,`) || !strings.Contains(view, `"b": 5,`) {
+		t.Fatalf("expected both sides in the view, got:\n%s", view)
+	}
+}
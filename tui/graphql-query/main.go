@@ -10,6 +10,7 @@ import (
 	"github.com/mattn/go-runewidth"
 
 	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/config"
 	"github.com/skatkov/devtui/internal/editor"
 	"github.com/skatkov/devtui/internal/ui"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -107,7 +108,7 @@ func (m GraphQLQueryModel) View() tea.View {
 
 func (m *GraphQLQueryModel) SetContent(content string) error {
 	m.Content = content
-	m.FormattedContent = formatGraphQL(content)
+	m.FormattedContent = formatGraphQL(content, m.Common.Config)
 
 	// Check if GraphQL query is valid for syntax highlighting
 	_, err := parser.ParseQuery(&ast.Source{Input: content})
@@ -162,7 +163,9 @@ func (m GraphQLQueryModel) helpView() (s string) {
 	return ui.HelpViewStyle(s)
 }
 
-func formatGraphQL(content string) string {
+// formatGraphQL formats a query with the configured gqlquery options,
+// returning content unchanged when it doesn't parse.
+func formatGraphQL(content string, cfg *config.Config) string {
 	// Parse the query
 	query, err := parser.ParseQuery(&ast.Source{Input: content})
 	if err != nil {
@@ -171,7 +174,14 @@ func formatGraphQL(content string) string {
 
 	// Format the query
 	var buf bytes.Buffer
-	f := formatter.NewFormatter(&buf)
+	opts := []formatter.FormatterOption{formatter.WithIndent(cfg.String("gqlquery.indent", "  "))}
+	if cfg.Bool("gqlquery.with-comments", false) {
+		opts = append(opts, formatter.WithComments())
+	}
+	if !cfg.Bool("gqlquery.with-descriptions", true) {
+		opts = append(opts, formatter.WithoutDescription())
+	}
+	f := formatter.NewFormatter(&buf, opts...)
 	f.FormatQueryDocument(query)
 
 	return buf.String()
@@ -0,0 +1,149 @@
+package hash
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/hashutil"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+const Title = "Hash Generator"
+
+type HashModel struct {
+	ui.BasePagerModel
+	encoding hashutil.Encoding
+}
+
+func NewHashModel(common *ui.CommonModel) *HashModel {
+	model := HashModel{
+		BasePagerModel: ui.NewBasePagerModel(common, Title),
+		encoding:       hashutil.EncodingHex,
+	}
+
+	return &model
+}
+
+func (m *HashModel) Init() tea.Cmd {
+	return m.BasePagerModel.Init()
+}
+
+func (m *HashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "e":
+			return m, editor.OpenEditor(m.Content, "txt")
+		case "v":
+			content, err := clipboard.Paste()
+			if err == nil {
+				err = m.SetContent(content)
+			}
+
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage("Pasted and hashed. Press 'c' to copy digests."))
+			}
+		case "x":
+			if m.encoding == hashutil.EncodingHex {
+				m.encoding = hashutil.EncodingBase64
+			} else {
+				m.encoding = hashutil.EncodingHex
+			}
+			if err := m.SetContent(m.Content); err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage(fmt.Sprintf("Switched to %s encoding.", m.encoding)))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+
+	case editor.EditorFinishedMsg:
+		if msg.Err != nil {
+			return m, m.ShowErrorMessage(msg.Err.Error())
+		}
+
+		if err := m.SetContent(msg.Content); err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+		} else {
+			cmds = append(cmds, m.ShowStatusMessage("Content hashed. Press 'c' to copy digests."))
+		}
+	case tea.WindowSizeMsg:
+		cmd = m.HandleWindowSizeMsg(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *HashModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+func (m *HashModel) SetContent(content string) error {
+	m.Content = content
+
+	if content == "" {
+		m.FormattedContent = ""
+		m.Viewport.SetContent("")
+		return nil
+	}
+
+	digests := hashutil.SumString(content, hashutil.Algorithms, m.encoding)
+
+	var plain strings.Builder
+	rows := make([][]string, 0, len(digests))
+	for i, digest := range digests {
+		label := hashutil.Algorithms[i].Label
+		fmt.Fprintf(&plain, "%s  %s\n", digest.Value, label)
+		rows = append(rows, []string{label, digest.Value})
+	}
+	m.FormattedContent = plain.String()
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		Headers("Algorithm", "Digest ("+string(m.encoding)+")").
+		Rows(rows...)
+	m.Viewport.SetContent(t.String())
+
+	return nil
+}
+
+func (m *HashModel) helpView() string {
+	col1 := []string{
+		"c              copy digests",
+		"e              edit text",
+		"v              paste text to hash",
+		"x              toggle hex/base64",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
@@ -25,8 +25,8 @@ type JsonToonModel struct {
 func NewJsonToonModel(common *ui.CommonModel) JsonToonModel {
 	model := JsonToonModel{
 		BasePagerModel: ui.NewBasePagerModel(common, Title),
-		indent:         2,
-		lengthMarker:   "",
+		indent:         common.Config.Int("json2toon.indent", 2),
+		lengthMarker:   common.Config.String("json2toon.length-marker", ""),
 	}
 
 	return model
@@ -3,11 +3,11 @@ package jsonstruct
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/alecthomas/chroma/quick"
-	"github.com/mattn/go-runewidth"
 
 	"github.com/skatkov/devtui/internal/clipboard"
 	"github.com/skatkov/devtui/internal/editor"
@@ -15,16 +15,19 @@ import (
 	"github.com/skatkov/devtui/internal/ui"
 )
 
-const Title = "JSON to Go Struct Converter"
+const Title = "JSON to Struct Converter"
 
 type JsonStructModel struct {
 	ui.BasePagerModel
+	lang structgen.Language
 }
 
 func NewJsonStructModel(common *ui.CommonModel) JsonStructModel {
 	model := JsonStructModel{
 		BasePagerModel: ui.NewBasePagerModel(common, Title),
+		lang:           structgen.LanguageGo,
 	}
+	model.Title = model.title()
 
 	return model
 }
@@ -46,6 +49,14 @@ func (m JsonStructModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "e":
 			return m, editor.OpenEditor(m.Content, "json")
+		case "L":
+			m.lang = m.nextLanguage()
+			m.Title = m.title()
+			if strings.TrimSpace(m.Content) != "" {
+				if err := m.SetContent(m.Content); err != nil {
+					cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+				}
+			}
 		case "v":
 			content, err := clipboard.Paste()
 			if err == nil {
@@ -99,14 +110,14 @@ func (m JsonStructModel) View() tea.View {
 
 func (m *JsonStructModel) SetContent(content string) error {
 	m.Content = content
-	converted, err := structgen.JSONToGoStruct(strings.NewReader(content))
+	converted, err := structgen.JSONToStruct(strings.NewReader(content), m.lang)
 	if err != nil {
 		return err
 	}
 	m.FormattedContent = converted
 	var buf bytes.Buffer
 
-	err = quick.Highlight(&buf, m.FormattedContent, "go", "terminal", "nord")
+	err = quick.Highlight(&buf, m.FormattedContent, m.lang.Lexer(), "terminal", "nord")
 	if err != nil {
 		return err
 	}
@@ -114,38 +125,25 @@ func (m *JsonStructModel) SetContent(content string) error {
 	return nil
 }
 
-func (m JsonStructModel) helpView() (s string) {
+// nextLanguage returns the language after the current one, wrapping around.
+func (m JsonStructModel) nextLanguage() structgen.Language {
+	languages := structgen.Languages()
+	i := slices.Index(languages, m.lang)
+	return languages[(i+1)%len(languages)]
+}
+
+func (m JsonStructModel) title() string {
+	return fmt.Sprintf("%s (%s)", Title, m.lang)
+}
+
+func (m JsonStructModel) helpView() string {
 	col1 := []string{
-		"c              copy Go struct",
+		"c              copy result",
 		"e              edit JSON",
 		"v              paste JSON to convert",
+		"L              switch language",
 		"q/ctrl+c       quit",
 	}
 
-	s += "\n"
-	s += "k/↑      up                  " + col1[0] + "\n"
-	s += "j/↓      down                " + col1[1] + "\n"
-	s += "b/pgup   page up             " + col1[2] + "\n"
-	s += "f/pgdn   page down           " + col1[3] + "\n"
-	s += "u        ½ page up           " + "\n"
-	s += "d        ½ page down         "
-
-	if len(col1) > 5 {
-		s += col1[5]
-	}
-
-	s = ui.Indent(s, 2)
-
-	if m.Common.Width > 0 {
-		lines := strings.Split(s, "\n")
-		for i := range lines {
-			l := runewidth.StringWidth(lines[i])
-			n := max(m.Common.Width-l, 0)
-			lines[i] += strings.Repeat(" ", n)
-		}
-
-		s = strings.Join(lines, "\n")
-	}
-
-	return ui.HelpViewStyle(s)
+	return m.FormatHelpColumns(col1)
 }
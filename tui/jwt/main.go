@@ -0,0 +1,248 @@
+package jwt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/alecthomas/chroma/quick"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/jwt"
+	"github.com/skatkov/devtui/internal/ui"
+	js "github.com/skatkov/devtui/tui/json"
+)
+
+const Title = "JWT Decoder"
+
+var paneStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("240")).
+	Padding(0, 1)
+
+type JWTModel struct {
+	ui.BasePagerModel
+	token *jwt.Token
+	// key holds the secret, PEM or JWKS used for verification.
+	key        string
+	editingKey bool
+}
+
+func NewJWTModel(common *ui.CommonModel) *JWTModel {
+	model := JWTModel{
+		BasePagerModel: ui.NewBasePagerModel(common, Title),
+	}
+
+	return &model
+}
+
+func (m *JWTModel) Init() tea.Cmd {
+	return m.BasePagerModel.Init()
+}
+
+func (m *JWTModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "e":
+			m.editingKey = false
+			return m, editor.OpenEditor(m.Content, "txt")
+		case "s":
+			m.editingKey = true
+			return m, editor.OpenEditor(m.key, "txt")
+		case "v":
+			content, err := clipboard.Paste()
+			if err == nil {
+				err = m.SetContent(content)
+			}
+
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage("Token decoded. Press 's' to verify the signature."))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+
+	case editor.EditorFinishedMsg:
+		if msg.Err != nil {
+			return m, m.ShowErrorMessage(msg.Err.Error())
+		}
+
+		if m.editingKey {
+			m.editingKey = false
+			m.key = strings.TrimSpace(msg.Content)
+			if err := m.SetContent(m.Content); err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage(m.verificationStatus()))
+			}
+			break
+		}
+
+		if err := m.SetContent(msg.Content); err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+		} else {
+			cmds = append(cmds, m.ShowStatusMessage("Token decoded."))
+		}
+	case tea.WindowSizeMsg:
+		cmd = m.HandleWindowSizeMsg(msg)
+		cmds = append(cmds, cmd)
+		if m.token != nil {
+			m.Viewport.SetContent(m.render())
+		}
+	}
+
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *JWTModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+func (m *JWTModel) SetContent(content string) error {
+	m.Content = content
+
+	if strings.TrimSpace(content) == "" {
+		m.token = nil
+		m.FormattedContent = ""
+		m.Viewport.SetContent("")
+		return nil
+	}
+
+	token, err := jwt.Decode(content)
+	if err != nil {
+		return err
+	}
+	m.token = token
+
+	var plain strings.Builder
+	fmt.Fprint(&plain, js.FormatJSON(token.HeaderJSON))
+	fmt.Fprint(&plain, js.FormatJSON(token.PayloadJSON))
+	m.FormattedContent = plain.String()
+
+	m.Viewport.SetContent(m.render())
+	return nil
+}
+
+// verify checks the signature against the configured key. checked is false
+// when no key has been provided.
+func (m *JWTModel) verify() (checked bool, err error) {
+	if m.key == "" {
+		return false, nil
+	}
+
+	var key any = []byte(m.key)
+	if !strings.HasPrefix(m.token.Algorithm(), "HS") {
+		parsed, err := jwt.ParseKey([]byte(m.key), m.token)
+		if err != nil {
+			return true, err
+		}
+		key = parsed
+	}
+	return true, jwt.Verify(m.token, key)
+}
+
+func (m *JWTModel) verificationStatus() string {
+	if m.token == nil {
+		return "No token to verify."
+	}
+	checked, err := m.verify()
+	switch {
+	case err != nil:
+		return "Signature invalid: " + err.Error()
+	case !checked:
+		return "No key provided."
+	default:
+		return "Signature verified."
+	}
+}
+
+func (m *JWTModel) render() string {
+	now := time.Now()
+	width := max(m.Common.Width-4, 20)
+
+	header := highlight(js.FormatJSON(m.token.HeaderJSON))
+	payload := highlight(js.FormatJSON(m.token.PayloadJSON))
+
+	signature := fmt.Sprintf("Algorithm: %s\n", m.token.Algorithm())
+	checked, err := m.verify()
+	switch {
+	case err != nil:
+		signature += "Status:    INVALID (" + err.Error() + ")"
+	case !checked:
+		signature += "Status:    not verified (press 's' to enter a secret, PEM key or JWKS)"
+	default:
+		signature += "Status:    verified"
+	}
+
+	panes := []string{
+		pane("Header", header, width),
+		pane("Payload", payload, width),
+	}
+
+	if claims := m.token.Claims(now); len(claims) > 0 {
+		rows := make([][]string, 0, len(claims))
+		for _, claim := range claims {
+			rows = append(rows, []string{claim.Name, claim.Description, claim.Value, claim.Status})
+		}
+		t := table.New().
+			Border(lipgloss.RoundedBorder()).
+			Headers("Claim", "Description", "Value", "Status").
+			Rows(rows...)
+		panes = append(panes, "Claims ("+m.token.Status(now)+")\n"+t.String())
+	}
+
+	panes = append(panes, pane("Signature", signature, width))
+
+	return lipgloss.JoinVertical(lipgloss.Left, panes...)
+}
+
+func pane(title, content string, width int) string {
+	return title + "\n" + paneStyle.Width(width).Render(strings.TrimRight(content, "\n"))
+}
+
+func highlight(content string) string {
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, content, "json", "terminal", "nord"); err != nil {
+		return content
+	}
+	return buf.String()
+}
+
+func (m *JWTModel) helpView() string {
+	col1 := []string{
+		"c              copy header and payload",
+		"e              edit token",
+		"s              enter secret/key to verify",
+		"v              paste token",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
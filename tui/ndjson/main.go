@@ -0,0 +1,192 @@
+package ndjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/alecthomas/chroma/quick"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/ndjson"
+	"github.com/skatkov/devtui/internal/ui"
+	js "github.com/skatkov/devtui/tui/json"
+)
+
+const Title = "NDJSON Viewer"
+
+// NDJSONModel pages through a JSON Lines document one record at a time.
+// Records are read on demand from an index, so large files are never held
+// in memory as a whole.
+type NDJSONModel struct {
+	ui.BasePagerModel
+	index    *ndjson.Index
+	current  int
+	inMemory bool
+}
+
+func NewNDJSONModel(common *ui.CommonModel) *NDJSONModel {
+	return &NDJSONModel{
+		BasePagerModel: ui.NewBasePagerModel(common, Title),
+	}
+}
+
+func (m *NDJSONModel) Init() tea.Cmd {
+	return m.BasePagerModel.Init()
+}
+
+func (m *NDJSONModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			return m, cmd
+		}
+		switch msg.String() {
+		case "n":
+			cmds = append(cmds, m.showRecord(m.current+1))
+		case "p":
+			cmds = append(cmds, m.showRecord(m.current-1))
+		case "g":
+			cmds = append(cmds, m.showRecord(0))
+		case "G":
+			if m.index != nil {
+				cmds = append(cmds, m.showRecord(m.index.Len()-1))
+			}
+		case "e":
+			if m.index != nil && !m.inMemory {
+				cmds = append(cmds, m.ShowErrorMessage("Files are opened read-only. Press 'c' to copy the record"))
+				break
+			}
+			return m, editor.OpenEditor(m.Content, "jsonl")
+		case "v":
+			content, err := clipboard.Paste()
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else if err := m.SetContent(content); err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage("Pasted. Press 'n'/'p' to browse records"))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+	case editor.EditorFinishedMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(msg.Err.Error()))
+		} else if err := m.SetContent(msg.Content); err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+		}
+	case tea.WindowSizeMsg:
+		cmd = m.HandleWindowSizeMsg(msg)
+		cmds = append(cmds, cmd)
+		if m.index != nil && m.index.Len() > 0 {
+			// The viewport is recreated on the first resize.
+			if err := m.render(); err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			}
+		}
+	}
+
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *NDJSONModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+// SetContent indexes an in-memory JSON Lines document and shows its first
+// record.
+func (m *NDJSONModel) SetContent(content string) error {
+	err := m.SetIndex(ndjson.FromString(content))
+	m.Content = content
+	m.inMemory = true
+	return err
+}
+
+// SetIndex shows the records of an already indexed document, such as a file
+// opened with ndjson.Open.
+func (m *NDJSONModel) SetIndex(index *ndjson.Index) error {
+	m.index = index
+	m.current = 0
+	m.inMemory = false
+	if index.Len() == 0 {
+		m.FormattedContent = ""
+		m.Title = Title
+		m.Viewport.SetContent("")
+		return errors.New("no records found")
+	}
+	// Content gates the scroll indicator and paste hint of the status bar.
+	m.Content = " "
+	return m.render()
+}
+
+func (m *NDJSONModel) showRecord(i int) tea.Cmd {
+	if m.index == nil || m.index.Len() == 0 {
+		return nil
+	}
+	i = max(0, min(i, m.index.Len()-1))
+	if i == m.current && m.FormattedContent != "" {
+		return nil
+	}
+	m.current = i
+	if err := m.render(); err != nil {
+		return m.ShowErrorMessage(err.Error())
+	}
+	return nil
+}
+
+func (m *NDJSONModel) render() error {
+	record, err := m.index.Record(m.current)
+	if err != nil {
+		return err
+	}
+	m.Title = fmt.Sprintf("%s %d/%d", Title, m.current+1, m.index.Len())
+
+	if !json.Valid([]byte(record)) {
+		m.FormattedContent = record
+		m.Viewport.SetContent(record)
+		m.Viewport.GotoTop()
+		return fmt.Errorf("record %d is not valid JSON", m.current+1)
+	}
+
+	m.FormattedContent = js.FormatJSON(record)
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, m.FormattedContent, "json", "terminal", "nord"); err != nil {
+		return err
+	}
+	m.Viewport.SetContent(buf.String())
+	m.Viewport.GotoTop()
+	return nil
+}
+
+func (m *NDJSONModel) helpView() string {
+	col1 := []string{
+		"n/p            next/previous record",
+		"g/G            first/last record",
+		"c              copy current record",
+		"e              edit JSON Lines",
+		"v              paste JSON Lines",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
@@ -0,0 +1,65 @@
+package ndjson
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func TestBrowseRecords(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewNDJSONModel(common)
+	if err := model.SetContent("{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"); err != nil {
+		t.Fatalf("SetContent() error = %v", err)
+	}
+	if !strings.Contains(model.FormattedContent, `"id": 1`) {
+		t.Fatalf("expected the first record, got %q", model.FormattedContent)
+	}
+
+	press := func(key string) {
+		r := []rune(key)[0]
+		next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: key}))
+		model = next.(*NDJSONModel)
+	}
+
+	press("n")
+	press("n")
+	press("n")
+	if model.current != 2 || !strings.Contains(model.FormattedContent, `"id": 3`) {
+		t.Fatalf("expected to stop at the last record, got %d", model.current)
+	}
+	if model.Title != Title+" 3/3" {
+		t.Fatalf("Title = %q", model.Title)
+	}
+
+	press("g")
+	if model.current != 0 {
+		t.Fatalf("expected the first record after g, got %d", model.current)
+	}
+	press("G")
+	press("p")
+	if model.current != 1 {
+		t.Fatalf("expected the second record, got %d", model.current)
+	}
+}
+
+func TestInvalidRecord(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewNDJSONModel(common)
+	if err := model.SetContent("not json\n"); err == nil {
+		t.Fatal("expected an error for an invalid record")
+	}
+	if model.FormattedContent != "not json" {
+		t.Fatalf("expected the raw record, got %q", model.FormattedContent)
+	}
+}
@@ -1,196 +1,352 @@
-package main
+package password
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/password"
+	"github.com/skatkov/devtui/internal/ui"
+
+	tea "charm.land/bubbletea/v2"
 )
 
-// PasswordConfig holds the configuration for password generation.
-type PasswordConfig struct {
-	Length        int
-	CharacterSets []string
-	GeneratedPass string
-	Continue      bool
-}
+const Title = "Password Generator"
 
-type passwordCharacterSet struct {
-	id    string
-	label string
-	chars string
-}
+// resultCount is how many results are generated at once, to pick from.
+const resultCount = 5
+
+const (
+	kindPassword   = "password"
+	kindPassphrase = "passphrase"
+	kindCheck      = "check"
+)
+
+type PasswordModel struct {
+	common *ui.CommonModel
+	form   *huh.Form
 
-var passwordCharacterSets = []passwordCharacterSet{
-	{id: "lowercase", label: "Lowercase (a-z)", chars: "abcdefghijklmnopqrstuvwxyz"},
-	{id: "uppercase", label: "Uppercase (A-Z)", chars: "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
-	{id: "numbers", label: "Numbers (0-9)", chars: "0123456789"},
-	{id: "special", label: "Special (!@#$%^&*)", chars: "!@#$%^&*()_+-=[]{}|;:,.<>?"},
+	kind             string
+	length           string
+	sets             []string
+	excludeAmbiguous bool
+	words            string
+	separator        string
+	capitalize       bool
+	secret           string
+
+	results  []string
+	entropy  float64
+	selected int
+	status   string
+	err      error
+	// report is the analysis of secret, when checking strength.
+	report password.Report
 }
 
-func main() {
-	config := &PasswordConfig{
-		Length: 12, // default length
+func NewPasswordModel(common *ui.CommonModel) *PasswordModel {
+	m := &PasswordModel{
+		common:           common,
+		kind:             kindPassword,
+		length:           strconv.Itoa(common.Config.Int("password.length", 16)),
+		sets:             slices.Clone(password.DefaultSets),
+		excludeAmbiguous: common.Config.Bool("password.exclude-ambiguous", false),
+		words:            strconv.Itoa(common.Config.Int("password.words", 6)),
+		separator:        common.Config.String("password.separator", "-"),
+		capitalize:       common.Config.Bool("password.capitalize", false),
 	}
-
-	for {
-		err := showInterface(config)
-		if err != nil {
-			fmt.Println("Error:", err)
-			return
-		}
+	if common.Config.Bool("password.passphrase", false) {
+		m.kind = kindPassphrase
 	}
+	m.newForm()
+
+	return m
 }
 
-func showInterface(config *PasswordConfig) error {
-	lengthStr := strconv.Itoa(config.Length)
+func (m *PasswordModel) newForm() {
+	accessible, _ := strconv.ParseBool(os.Getenv("ACCESSIBLE"))
 
-	// Create the form
-	form := huh.NewForm(
+	sets := make([]huh.Option[string], len(password.CharacterSets))
+	for i, set := range password.CharacterSets {
+		sets[i] = huh.NewOption(set.Label, set.ID)
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Action").
+				Options(
+					huh.NewOption("Generate a password", kindPassword),
+					huh.NewOption("Generate a passphrase (diceware words)", kindPassphrase),
+					huh.NewOption("Check strength of a password", kindCheck),
+				).
+				Value(&m.kind),
+		),
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Password Length").
-				Value(&lengthStr),
+				Value(&m.length).
+				Validate(positive),
 			huh.NewMultiSelect[string]().
-				Title("Select character sets to include").
-				Options(characterSetOptions()...).
-				Value(&config.CharacterSets),
-		),
-	)
+				Title("Character Sets").
+				Options(sets...).
+				Validate(func(sets []string) error {
+					if len(sets) == 0 {
+						return errors.New("select at least one character set")
+					}
+					return nil
+				}).
+				Value(&m.sets),
+			huh.NewConfirm().
+				Title("Exclude ambiguous characters ("+password.Ambiguous+")?").
+				Value(&m.excludeAmbiguous),
+		).WithHideFunc(func() bool { return m.kind != kindPassword }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Words").
+				Value(&m.words).
+				Validate(positive),
+			huh.NewInput().
+				Title("Separator").
+				Value(&m.separator),
+			huh.NewConfirm().
+				Title("Capitalize words?").
+				Value(&m.capitalize),
+		).WithHideFunc(func() bool { return m.kind != kindPassphrase }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Password").
+				EchoMode(huh.EchoModePassword).
+				Value(&m.secret).
+				DescriptionFunc(func() string { return liveSummary(m.secret) }, &m.secret),
+		).WithHideFunc(func() bool { return m.kind != kindCheck }),
+	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithAccessible(accessible).WithShowHelp(false)
+}
 
-	// Run the form
-	err := form.Run()
-	if err != nil {
-		return err
+func positive(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 {
+		return errors.New("enter a positive number")
 	}
+	return nil
+}
 
-	// Convert length back to int
-	config.Length, err = strconv.Atoi(lengthStr)
-	if err != nil {
-		return fmt.Errorf("invalid length: %w", err)
+// liveSummary rates the password being typed, with the warning about its
+// weakest part.
+func liveSummary(secret string) string {
+	if secret == "" {
+		return "Type to see how hard it is to guess."
+	}
+	report := password.Check(secret)
+	summary := fmt.Sprintf("%s • 10^%.1f guesses • cracked in %s online",
+		report.Strength, report.Guesses, report.CrackTimes[1].Display)
+	if len(report.Feedback) > 0 {
+		summary += "\n" + report.Feedback[0]
 	}
+	return summary
+}
 
-	// Generate password if at least one character set is selected
-	if len(config.CharacterSets) > 0 {
-		config.GeneratedPass, err = generateSecurePassword(config)
-		if err != nil {
-			return fmt.Errorf("failed to generate password: %w", err)
-		}
+// complete acts on the settings of the completed form.
+func (m *PasswordModel) complete() {
+	if m.kind == kindCheck {
+		m.report = password.Check(m.secret)
+		return
+	}
+	m.generate()
+}
 
-		// Show the generated password
-		result := huh.NewForm(
-			huh.NewGroup(
-				huh.NewNote().
-					Title("Generated Password").
-					Description(config.GeneratedPass),
-				huh.NewConfirm().
-					Title("Generate another password?").
-					Value(&config.Continue),
-			),
-		)
+// generate fills the results from the form's settings.
+func (m *PasswordModel) generate() {
+	m.results, m.err, m.selected, m.status = nil, nil, 0, ""
+
+	length, _ := strconv.Atoi(strings.TrimSpace(m.length))
+	words, _ := strconv.Atoi(strings.TrimSpace(m.words))
+	opts := password.Options{Length: length, Sets: m.sets, ExcludeAmbiguous: m.excludeAmbiguous}
+	phraseOpts := password.PassphraseOptions{Words: words, Separator: m.separator, Capitalize: m.capitalize}
 
-		err = result.Run()
+	m.entropy = opts.Entropy()
+	if m.kind == kindPassphrase {
+		m.entropy = phraseOpts.Entropy()
+	}
+	for range resultCount {
+		var (
+			result string
+			err    error
+		)
+		if m.kind == kindPassphrase {
+			result, err = password.GeneratePassphrase(phraseOpts)
+		} else {
+			result, err = password.Generate(opts)
+		}
 		if err != nil {
-			return err
+			m.err = err
+			return
 		}
-
-		return nil
+		m.results = append(m.results, result)
 	}
+}
 
-	// Show error if no character set is selected
-	errorForm := huh.NewForm(
-		huh.NewGroup(
-			huh.NewNote().
-				Title("Error").
-				Description("Please select at least one character set!"),
-		),
-	)
-
-	return errorForm.Run()
+func (m *PasswordModel) Init() tea.Cmd {
+	return m.form.Init()
 }
 
-func characterSetOptions() []huh.Option[string] {
-	options := make([]huh.Option[string], 0, len(passwordCharacterSets))
-	for _, set := range passwordCharacterSets {
-		options = append(options, huh.Option[string]{Key: set.label, Value: set.id})
-	}
+func (m *PasswordModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// Window size is received when starting up and on every resize
+	case tea.WindowSizeMsg:
+		m.common.Width = msg.Width
+		m.common.Height = msg.Height
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, func() tea.Msg {
+				return ui.ReturnToListMsg{
+					Common: m.common,
+				}
+			}
+		}
 
-	return options
-}
+		if m.form.State == huh.StateCompleted {
+			if m.kind == kindCheck {
+				switch msg.String() {
+				case "q":
+					return m, tea.Quit
+				case "s", "r", "enter":
+					m.secret = ""
+					m.newForm()
+					return m, m.form.Init()
+				}
+				return m, nil
+			}
 
-func selectedCharacterSets(ids []string) []passwordCharacterSet {
-	sets := make([]passwordCharacterSet, 0, len(ids))
-	for _, id := range ids {
-		for _, set := range passwordCharacterSets {
-			if set.id == id {
-				sets = append(sets, set)
-				break
+			switch msg.String() {
+			case "q":
+				return m, tea.Quit
+			case "up", "k":
+				m.selected = max(m.selected-1, 0)
+			case "down", "j":
+				m.selected = min(m.selected+1, max(len(m.results)-1, 0))
+			case "r", "enter":
+				m.generate()
+			case "s":
+				m.newForm()
+				return m, m.form.Init()
+			case "c":
+				if m.selected < len(m.results) {
+					if err := clipboard.Copy(m.results[m.selected]); err != nil {
+						m.status = err.Error()
+					} else {
+						m.status = "Copied."
+					}
+				}
 			}
+			return m, nil
 		}
 	}
 
-	return sets
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		if m.form.State == huh.StateCompleted {
+			m.complete()
+		}
+	}
+	return m, cmd
 }
 
-func generateSecurePassword(config *PasswordConfig) (string, error) {
-	selectedSets := selectedCharacterSets(config.CharacterSets)
-	if len(selectedSets) == 0 {
-		return "", errors.New("no valid character sets selected")
-	}
+func (m *PasswordModel) View() tea.View {
+	s := m.common.Styles
+	header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
+		ui.AppTitle,
+		" :: ",
+		lipgloss.NewStyle().Bold(true).Render(Title),
+	))
 
-	var charPool strings.Builder
-	for _, set := range selectedSets {
-		charPool.WriteString(set.chars)
+	if m.form.State != huh.StateCompleted {
+		v := strings.TrimSuffix(m.form.View(), "\n\n")
+		form := lipgloss.NewStyle().Margin(1, 0).Render(v)
+		body := lipgloss.JoinVertical(
+			lipgloss.Top,
+			form,
+			lipgloss.PlaceVertical(
+				m.common.Height-lipgloss.Height(header)-lipgloss.Height(form)-2,
+				lipgloss.Bottom,
+				m.form.Help().ShortHelpView(m.form.KeyBinds()),
+			),
+		)
+		return ui.AltScreenView(s.Base.Render(header + "\n" + body))
 	}
 
-	pool := charPool.String()
-	poolLength := big.NewInt(int64(len(pool)))
-	password := make([]byte, config.Length)
+	if m.kind == kindCheck {
+		help := s.Help.Render("s check another • esc back • q quit")
+		return ui.AltScreenView(s.Base.Render(header + "\n\n" + reportView(m.report) + "\n\n" + help))
+	}
 
-	for i := range config.Length {
-		// Generate cryptographically secure random number
-		n, err := rand.Int(rand.Reader, poolLength)
-		if err != nil {
-			return "", fmt.Errorf("failed to generate random number: %w", err)
+	var body string
+	if m.err != nil {
+		body = fmt.Sprintf("Error generating %s: %v", m.kind, m.err)
+	} else {
+		rows := make([][]string, len(m.results))
+		for i, result := range m.results {
+			rows[i] = []string{strconv.Itoa(i + 1), result}
 		}
-		password[i] = pool[n.Int64()]
+		t := table.New().
+			Border(lipgloss.RoundedBorder()).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				if row == m.selected {
+					return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4")).Bold(true)
+				}
+				return lipgloss.NewStyle()
+			}).
+			Rows(rows...)
+		body = t.String() + "\n" + fmt.Sprintf("Entropy: %.1f bits (%s)", m.entropy, password.StrengthOf(m.entropy))
 	}
-
-	// Ensure at least one character from each selected set
-	err := ensureAllSetsIncluded(password, selectedSets)
-	if err != nil {
-		return "", err
+	if m.status != "" {
+		body += "\n\n" + m.status
 	}
 
-	return string(password), nil
+	help := s.Help.Render("↑/↓ select • c copy • r regenerate • s settings • esc back • q quit")
+	return ui.AltScreenView(s.Base.Render(header + "\n\n" + body + "\n\n" + help))
 }
 
-func ensureAllSetsIncluded(password []byte, selectedSets []passwordCharacterSet) error {
-	if len(password) < len(selectedSets) {
-		return errors.New("password length must be at least the number of character sets")
-	}
+// reportView shows the strength, crack times and patterns of a report,
+// followed by its feedback.
+func reportView(report password.Report) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4")).Bold(true)
 
-	// For each selected character set, ensure at least one character is included
-	positions := make([]int, len(selectedSets))
-	for i := range positions {
-		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(password))))
-		if err != nil {
-			return err
-		}
-		positions[i] = int(n.Int64())
+	summary := table.New().
+		Border(lipgloss.RoundedBorder()).
+		Rows(
+			[]string{"Strength", report.Strength.String()},
+			[]string{"Length", strconv.Itoa(report.Length)},
+			[]string{"Character sets", strings.Join(report.CharacterSets, ", ")},
+			[]string{"Charset entropy", fmt.Sprintf("%.1f bits", report.CharsetEntropy)},
+			[]string{"Guesses", fmt.Sprintf("10^%.1f (%.1f bits)", report.Guesses, report.Entropy)},
+		)
+	crackTimes := table.New().Border(lipgloss.RoundedBorder()).Headers("Attack", "Crack time")
+	for _, ct := range report.CrackTimes {
+		crackTimes.Row(ct.Scenario, ct.Display)
 	}
+	sections := []string{lipgloss.JoinHorizontal(lipgloss.Top, summary.String(), " ", crackTimes.String())}
 
-	for i, set := range selectedSets {
-		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set.chars))))
-		if err != nil {
-			return err
+	if len(report.Matches) > 0 {
+		matches := table.New().Border(lipgloss.RoundedBorder()).Headers("Pattern", "Token", "Detail", "Guesses")
+		for _, m := range report.Matches {
+			matches.Row(m.Pattern, m.Token, m.Detail, fmt.Sprintf("10^%.1f", m.Guesses))
 		}
-		password[positions[i]] = set.chars[n.Int64()]
+		sections = append(sections, titleStyle.Render("Patterns")+"\n"+matches.String())
 	}
-
-	return nil
+	if len(report.Feedback) > 0 {
+		sections = append(sections, titleStyle.Render("Suggestions")+"\n- "+strings.Join(report.Feedback, "\n- "))
+	}
+	return strings.Join(sections, "\n\n")
 }
@@ -0,0 +1,111 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"github.com/skatkov/devtui/internal/password"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func newTestModel() *PasswordModel {
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+	return NewPasswordModel(common)
+}
+
+func TestGenerateResults(t *testing.T) {
+	t.Parallel()
+
+	model := newTestModel()
+	model.length = "12"
+	model.sets = []string{"numbers"}
+	model.generate()
+
+	if model.err != nil {
+		t.Fatalf("unexpected error: %v", model.err)
+	}
+	if len(model.results) != resultCount {
+		t.Fatalf("expected %d results, got %v", resultCount, model.results)
+	}
+	for _, result := range model.results {
+		if len(result) != 12 || strings.Trim(result, "0123456789") != "" {
+			t.Errorf("unexpected password %q", result)
+		}
+	}
+}
+
+func TestPassphraseResults(t *testing.T) {
+	t.Parallel()
+
+	model := newTestModel()
+	model.kind = kindPassphrase
+	model.words = "3"
+	model.separator = "_"
+	model.generate()
+
+	if len(model.results) != resultCount {
+		t.Fatalf("expected %d results, got %v (%v)", resultCount, model.results, model.err)
+	}
+	if words := strings.Split(model.results[0], "_"); len(words) != 3 {
+		t.Errorf("expected 3 words, got %q", model.results[0])
+	}
+	model.form.State = huh.StateCompleted
+	if view := model.View().Content; !strings.Contains(view, "Entropy: 38.8 bits (very strong)") {
+		t.Errorf("view lacks the estimate:\n%s", view)
+	}
+}
+
+func TestRegenerate(t *testing.T) {
+	t.Parallel()
+
+	model := newTestModel()
+	model.generate()
+	model.form.State = huh.StateCompleted
+	first := model.results[0]
+
+	next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: 'r', Text: "r"}))
+	model = next.(*PasswordModel)
+	if model.results[0] == first {
+		t.Errorf("expected r to generate new passwords, still %q", first)
+	}
+}
+
+func TestCheckReport(t *testing.T) {
+	t.Parallel()
+
+	model := newTestModel()
+	model.kind = kindCheck
+	model.secret = "P@ssw0rd"
+	model.complete()
+	model.form.State = huh.StateCompleted
+
+	if model.report.Strength != password.VeryWeak {
+		t.Errorf("strength = %s, want very weak", model.report.Strength)
+	}
+	view := model.View().Content
+	for _, want := range []string{"very weak", "Crack time", `"password" with l33t substitutions`, "Suggestions"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view lacks %q:\n%s", want, view)
+		}
+	}
+
+	next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: 's', Text: "s"}))
+	model = next.(*PasswordModel)
+	if model.form.State == huh.StateCompleted || model.secret != "" {
+		t.Error("expected s to ask for another password")
+	}
+}
+
+func TestLiveSummary(t *testing.T) {
+	t.Parallel()
+
+	if got := liveSummary("qwerty"); !strings.HasPrefix(got, "very weak") {
+		t.Errorf("liveSummary(qwerty) = %q", got)
+	}
+	if got := liveSummary("x7$Kp!2mQz#9"); !strings.HasPrefix(got, "very strong") {
+		t.Errorf("liveSummary = %q", got)
+	}
+}
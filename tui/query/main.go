@@ -0,0 +1,240 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/quick"
+	"github.com/muesli/reflow/truncate"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/converter"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/query"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+const Title = "jq / JSONPath Query"
+
+// headerHeight is the expression input plus the line below it.
+const headerHeight = 2
+
+var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+
+// QueryModel evaluates the expression typed above the result viewport on
+// every keystroke. While typing, the last successful result stays visible.
+type QueryModel struct {
+	ui.BasePagerModel
+	input  textinput.Model
+	format converter.Format
+	value  any
+	err    error
+}
+
+func NewQueryModel(common *ui.CommonModel) *QueryModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = `jq or JSONPath expression, e.g. .items[] | select(.price > 10) or $..name`
+	input.SetValue(".")
+	input.CursorEnd()
+
+	return &QueryModel{
+		BasePagerModel: ui.NewBasePagerModel(common, Title),
+		input:          input,
+	}
+}
+
+func (m *QueryModel) Init() tea.Cmd {
+	return m.input.Focus()
+}
+
+func (m *QueryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m, func() tea.Msg { return ui.ReturnToListMsg{Common: m.Common} }
+		case "tab":
+			if m.input.Focused() {
+				m.input.Blur()
+				return m, nil
+			}
+			return m, m.input.Focus()
+		}
+
+		if m.input.Focused() {
+			if msg.String() == "enter" {
+				m.input.Blur()
+				return m, nil
+			}
+			m.input, cmd = m.input.Update(msg)
+			m.evaluate()
+			return m, cmd
+		}
+
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			m.resize()
+			return m, cmd
+		}
+		switch msg.String() {
+		case "/", "i":
+			return m, m.input.Focus()
+		case "e":
+			return m, editor.OpenEditor(m.Content, string(m.formatOrJSON()))
+		case "v":
+			content, err := clipboard.Paste()
+			if err == nil {
+				err = m.SetContent(content)
+			}
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage("Pasted "+string(m.format)+". Press tab to edit the expression"))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+	case editor.EditorFinishedMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(msg.Err.Error()))
+		} else if err := m.SetContent(msg.Content); err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+		}
+	case tea.WindowSizeMsg:
+		cmds = append(cmds, m.HandleWindowSizeMsg(msg))
+		m.input.SetWidth(msg.Width - 4)
+		m.resize()
+		m.evaluate()
+	}
+
+	if !m.input.Focused() {
+		m.Viewport, cmd = m.Viewport.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *QueryModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, m.input.View())
+	switch {
+	case m.err != nil:
+		fmt.Fprintln(&b, errorStyle.Render(truncateLine(m.err.Error(), m.Common.Width)))
+	case m.format != "":
+		fmt.Fprintln(&b, ui.Indent("input: "+string(m.format), 2))
+	default:
+		fmt.Fprintln(&b)
+	}
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+// SetContent decodes the data to query, detecting its format.
+func (m *QueryModel) SetContent(content string) error {
+	format, err := converter.Detect(content)
+	if err != nil {
+		return err
+	}
+	value, err := converter.Decode(content, format)
+	if err != nil {
+		return err
+	}
+
+	m.Content = content
+	m.format = format
+	m.value = value
+	m.evaluate()
+	return m.err
+}
+
+// SetExpression replaces the expression being evaluated.
+func (m *QueryModel) SetExpression(expr string) {
+	m.input.SetValue(expr)
+	m.input.CursorEnd()
+	m.evaluate()
+}
+
+// StepArgs returns the expression, the argument of the query command that
+// does what the model shows.
+func (m *QueryModel) StepArgs() []string {
+	return []string{m.input.Value()}
+}
+
+func (m *QueryModel) evaluate() {
+	if m.format == "" {
+		return
+	}
+
+	q, err := query.Compile(m.input.Value())
+	if err != nil {
+		m.err = err
+		return
+	}
+	results, err := q.Run(m.value)
+	if err != nil {
+		m.err = err
+		return
+	}
+	out, err := query.Format(results, converter.FormatJSON, query.OutputOptions{})
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	m.err = nil
+	m.FormattedContent = out
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, out, "json", "terminal", "nord"); err != nil {
+		m.Viewport.SetContent(out)
+		return
+	}
+	m.Viewport.SetContent(buf.String())
+}
+
+// resize leaves room for the expression header above the viewport.
+func (m *QueryModel) resize() {
+	m.SetSize(m.Common.Width, m.Common.Height)
+	m.Viewport.SetHeight(max(0, m.Viewport.Height()-headerHeight))
+}
+
+func (m *QueryModel) formatOrJSON() converter.Format {
+	if m.format == "" {
+		return converter.FormatJSON
+	}
+	return m.format
+}
+
+func truncateLine(s string, width int) string {
+	s, _, _ = strings.Cut(s, "\n")
+	return ui.Indent(truncate.StringWithTail(s, uint(max(0, width-2)), ui.Ellipsis), 2)
+}
+
+func (m *QueryModel) helpView() string {
+	col1 := []string{
+		"tab/enter      leave/edit expression",
+		"c              copy result",
+		"e              edit input data",
+		"v              paste input data",
+		"esc            back to menu",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
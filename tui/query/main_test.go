@@ -0,0 +1,45 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func TestTypingEvaluatesLive(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewQueryModel(common)
+	model.input.Focus()
+	if err := model.SetContent("name: devtui\nversion: 2\n"); err != nil {
+		t.Fatalf("SetContent() error = %v", err)
+	}
+	if !strings.Contains(model.FormattedContent, `"version": 2`) {
+		t.Fatalf("expected the whole document, got %q", model.FormattedContent)
+	}
+
+	for _, r := range "name" {
+		next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+		model = next.(*QueryModel)
+	}
+	if model.err != nil {
+		t.Fatalf("unexpected error: %v", model.err)
+	}
+	if model.FormattedContent != "\"devtui\"\n" {
+		t.Fatalf("FormattedContent = %q", model.FormattedContent)
+	}
+
+	next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: '|', Text: "|"}))
+	model = next.(*QueryModel)
+	if model.err == nil {
+		t.Fatal("expected an error for an incomplete expression")
+	}
+	if model.FormattedContent != "\"devtui\"\n" {
+		t.Fatalf("expected the last result to stay visible, got %q", model.FormattedContent)
+	}
+}
@@ -0,0 +1,127 @@
+package recipe
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/editor"
+	"github.com/skatkov/devtui/internal/recipe"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+// RecipeModel runs a saved recipe on the pasted or edited input and shows
+// the output of its last step.
+type RecipeModel struct {
+	ui.BasePagerModel
+	recipe recipe.Recipe
+	run    recipe.StepFunc
+}
+
+func NewRecipeModel(common *ui.CommonModel, r recipe.Recipe, run recipe.StepFunc) *RecipeModel {
+	return &RecipeModel{
+		BasePagerModel: ui.NewBasePagerModel(common, "Recipe: "+r.Name),
+		recipe:         r,
+		run:            run,
+	}
+}
+
+func (m *RecipeModel) Init() tea.Cmd {
+	return m.BasePagerModel.Init()
+}
+
+func (m *RecipeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if cmd, handled := m.HandleCommonKeys(msg); handled {
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "e":
+			return m, editor.OpenEditor(m.Content, "txt")
+		case "v":
+			content, err := clipboard.Paste()
+			if err == nil {
+				err = m.SetContent(content)
+			}
+
+			if err != nil {
+				cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+			} else {
+				cmds = append(cmds, m.ShowStatusMessage("Recipe ran. Press 'c' to copy the output."))
+			}
+		}
+	case ui.StatusMessageTimeoutMsg:
+		m.State = ui.PagerStateBrowse
+
+	case editor.EditorFinishedMsg:
+		if msg.Err != nil {
+			return m, m.ShowErrorMessage(msg.Err.Error())
+		}
+
+		if err := m.SetContent(msg.Content); err != nil {
+			cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+		} else {
+			cmds = append(cmds, m.ShowStatusMessage("Recipe ran. Press 'c' to copy the output."))
+		}
+	case tea.WindowSizeMsg:
+		cmd = m.HandleWindowSizeMsg(msg)
+		cmds = append(cmds, cmd)
+		m.Viewport.SetContent(m.FormattedContent)
+	}
+
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *RecipeModel) View() tea.View {
+	var b strings.Builder
+
+	fmt.Fprint(&b, m.Viewport.View()+"\n")
+	fmt.Fprint(&b, m.StatusBarView())
+
+	if m.ShowHelp {
+		fmt.Fprint(&b, "\n"+m.helpView())
+	}
+
+	return m.NewView(b.String())
+}
+
+// SetContent runs the recipe on content.
+func (m *RecipeModel) SetContent(content string) error {
+	m.Content = content
+
+	if strings.TrimSpace(content) == "" {
+		m.FormattedContent = ""
+		m.Viewport.SetContent("")
+		return nil
+	}
+
+	output, err := m.recipe.Run([]byte(content), m.run)
+	if err != nil {
+		return err
+	}
+	m.FormattedContent = string(output)
+	m.Viewport.SetContent(m.FormattedContent)
+	return nil
+}
+
+func (m *RecipeModel) helpView() string {
+	col1 := []string{
+		"c              copy output",
+		"e              edit input",
+		"v              paste input",
+		"|              pipe output to a tool",
+		"q/ctrl+c       quit",
+	}
+
+	return m.FormatHelpColumns(col1)
+}
@@ -6,13 +6,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/adrg/xdg"
+	"github.com/skatkov/devtui/internal/clipboard"
+	"github.com/skatkov/devtui/internal/detect"
+	"github.com/skatkov/devtui/internal/history"
+	"github.com/skatkov/devtui/internal/recipe"
 	"github.com/skatkov/devtui/internal/ui"
 	base64decoder "github.com/skatkov/devtui/tui/base64-decoder"
 	base64encoder "github.com/skatkov/devtui/tui/base64-encoder"
@@ -20,7 +25,9 @@ import (
 	"github.com/skatkov/devtui/tui/css"
 	"github.com/skatkov/devtui/tui/csv2json"
 	"github.com/skatkov/devtui/tui/csv2md"
+	"github.com/skatkov/devtui/tui/diff"
 	graphqlquery "github.com/skatkov/devtui/tui/graphql-query"
+	"github.com/skatkov/devtui/tui/hash"
 	"github.com/skatkov/devtui/tui/html"
 	"github.com/skatkov/devtui/tui/iban"
 	js "github.com/skatkov/devtui/tui/json"
@@ -28,8 +35,14 @@ import (
 	"github.com/skatkov/devtui/tui/json2toon"
 	"github.com/skatkov/devtui/tui/jsonrepair"
 	"github.com/skatkov/devtui/tui/jsonstruct"
+	"github.com/skatkov/devtui/tui/jwt"
 	"github.com/skatkov/devtui/tui/markdown"
+	"github.com/skatkov/devtui/tui/ndjson"
 	"github.com/skatkov/devtui/tui/numbers"
+	"github.com/skatkov/devtui/tui/password"
+	"github.com/skatkov/devtui/tui/query"
+	recipetui "github.com/skatkov/devtui/tui/recipe"
+	"github.com/skatkov/devtui/tui/timestamp"
 	"github.com/skatkov/devtui/tui/toml"
 	"github.com/skatkov/devtui/tui/toml2json"
 	"github.com/skatkov/devtui/tui/tsv2md"
@@ -44,30 +57,66 @@ import (
 const listHeight = 15
 
 type listModel struct {
-	list   list.Model
-	err    string
-	common *ui.CommonModel
-	items  []MenuOption
+	list    list.Model
+	err     string
+	common  *ui.CommonModel
+	items   []MenuOption
+	state   *menuState
+	palette *paletteModel
+
+	// detectClipboard suggests tools for the clipboard once the root screen
+	// starts.
+	detectClipboard bool
+	// current is the id of the tool opened last.
+	current string
+	// content is the clipboard or piped text that hints were made for;
+	// suggested tools are opened with it.
+	content string
+	hints   map[string]string
 }
 
+// maxHints is how many suggested tools are highlighted in the list.
+const maxHints = 3
+
 var (
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
 	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("170"))
+	hintStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	paginationStyle   = list.DefaultStyles(true).PaginationStyle.PaddingLeft(4)
 	helpStyle         = list.DefaultStyles(true).HelpStyle.PaddingLeft(2).PaddingBottom(1)
 )
 
 type MenuOption struct {
-	id         string
-	title      string
-	model      func() tea.Model
-	usageCount int
+	id          string
+	title       string
+	description string
+	// aliases and keywords are only used for searching, so that for example
+	// "b64" finds the Base64 tools and "epoch" the timestamp converter.
+	aliases  []string
+	keywords []string
+	// steps are the commands that do what the tool does, used to record a
+	// chain of tools as a recipe. Tools without them can't be recorded.
+	steps []recipe.Step
+	model func() tea.Model
+	// takesContent is set for tools whose model is a contentSetter, which
+	// can be opened with piped or suggested content.
+	takesContent bool
+	usageCount   int
+}
+
+// step is the recipe step running the devtui command name with args.
+func step(name string, args ...string) []recipe.Step {
+	return []recipe.Step{{Command: name, Args: args}}
 }
 
 func (i MenuOption) FilterValue() string { return i.title }
 func (i MenuOption) Title() string       { return i.title }
 
-type itemDelegate struct{}
+type itemDelegate struct {
+	state *menuState
+	// hints maps suggested tools to why they were suggested.
+	hints map[string]string
+}
 
 func (d itemDelegate) Height() int                             { return 1 }
 func (d itemDelegate) Spacing() int                            { return 0 }
@@ -78,7 +127,14 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		return
 	}
 
-	str := fmt.Sprintf("%s %s", "•", i.title)
+	bullet := "•"
+	if d.state.isFavorite(i.id) {
+		bullet = "★"
+	}
+	str := fmt.Sprintf("%s %s", bullet, i.title)
+	if reason, ok := d.hints[i.id]; ok {
+		str += "  " + hintStyle.Render("looks like "+reason)
+	}
 
 	fn := itemStyle.Render
 	if index == m.Index() {
@@ -93,138 +149,352 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	}
 }
 
+// addressable returns a pointer to a tool model that is passed by value, so
+// that its pointer methods such as SetContent can be reached through
+// tea.Model.
+func addressable[T any, PT interface {
+	*T
+	tea.Model
+}](model T) tea.Model {
+	return PT(&model)
+}
+
 func getMenuOptions(common *ui.CommonModel) []MenuOption {
 	return []MenuOption{
 		{
-			id:    "base64-encoder",
-			title: base64encoder.Title,
-			model: func() tea.Model { return base64encoder.NewBase64Model(common) },
+			id:           "base64-encoder",
+			title:        base64encoder.Title,
+			description:  "Encode text as Base64",
+			aliases:      []string{"b64", "b64enc"},
+			keywords:     []string{"encode", "base64"},
+			steps:        step("base64"),
+			model:        func() tea.Model { return addressable(base64encoder.NewBase64Model(common)) },
+			takesContent: true,
+		},
+		{
+			id:           "base64-decoder",
+			title:        base64decoder.Title,
+			description:  "Decode Base64 back to text",
+			aliases:      []string{"b64d", "b64dec"},
+			keywords:     []string{"decode", "base64"},
+			steps:        step("base64", "--decode"),
+			model:        func() tea.Model { return addressable(base64decoder.NewBase64Model(common)) },
+			takesContent: true,
+		},
+		{
+			id:           "uuiddecode",
+			title:        uuiddecode.Title,
+			description:  "Inspect the version, variant and time of a UUID",
+			aliases:      []string{"uuid"},
+			keywords:     []string{"guid", "decode", "inspect"},
+			steps:        step("uuiddecode"),
+			model:        func() tea.Model { return uuiddecode.NewUUIDDecodeModel(common) },
+			takesContent: true,
+		},
+		{
+			id:          "numbers",
+			title:       numbers.Title,
+			description: "Convert numbers between binary, octal, decimal and hex",
+			aliases:     []string{"base", "hex"},
+			keywords:    []string{"binary", "octal", "decimal", "radix"},
+			steps:       step("numbers"),
+			model:       func() tea.Model { return addressable(numbers.NewNumberModel(common)) },
 		},
 		{
-			id:    "base64-decoder",
-			title: base64decoder.Title,
-			model: func() tea.Model { return base64decoder.NewBase64Model(common) },
+			id:           "hash",
+			title:        hash.Title,
+			description:  "Compute MD5, SHA and BLAKE digests",
+			aliases:      []string{"sha", "md5"},
+			keywords:     []string{"digest", "checksum", "sha256", "blake3"},
+			steps:        step("hash"),
+			model:        func() tea.Model { return hash.NewHashModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "uuiddecode",
-			title: uuiddecode.Title,
-			model: func() tea.Model { return uuiddecode.NewUUIDDecodeModel(common) },
+			id:           "timestamp",
+			title:        timestamp.Title,
+			description:  "Convert Unix timestamps to dates and back",
+			aliases:      []string{"unix", "time"},
+			keywords:     []string{"epoch", "date", "datetime", "rfc3339"},
+			steps:        step("timestamp"),
+			model:        func() tea.Model { return timestamp.NewTimestampModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "numbers",
-			title: numbers.Title,
-			model: func() tea.Model { return numbers.NewNumberModel(common) },
+			id:           "jwt",
+			title:        jwt.Title,
+			description:  "Decode JSON Web Tokens and inspect their claims",
+			aliases:      []string{"token"},
+			keywords:     []string{"bearer", "claims", "auth"},
+			steps:        step("jwt"),
+			model:        func() tea.Model { return jwt.NewJWTModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "uuidgenerate",
-			title: uuidgenerate.Title,
-			model: func() tea.Model { return uuidgenerate.NewUUIDGenerateModel(common) },
+			id:          "uuidgenerate",
+			title:       uuidgenerate.Title,
+			description: "Generate UUIDs, ULIDs, NanoIDs and other IDs in bulk",
+			aliases:     []string{"uuidgen", "guid"},
+			keywords:    []string{"generate", "random", "ulid", "ksuid", "nanoid", "cuid2", "snowflake"},
+			model:       func() tea.Model { return uuidgenerate.NewUUIDGenerateModel(common) },
 		},
 		{
-			id:    "iban",
-			title: iban.Title,
-			model: func() tea.Model { return iban.NewIBANGenerateModel(common) },
+			id:          "iban",
+			title:       iban.Title,
+			description: "Generate valid IBANs for testing",
+			keywords:    []string{"bank", "account", "generate"},
+			model:       func() tea.Model { return iban.NewIBANGenerateModel(common) },
 		},
 		{
-			id:    "cron",
-			title: cron.Title,
-			model: func() tea.Model { return cron.NewCronModel(common) },
+			id:          "password",
+			title:       password.Title,
+			description: "Generate passwords and passphrases, or check their strength",
+			aliases:     []string{"pwgen", "passphrase"},
+			keywords:    []string{"secret", "generate", "random", "diceware", "strength", "zxcvbn"},
+			model:       func() tea.Model { return password.NewPasswordModel(common) },
 		},
 		{
-			id:    "json",
-			title: js.Title,
-			model: func() tea.Model { return js.NewJsonModel(common) },
+			id:           "cron",
+			title:        cron.Title,
+			description:  "Explain cron expressions and list their next runs",
+			aliases:      []string{"crontab"},
+			keywords:     []string{"schedule", "job"},
+			model:        func() tea.Model { return cron.NewCronModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "yaml",
-			title: yaml.Title,
-			model: func() tea.Model { return yaml.NewYamlModel(common) },
+			id:           "json",
+			title:        js.Title,
+			description:  "Format and browse JSON",
+			aliases:      []string{"jsonfmt"},
+			keywords:     []string{"pretty", "format", "prettify"},
+			steps:        step("jsonfmt"),
+			model:        func() tea.Model { return addressable(js.NewJsonModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "markdown",
-			title: markdown.Title,
-			model: func() tea.Model { return markdown.NewMarkdownModel(common) },
+			id:           "ndjson",
+			title:        ndjson.Title,
+			description:  "Browse JSON Lines files record by record",
+			aliases:      []string{"jsonl", "jsonlines"},
+			keywords:     []string{"logs", "records", "stream"},
+			model:        func() tea.Model { return ndjson.NewNDJSONModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "jsonstruct",
-			title: jsonstruct.Title,
-			model: func() tea.Model { return jsonstruct.NewJsonStructModel(common) },
+			id:           "query",
+			title:        query.Title,
+			description:  "Run jq or JSONPath queries on structured data",
+			aliases:      []string{"jq", "jsonpath"},
+			keywords:     []string{"filter", "select", "path"},
+			steps:        step("query"),
+			model:        func() tea.Model { return query.NewQueryModel(common) },
+			takesContent: true,
 		},
 		{
-			id:    "yamlstruct",
-			title: yamlstruct.Title,
-			model: func() tea.Model { return yamlstruct.NewYamlStructModel(common) },
+			id:          "diff",
+			title:       diff.Title,
+			description: "Compare two documents structurally",
+			aliases:     []string{"compare"},
+			keywords:    []string{"patch", "changes", "delta"},
+			model:       func() tea.Model { return diff.NewDiffModel(common) },
 		},
 		{
-			id:    "csv2json",
-			title: csv2json.Title,
-			model: func() tea.Model { return csv2json.NewCSVJsonModel(common) },
+			id:           "yaml",
+			title:        yaml.Title,
+			description:  "Format and browse YAML",
+			aliases:      []string{"yml", "yamlfmt"},
+			keywords:     []string{"pretty", "format"},
+			steps:        step("yamlfmt"),
+			model:        func() tea.Model { return addressable(yaml.NewYamlModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "toml2json",
-			title: toml2json.Title,
-			model: func() tea.Model { return toml2json.NewTomlJsonModel(common) },
+			id:           "markdown",
+			title:        markdown.Title,
+			description:  "Render Markdown in the terminal",
+			aliases:      []string{"md"},
+			keywords:     []string{"preview", "render"},
+			model:        func() tea.Model { return addressable(markdown.NewMarkdownModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "json2toml",
-			title: json2toml.Title,
-			model: func() tea.Model { return json2toml.NewJsonTomlModel(common) },
+			id:           "jsonstruct",
+			title:        jsonstruct.Title,
+			description:  "Generate Go, TypeScript, Rust or Python types from JSON",
+			aliases:      []string{"json2go"},
+			keywords:     []string{"types", "struct", "typescript", "rust", "python", "schema"},
+			steps:        step("jsonstruct"),
+			model:        func() tea.Model { return addressable(jsonstruct.NewJsonStructModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "json2toon",
-			title: json2toon.Title,
-			model: func() tea.Model { return json2toon.NewJsonToonModel(common) },
+			id:           "yamlstruct",
+			title:        yamlstruct.Title,
+			description:  "Generate Go, TypeScript, Rust or Python types from YAML",
+			aliases:      []string{"yaml2go"},
+			keywords:     []string{"types", "struct", "typescript", "rust", "python"},
+			steps:        step("yamlstruct"),
+			model:        func() tea.Model { return addressable(yamlstruct.NewYamlStructModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "toml",
-			title: toml.Title,
-			model: func() tea.Model { return toml.NewTomlFormatModel(common) },
+			id:           "csv2json",
+			title:        csv2json.Title,
+			description:  "Convert CSV tables to JSON",
+			keywords:     []string{"convert", "spreadsheet", "table"},
+			steps:        step("csv2json"),
+			model:        func() tea.Model { return addressable(csv2json.NewCSVJsonModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "html",
-			title: html.Title,
-			model: func() tea.Model { return html.NewHTMLFormatterModel(common) },
+			id:           "toml2json",
+			title:        toml2json.Title,
+			description:  "Convert TOML to JSON",
+			keywords:     []string{"convert"},
+			steps:        step("toml2json"),
+			model:        func() tea.Model { return addressable(toml2json.NewTomlJsonModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "xml",
-			title: xml.Title,
-			model: func() tea.Model { return xml.NewXMLFormatterModel(common) },
+			id:           "json2toml",
+			title:        json2toml.Title,
+			description:  "Convert JSON to TOML",
+			keywords:     []string{"convert"},
+			steps:        step("json2toml"),
+			model:        func() tea.Model { return addressable(json2toml.NewJsonTomlModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "css",
-			title: css.Title,
-			model: func() tea.Model { return css.NewCSSFormatterModel(common) },
+			id:           "json2toon",
+			title:        json2toon.Title,
+			description:  "Convert JSON to token-efficient TOON",
+			aliases:      []string{"toon"},
+			keywords:     []string{"convert", "llm", "tokens"},
+			steps:        step("json2toon"),
+			model:        func() tea.Model { return addressable(json2toon.NewJsonToonModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "graphql-query",
-			title: graphqlquery.Title,
-			model: func() tea.Model { return graphqlquery.NewGraphQLQueryModel(common) },
+			id:           "toml",
+			title:        toml.Title,
+			description:  "Format and browse TOML",
+			aliases:      []string{"tomlfmt"},
+			keywords:     []string{"pretty", "format"},
+			steps:        step("tomlfmt"),
+			model:        func() tea.Model { return addressable(toml.NewTomlFormatModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "csv2md",
-			title: csv2md.Title,
-			model: func() tea.Model { return csv2md.NewCSV2MDModel(common) },
+			id:           "html",
+			title:        html.Title,
+			description:  "Format HTML markup",
+			aliases:      []string{"htmlfmt"},
+			keywords:     []string{"pretty", "format", "markup"},
+			steps:        step("htmlfmt"),
+			model:        func() tea.Model { return addressable(html.NewHTMLFormatterModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "tsv2md",
-			title: tsv2md.Title,
-			model: func() tea.Model { return tsv2md.NewTSV2MDModel(common) },
+			id:           "xml",
+			title:        xml.Title,
+			description:  "Format XML documents",
+			aliases:      []string{"xmlfmt"},
+			keywords:     []string{"pretty", "format"},
+			steps:        step("xmlfmt"),
+			model:        func() tea.Model { return addressable(xml.NewXMLFormatterModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "url-extractor",
-			title: urlextractor.Title,
-			model: func() tea.Model { return urlextractor.NewURLExtractorModel(common) },
+			id:           "css",
+			title:        css.Title,
+			description:  "Format CSS stylesheets",
+			aliases:      []string{"cssfmt"},
+			keywords:     []string{"pretty", "format", "stylesheet"},
+			steps:        step("cssfmt"),
+			model:        func() tea.Model { return addressable(css.NewCSSFormatterModel(common)) },
+			takesContent: true,
 		},
 		{
-			id:    "jsonrepair",
-			title: jsonrepair.Title,
-			model: func() tea.Model { return jsonrepair.NewJSONRepairModel(common) },
+			id:           "graphql-query",
+			title:        graphqlquery.Title,
+			description:  "Format GraphQL queries",
+			aliases:      []string{"gql", "graphql"},
+			keywords:     []string{"pretty", "format", "query"},
+			steps:        step("gqlquery"),
+			model:        func() tea.Model { return addressable(graphqlquery.NewGraphQLQueryModel(common)) },
+			takesContent: true,
+		},
+		{
+			id:           "csv2md",
+			title:        csv2md.Title,
+			description:  "Convert CSV to a Markdown table",
+			keywords:     []string{"convert", "table"},
+			steps:        step("csv2md"),
+			model:        func() tea.Model { return addressable(csv2md.NewCSV2MDModel(common)) },
+			takesContent: true,
+		},
+		{
+			id:           "tsv2md",
+			title:        tsv2md.Title,
+			description:  "Convert TSV to a Markdown table",
+			keywords:     []string{"convert", "table"},
+			steps:        step("tsv2md"),
+			model:        func() tea.Model { return addressable(tsv2md.NewTSV2MDModel(common)) },
+			takesContent: true,
+		},
+		{
+			id:           "url-extractor",
+			title:        urlextractor.Title,
+			description:  "Extract every URL from text",
+			aliases:      []string{"urls"},
+			keywords:     []string{"links", "extract"},
+			steps:        step("urls"),
+			model:        func() tea.Model { return urlextractor.NewURLExtractorModel(common) },
+			takesContent: true,
+		},
+		{
+			id:           "jsonrepair",
+			title:        jsonrepair.Title,
+			description:  "Fix broken or truncated JSON",
+			aliases:      []string{"repair"},
+			keywords:     []string{"fix", "broken", "invalid"},
+			steps:        step("jsonrepair"),
+			model:        func() tea.Model { return addressable(jsonrepair.NewJSONRepairModel(common)) },
+			takesContent: true,
 		},
 	}
 }
 
-func newListModel(common *ui.CommonModel) *listModel {
+// recipeOptions lists the saved recipes as tools running them with run.
+func recipeOptions(common *ui.CommonModel, recipes []recipe.Recipe, run recipe.StepFunc) []MenuOption {
+	options := make([]MenuOption, len(recipes))
+	for i, r := range recipes {
+		options[i] = MenuOption{
+			id:           "recipe:" + r.Name,
+			title:        "Recipe: " + r.Name,
+			description:  r.Summary(),
+			aliases:      []string{r.Name},
+			keywords:     []string{"recipe", "pipeline"},
+			steps:        r.Steps,
+			model:        func() tea.Model { return recipetui.NewRecipeModel(common, r, run) },
+			takesContent: true,
+		}
+	}
+	return options
+}
+
+// newListModel creates the tool list. With runStep, saved recipes are
+// listed as tools too.
+func newListModel(common *ui.CommonModel, runStep recipe.StepFunc) *listModel {
 	menuOptions := getMenuOptions(common)
+	if runStep != nil {
+		recipes, err := recipe.List()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load recipes: %v\n", err)
+		}
+		menuOptions = append(menuOptions, recipeOptions(common, recipes, runStep)...)
+	}
 
 	// Load usage stats
 	stats, err := loadUsageStats()
@@ -238,10 +508,13 @@ func newListModel(common *ui.CommonModel) *listModel {
 		menuOptions[i].usageCount = stats[menuOptions[i].id]
 	}
 
-	// Sort items by usage count (descending)
-	sort.Slice(menuOptions, func(i, j int) bool {
-		return menuOptions[i].usageCount > menuOptions[j].usageCount
-	})
+	state, err := loadMenuState()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load menu state: %v\n", err)
+	}
+
+	// Favorites first, then recently used tools, then the rest by usage
+	menuOptions = state.order(menuOptions)
 
 	// Convert to list.Item interface for bubbles/list
 	listItems := make([]list.Item, len(menuOptions))
@@ -249,23 +522,36 @@ func newListModel(common *ui.CommonModel) *listModel {
 		listItems[i] = item
 	}
 
-	delegate := itemDelegate{}
+	hints := map[string]string{}
+	delegate := itemDelegate{state: &state, hints: hints}
 	l := list.New(listItems, delegate, 20, listHeight)
 	l.Title = ui.AppTitle
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = l.Styles.Title.MarginTop(1)
+	l.StatusMessageLifetime = 10 * time.Second
 
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{paletteKey, favoriteKey}
+	}
+	l.AdditionalFullHelpKeys = l.AdditionalShortHelpKeys
 
 	return &listModel{
 		list:   l,
 		common: common,
 		items:  menuOptions,
+		state:  &state,
+		hints:  hints,
 	}
 }
 
+var (
+	paletteKey  = key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "palette"))
+	favoriteKey = key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "pin"))
+)
+
 func (m listModel) Init() tea.Cmd {
 	return nil
 }
@@ -276,31 +562,59 @@ func (m *listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.common.Width = msg.Width
 		m.common.Height = msg.Height
 		m.list.SetSize(msg.Width, msg.Height)
+		if m.palette != nil {
+			m.palette.Update(msg)
+		}
+		return m, nil
+
+	case paletteSelectMsg:
+		m.palette = nil
+		return m.open(msg.id)
+
+	case paletteCloseMsg:
+		m.palette = nil
+		return m, nil
+
+	case clipboardMsg:
+		m.suggest(msg.content, detect.Detect(msg.content), "Clipboard", false)
+		return m, nil
+
+	case paletteFavoriteMsg:
+		m.toggleFavorite(msg.id)
+		if m.palette != nil {
+			m.palette.refresh()
+		}
 		return m, nil
+	}
+
+	if m.palette != nil {
+		if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, m.palette.Update(msg)
+	}
 
-	case tea.KeyPressMsg:
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch keypress := msg.String(); keypress {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 
+		case "ctrl+p":
+			m.palette = newPaletteModel(m.items, m.state, m.common.Width, m.common.Height)
+			return m, m.palette.Init()
+
+		case "*":
+			if m.list.FilterState() == list.Filtering {
+				break
+			}
+			if i, ok := m.list.SelectedItem().(MenuOption); ok {
+				m.toggleFavorite(i.id)
+			}
+			return m, nil
+
 		case "enter":
-			m.common.LastSelectedItem = m.list.Index()
-			i, ok := m.list.SelectedItem().(MenuOption)
-			if ok {
-				// Find the selected item in our items slice and increment usage
-				for idx := range m.items {
-					if m.items[idx].id == i.id {
-						m.items[idx].usageCount++
-						break
-					}
-				}
-
-				// Save updated usage stats
-				if err := saveUsageStats(m.items); err != nil {
-					m.err = fmt.Sprintf("Failed to save usage stats: %v", err)
-				}
-				newScreen := i.model()
-				return newScreen, newScreen.Init()
+			if i, ok := m.list.SelectedItem().(MenuOption); ok {
+				return m.open(i.id)
 			}
 		}
 	}
@@ -310,18 +624,185 @@ func (m *listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// open switches to the tool with the given id, recording its use.
+func (m *listModel) open(id string) (tea.Model, tea.Cmd) {
+	newScreen := m.launch(id)
+	if newScreen == nil {
+		return m, nil
+	}
+	return newScreen, newScreen.Init()
+}
+
+// launch creates the tool with the given id and records its use. It returns
+// nil for an unknown id.
+func (m *listModel) launch(id string) tea.Model {
+	idx := m.indexOf(id)
+	if idx < 0 {
+		return nil
+	}
+	m.items[idx].usageCount++
+	selected := m.items[idx]
+	m.current = id
+
+	// Save updated usage stats
+	if err := saveUsageStats(m.items); err != nil {
+		m.err = fmt.Sprintf("Failed to save usage stats: %v", err)
+	}
+	m.state.visit(id)
+	if err := saveMenuState(*m.state); err != nil {
+		m.err = fmt.Sprintf("Failed to save menu state: %v", err)
+	}
+
+	// The tool moves into the recently used section, so remember where it
+	// ends up for when we return to the list.
+	m.RefreshOrder()
+	m.common.LastSelectedItem = m.indexOf(id)
+
+	newScreen := selected.model()
+	m.restoreHistory(id, newScreen)
+	if _, hinted := m.hints[id]; hinted {
+		if setter, ok := newScreen.(contentSetter); ok {
+			// The detector can be wrong; the tool then opens empty, as it
+			// would without a suggestion.
+			if err := setter.SetContent(m.content); err != nil {
+				_ = setter.SetContent("")
+			}
+		}
+	}
+	return newScreen
+}
+
+// restoreHistory attaches the tool's history and reopens the tool with its
+// last input.
+func (m *listModel) restoreHistory(id string, screen tea.Model) {
+	tool, ok := screen.(historyKeeper)
+	if !ok {
+		return
+	}
+	log, err := history.ForTool(m.common.Config, id)
+	if err != nil {
+		m.err = fmt.Sprintf("Failed to open history: %v", err)
+		return
+	}
+	tool.SetHistory(log)
+
+	if last, ok := log.Last(); ok {
+		// Input the tool no longer accepts, say after an upgrade, is
+		// dropped rather than shown as an error on opening.
+		if err := tool.SetContent(last.Input); err != nil {
+			_ = tool.SetContent("")
+		}
+	}
+}
+
+// historyKeeper is implemented by tools that keep a history of their
+// inputs.
+type historyKeeper interface {
+	contentSetter
+	SetHistory(log *history.Log)
+}
+
+// pipeTargets returns the tools that can be opened with content.
+func (m *listModel) pipeTargets() []MenuOption {
+	var targets []MenuOption
+	for _, item := range m.items {
+		if item.takesContent {
+			targets = append(targets, item)
+		}
+	}
+	return targets
+}
+
+// contentSetter is implemented by tools that can be opened with content.
+type contentSetter interface {
+	SetContent(content string) error
+}
+
+// clipboardMsg carries the clipboard contents read on startup.
+type clipboardMsg struct{ content string }
+
+// readClipboard reads the clipboard for suggestions. A clipboard that can't
+// be read is treated as empty, since it only means there is nothing to
+// suggest.
+func readClipboard() tea.Msg {
+	content, err := clipboard.Paste()
+	if err != nil {
+		return clipboardMsg{}
+	}
+	return clipboardMsg{content: content}
+}
+
+// suggest highlights the best tools for content, which came from source,
+// and selects the first of them. With openTop the first tool is opened with
+// the content right away and returned; otherwise suggest returns nil.
+func (m *listModel) suggest(content string, suggestions []detect.Suggestion, source string, openTop bool) tea.Model {
+	clear(m.hints)
+	m.content = content
+
+	var top detect.Suggestion
+	for _, s := range suggestions {
+		if len(m.hints) == maxHints {
+			break
+		}
+		if m.indexOf(s.Tool) < 0 {
+			continue
+		}
+		if len(m.hints) == 0 {
+			top = s
+		}
+		m.hints[s.Tool] = s.Reason
+	}
+	if len(m.hints) == 0 {
+		if openTop {
+			m.list.NewStatusMessage(hintStyle.Render(source + " doesn't look like anything devtui knows"))
+		}
+		return nil
+	}
+
+	if openTop {
+		return m.launch(top.Tool)
+	}
+	if m.list.FilterState() == list.Unfiltered {
+		m.list.Select(m.indexOf(top.Tool))
+	}
+	m.list.NewStatusMessage(hintStyle.Render(fmt.Sprintf("%s looks like %s", source, top.Reason)))
+	return nil
+}
+
+// toggleFavorite pins or unpins a tool and keeps it selected as it moves.
+func (m *listModel) toggleFavorite(id string) {
+	m.state.toggleFavorite(id)
+	if err := saveMenuState(*m.state); err != nil {
+		m.err = fmt.Sprintf("Failed to save menu state: %v", err)
+	}
+	m.RefreshOrder()
+	if m.list.FilterState() == list.Unfiltered {
+		m.list.Select(m.indexOf(id))
+	}
+}
+
+func (m *listModel) indexOf(id string) int {
+	for i, item := range m.items {
+		if item.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m listModel) View() tea.View {
 	if m.err != "" {
 		return ui.AltScreenView(lipgloss.NewStyle().Padding(2).Render(m.err))
 	}
+	if m.palette != nil {
+		return ui.AltScreenView(m.palette.View())
+	}
 	return ui.AltScreenView(m.list.View())
 }
 
 func (m *listModel) RefreshOrder() {
-	// Re-sort items by usage count
-	sort.Slice(m.items, func(i, j int) bool {
-		return m.items[i].usageCount > m.items[j].usageCount
-	})
+	// Re-sort items into favorites, recently used, then by usage count
+	m.items = m.state.order(m.items)
 
 	// Convert MenuOptions to list.Items
 	items := make([]list.Item, len(m.items))
@@ -0,0 +1,98 @@
+package root
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/skatkov/devtui/internal/config"
+	"github.com/skatkov/devtui/internal/detect"
+	"github.com/skatkov/devtui/internal/history"
+	"github.com/skatkov/devtui/internal/recipe"
+	"github.com/skatkov/devtui/internal/ui"
+	js "github.com/skatkov/devtui/tui/json"
+	"github.com/skatkov/devtui/tui/yaml"
+)
+
+func TestMenuOptionsTakesContent(t *testing.T) {
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	options := getMenuOptions(common)
+	options = append(options, recipeOptions(common, []recipe.Recipe{{Name: "r"}}, nil)...)
+	for _, option := range options {
+		_, ok := option.model().(contentSetter)
+		if option.takesContent != ok {
+			t.Errorf("%s: takesContent = %v, but its model is a contentSetter: %v", option.id, option.takesContent, ok)
+		}
+	}
+}
+
+func TestDefaultEncryptedToolsKeepHistory(t *testing.T) {
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	keepers := map[string]bool{}
+	for _, option := range getMenuOptions(common) {
+		_, keepers[option.id] = option.model().(historyKeeper)
+	}
+	for _, id := range history.DefaultEncrypted {
+		if !keepers[id] {
+			t.Errorf("%s is encrypted by default but keeps no history", id)
+		}
+	}
+}
+
+func TestLaunchWithSuggestedContent(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	cfg, err := config.LoadFiles(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common := &ui.CommonModel{Width: 80, Height: 24, Config: cfg}
+	common.Styles = ui.NewStyle()
+
+	tests := []struct {
+		tool    string
+		content string
+		loaded  func(t *testing.T, screen any) string
+	}{
+		{
+			tool:    "json",
+			content: `{"a": 1}`,
+			loaded: func(t *testing.T, screen any) string {
+				model, ok := screen.(*js.JsonModel)
+				if !ok {
+					t.Fatalf("json opened as %T", screen)
+				}
+				return model.Content
+			},
+		},
+		{
+			tool:    "yaml",
+			content: "a: 1\n",
+			loaded: func(t *testing.T, screen any) string {
+				model, ok := screen.(*yaml.YamlModel)
+				if !ok {
+					t.Fatalf("yaml opened as %T", screen)
+				}
+				return model.Content
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			m := newListModel(common, nil)
+			suggestions := []detect.Suggestion{{Tool: tt.tool, Reason: tt.tool}}
+			screen := m.suggest(tt.content, suggestions, "Clipboard", true)
+			if got := tt.loaded(t, screen); got != tt.content {
+				t.Errorf("%s opened with %q, want %q", tt.tool, got, tt.content)
+			}
+		})
+	}
+}
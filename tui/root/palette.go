@@ -0,0 +1,241 @@
+package root
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
+)
+
+var (
+	paletteTitleStyle       = lipgloss.NewStyle().Bold(true).MarginLeft(2)
+	paletteSectionStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).PaddingLeft(2)
+	paletteDescriptionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	paletteErrorStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).PaddingLeft(2)
+)
+
+// paletteSelectMsg opens the tool picked in the command palette.
+type paletteSelectMsg struct{ id string }
+
+// paletteCloseMsg closes the command palette without opening a tool.
+type paletteCloseMsg struct{}
+
+// paletteFavoriteMsg pins or unpins a tool from the command palette.
+type paletteFavoriteMsg struct{ id string }
+
+// paletteMatch is a tool listed in the palette and the section it is shown
+// under; sections are only used while the query is empty.
+type paletteMatch struct {
+	item    MenuOption
+	section string
+}
+
+// paletteModel is the ctrl+p command palette: a query input above the tools
+// that fuzzy-match it by title, id, alias or keyword.
+type paletteModel struct {
+	title   string
+	err     string
+	input   textinput.Model
+	items   []MenuOption
+	state   *menuState
+	matches []paletteMatch
+	cursor  int
+	width   int
+	height  int
+}
+
+func newPaletteModel(items []MenuOption, state *menuState, width, height int) *paletteModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "search tools, e.g. epoch, b64 or jq"
+	input.SetWidth(max(width-6, 10))
+
+	m := &paletteModel{
+		title:  "Command Palette",
+		input:  input,
+		items:  items,
+		state:  state,
+		width:  width,
+		height: height,
+	}
+	m.refresh()
+	return m
+}
+
+func (m *paletteModel) Init() tea.Cmd {
+	return m.input.Focus()
+}
+
+func (m *paletteModel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.input.SetWidth(max(msg.Width-6, 10))
+		return nil
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "ctrl+p":
+			return func() tea.Msg { return paletteCloseMsg{} }
+		case "enter":
+			if len(m.matches) == 0 {
+				return nil
+			}
+			id := m.matches[m.cursor].item.id
+			return func() tea.Msg { return paletteSelectMsg{id: id} }
+		case "ctrl+s":
+			if len(m.matches) == 0 {
+				return nil
+			}
+			id := m.matches[m.cursor].item.id
+			return func() tea.Msg { return paletteFavoriteMsg{id: id} }
+		case "up", "ctrl+k":
+			m.cursor = max(m.cursor-1, 0)
+			return nil
+		case "down", "ctrl+j":
+			m.cursor = min(m.cursor+1, max(len(m.matches)-1, 0))
+			return nil
+		}
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.cursor = 0
+		m.refresh()
+	}
+	return cmd
+}
+
+// refresh recomputes the matches, keeping the cursor in range.
+func (m *paletteModel) refresh() {
+	m.matches = searchTools(m.input.Value(), m.items, m.state)
+	m.cursor = min(m.cursor, max(len(m.matches)-1, 0))
+}
+
+func (m *paletteModel) View() string {
+	titleWidth := 0
+	for _, match := range m.matches {
+		titleWidth = max(titleWidth, runewidth.StringWidth(match.item.title))
+	}
+
+	// Render every line first, then show the window that keeps the cursor
+	// in view.
+	var (
+		lines    []string
+		selected int
+		section  string
+	)
+	for i, match := range m.matches {
+		if match.section != "" && match.section != section {
+			section = match.section
+			lines = append(lines, paletteSectionStyle.Render(section))
+		}
+
+		marker := "  "
+		if m.state.isFavorite(match.item.id) {
+			marker = "★ "
+		}
+		title := runewidth.FillRight(match.item.title, titleWidth)
+		description := runewidth.Truncate(match.item.description, max(m.width-titleWidth-12, 0), "…")
+		line := marker + title + "  " + paletteDescriptionStyle.Render(description)
+		if i == m.cursor {
+			selected = len(lines)
+			lines = append(lines, selectedItemStyle.Render("> "+line))
+		} else {
+			lines = append(lines, itemStyle.Render(line))
+		}
+	}
+	if len(m.matches) == 0 {
+		lines = append(lines, paletteSectionStyle.Render("No matching tools"))
+	}
+
+	visible := max(m.height-7, 3)
+	start := min(max(selected-visible/2, 0), max(len(lines)-visible, 0))
+	end := min(start+visible, len(lines))
+
+	var b strings.Builder
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, paletteTitleStyle.Render(m.title))
+	fmt.Fprintln(&b, "  "+m.input.View())
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, strings.Join(lines[start:end], "\n"))
+	fmt.Fprintln(&b)
+	if m.err != "" {
+		fmt.Fprintln(&b, paletteErrorStyle.Render(m.err))
+	}
+	fmt.Fprint(&b, helpStyle.Render("↑/↓ select • enter open • ctrl+s pin/unpin • esc close"))
+	return b.String()
+}
+
+// searchTools ranks tools against a query. An empty query lists every tool
+// by section: favorites, recently used, then the rest by usage. Otherwise
+// tools are ranked by their best fuzzy match on title, id, aliases and
+// keywords, where exact and prefix matches of a whole term count most.
+func searchTools(query string, items []MenuOption, state *menuState) []paletteMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		ordered := state.order(items)
+		matches := make([]paletteMatch, len(ordered))
+		for i, item := range ordered {
+			matches[i] = paletteMatch{item: item, section: state.section(item.id)}
+		}
+		return matches
+	}
+
+	type scored struct {
+		item  MenuOption
+		score int
+	}
+	var results []scored
+	for _, item := range items {
+		terms := append([]string{item.title, item.id}, item.aliases...)
+		terms = append(terms, item.keywords...)
+
+		best, matched := 0, false
+		for _, term := range terms {
+			term = strings.ToLower(term)
+			found := fuzzy.Find(query, []string{term})
+			if len(found) == 0 {
+				continue
+			}
+			score := found[0].Score
+			switch {
+			case term == query:
+				score += 1000
+			case strings.HasPrefix(term, query):
+				score += 500
+			}
+			if !matched || score > best {
+				best, matched = score, true
+			}
+		}
+		if matched {
+			results = append(results, scored{item: item, score: best})
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b scored) int {
+		if a.score != b.score {
+			return b.score - a.score
+		}
+		if favA, favB := state.isFavorite(a.item.id), state.isFavorite(b.item.id); favA != favB {
+			if favA {
+				return -1
+			}
+			return 1
+		}
+		return b.item.usageCount - a.item.usageCount
+	})
+
+	matches := make([]paletteMatch, len(results))
+	for i, result := range results {
+		matches[i] = paletteMatch{item: result.item}
+	}
+	return matches
+}
@@ -0,0 +1,88 @@
+package root
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func TestSearchTools(t *testing.T) {
+	t.Parallel()
+
+	items := getMenuOptions(&ui.CommonModel{})
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "epoch", want: "timestamp"},
+		{query: "b64", want: "base64-encoder"},
+		{query: "jq", want: "query"},
+		{query: "crontab", want: "cron"},
+		{query: "JWT", want: "jwt"},
+		{query: "yml", want: "yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			matches := searchTools(tt.query, items, &menuState{})
+			if len(matches) == 0 {
+				t.Fatalf("no tools match %q", tt.query)
+			}
+			if got := matches[0].item.id; got != tt.want {
+				t.Errorf("first match for %q = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchToolsNoMatch(t *testing.T) {
+	t.Parallel()
+
+	items := getMenuOptions(&ui.CommonModel{})
+	if matches := searchTools("zzzzqqq", items, &menuState{}); len(matches) != 0 {
+		t.Errorf("expected no matches, got %d", len(matches))
+	}
+}
+
+func TestSearchToolsEmptyQuerySections(t *testing.T) {
+	t.Parallel()
+
+	items := []MenuOption{
+		{id: "a", usageCount: 1},
+		{id: "b", usageCount: 5},
+		{id: "c"},
+		{id: "d", usageCount: 9},
+	}
+	state := &menuState{Favorites: []string{"c"}, Recent: []string{"a"}}
+
+	matches := searchTools("", items, state)
+	var got []string
+	for _, match := range matches {
+		got = append(got, match.item.id+":"+match.section)
+	}
+	want := []string{"c:Favorites", "a:Recently used", "d:All tools", "b:All tools"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMenuState(t *testing.T) {
+	t.Parallel()
+
+	var state menuState
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "b"} {
+		state.visit(id)
+	}
+	if want := []string{"b", "f", "e", "d", "c"}; !slices.Equal(state.Recent, want) {
+		t.Errorf("Recent = %v, want %v", state.Recent, want)
+	}
+
+	if !state.toggleFavorite("x") || !state.isFavorite("x") {
+		t.Error("expected x to be pinned")
+	}
+	if state.toggleFavorite("x") || state.isFavorite("x") {
+		t.Error("expected x to be unpinned")
+	}
+}
@@ -1,7 +1,14 @@
 package root
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/config"
+	"github.com/skatkov/devtui/internal/detect"
+	"github.com/skatkov/devtui/internal/recipe"
 	"github.com/skatkov/devtui/internal/ui"
 )
 
@@ -9,20 +16,66 @@ type RootModel struct {
 	common      *ui.CommonModel
 	currentView tea.Model
 	listModel   *listModel
+
+	// chain holds the tools whose output was piped into the current view,
+	// oldest first; esc goes back through them before the list.
+	chain []chainStep
+	// picker selects the tool that pipe.Content is sent to.
+	picker *paletteModel
+	pipe   ui.PipeMsg
+	// recorded is the longest chain of tools piped together, ending with
+	// the tool piped into last, for recording it as a recipe.
+	recorded []chainStep
+}
+
+// chainStep is a tool left behind by piping its output into another one.
+type chainStep struct {
+	id    string
+	view  tea.Model
+	title string
+}
+
+// Options configure what the root screen starts with.
+type Options struct {
+	// Input is text piped into devtui. The tool that fits it best is opened
+	// with it, or the list suggests tools when none fits well enough.
+	Input string
+	// DetectClipboard suggests tools for the clipboard contents when there
+	// is no Input.
+	DetectClipboard bool
+	// RunStep runs recipe steps. Saved recipes are only listed with it.
+	RunStep recipe.StepFunc
 }
 
 // RootScreen creates the root model using the default renderer.
 // This is used for local terminal execution.
 func RootScreen() RootModel {
+	return RootScreenWithOptions(Options{})
+}
+
+// RootScreenWithOptions creates the root model, opening or suggesting a
+// tool for the given input or the clipboard.
+func RootScreenWithOptions(opts Options) RootModel {
 	common := ui.CommonModel{LastSelectedItem: 0}
 	common.Styles = ui.NewStyle()
+	common.Config = loadConfig()
 
-	listModel := newListModel(&common)
-	return RootModel{
+	listModel := newListModel(&common, opts.RunStep)
+	m := RootModel{
 		common:      &common,
 		currentView: listModel,
 		listModel:   listModel,
 	}
+
+	switch {
+	case strings.TrimSpace(opts.Input) != "":
+		if view := listModel.suggest(opts.Input, detect.Detect(opts.Input), "Input", true); view != nil {
+			m.currentView = view
+		}
+	case opts.DetectClipboard:
+		listModel.detectClipboard = true
+	}
+	return m
 }
 
 // RootScreenWithSize creates the root model with an initial window size.
@@ -33,8 +86,9 @@ func RootScreenWithSize(width, height int) RootModel {
 		Height:           height,
 	}
 	common.Styles = ui.NewStyle()
+	common.Config = loadConfig()
 
-	listModel := newListModel(&common)
+	listModel := newListModel(&common, nil)
 	return RootModel{
 		common:      &common,
 		currentView: listModel,
@@ -42,18 +96,49 @@ func RootScreenWithSize(width, height int) RootModel {
 	}
 }
 
+// loadConfig loads the settings for the tools, falling back to their
+// defaults when the configuration can't be read.
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
 // RootScreenWithRenderer preserves backward compatibility with old call sites.
 func RootScreenWithRenderer(_ any, width, height int) RootModel {
 	return RootScreenWithSize(width, height)
 }
 
 func (m RootModel) Init() tea.Cmd {
+	if m.listModel.detectClipboard {
+		return tea.Batch(m.currentView.Init(), readClipboard)
+	}
 	return m.currentView.Init()
 }
 
 func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.picker != nil {
+		return m.updatePicker(msg)
+	}
+
 	switch msg := msg.(type) {
+	case ui.PipeMsg:
+		m.pipe = msg
+		m.picker = newPaletteModel(m.listModel.pipeTargets(), m.listModel.state, m.common.Width, m.common.Height)
+		m.picker.title = "Pipe " + msg.From + " output to"
+		return m, m.picker.Init()
 	case ui.ReturnToListMsg:
+		if len(m.chain) > 0 {
+			last := m.chain[len(m.chain)-1]
+			m.chain = m.chain[:len(m.chain)-1]
+			m.common.Chain = m.chainTitles()
+			m.currentView = last.view
+			m.listModel.current = last.id
+			return m, nil
+		}
+
 		m.listModel.common = msg.Common
 		m.listModel.list.SetSize(msg.Common.Width, msg.Common.Height)
 
@@ -76,6 +161,97 @@ func (m RootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updatePicker handles messages while the pipe tool picker is open.
+func (m RootModel) updatePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case paletteSelectMsg:
+		from := m.listModel.current
+		next := m.listModel.launch(msg.id)
+		if next == nil {
+			return m, nil
+		}
+		if setter, ok := next.(contentSetter); ok {
+			if err := setter.SetContent(m.pipe.Content); err != nil {
+				m.picker.err = fmt.Sprintf("Can't pipe into this tool: %v", err)
+				m.listModel.current = from
+				return m, nil
+			}
+		}
+		m.chain = append(m.chain, chainStep{id: from, view: m.currentView, title: m.pipe.From})
+		m.common.Chain = m.chainTitles()
+		if len(m.chain)+1 >= len(m.recorded) {
+			m.recorded = append(slices.Clone(m.chain), chainStep{id: msg.id, view: next})
+		}
+		m.currentView = next
+		m.picker = nil
+		return m, next.Init()
+	case paletteCloseMsg:
+		m.picker = nil
+		return m, nil
+	case paletteFavoriteMsg:
+		m.listModel.toggleFavorite(msg.id)
+		m.picker.refresh()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.common.Width = msg.Width
+		m.common.Height = msg.Height
+		m.picker.Update(msg)
+		var cmd tea.Cmd
+		m.currentView, cmd = m.currentView.Update(msg)
+		return m, cmd
+	case tea.KeyPressMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		m.picker.err = ""
+		return m, m.picker.Update(msg)
+	}
+
+	// Timers and other messages still belong to the tool behind the picker.
+	var cmd tea.Cmd
+	m.currentView, cmd = m.currentView.Update(msg)
+	return m, tea.Batch(cmd, m.picker.Update(msg))
+}
+
+// stepArgser is implemented by tools whose settings, such as a query
+// expression, become arguments of their recipe step.
+type stepArgser interface {
+	StepArgs() []string
+}
+
+// Recorded returns the recipe steps of the longest chain of tools piped
+// together during the session, or nil when no tools were chained.
+func (m RootModel) Recorded() ([]recipe.Step, error) {
+	var steps []recipe.Step
+	for _, tool := range m.recorded {
+		idx := m.listModel.indexOf(tool.id)
+		if idx < 0 {
+			continue
+		}
+		item := m.listModel.items[idx]
+		if len(item.steps) == 0 {
+			return nil, fmt.Errorf("%s has no command and can't be part of a recipe", item.title)
+		}
+		toolSteps := slices.Clone(item.steps)
+		if view, ok := tool.view.(stepArgser); ok && len(toolSteps) == 1 {
+			toolSteps[0].Args = append(slices.Clone(toolSteps[0].Args), view.StepArgs()...)
+		}
+		steps = append(steps, toolSteps...)
+	}
+	return steps, nil
+}
+
+func (m RootModel) chainTitles() []string {
+	titles := make([]string, len(m.chain))
+	for i, step := range m.chain {
+		titles[i] = step.title
+	}
+	return titles
+}
+
 func (m RootModel) View() tea.View {
+	if m.picker != nil {
+		return ui.AltScreenView(m.picker.View())
+	}
 	return ui.WithAltScreen(m.currentView.View())
 }
@@ -0,0 +1,113 @@
+package root
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/adrg/xdg"
+)
+
+// maxRecent is how many recently opened tools the menu remembers.
+const maxRecent = 5
+
+// menuState is what the menu remembers between runs besides usage counts:
+// pinned favorites and the most recently opened tools, newest first.
+type menuState struct {
+	Favorites []string `json:"favorites"`
+	Recent    []string `json:"recent"`
+}
+
+func (s *menuState) isFavorite(id string) bool {
+	return slices.Contains(s.Favorites, id)
+}
+
+func (s *menuState) isRecent(id string) bool {
+	return slices.Contains(s.Recent, id)
+}
+
+// toggleFavorite pins or unpins a tool and reports whether it is now pinned.
+func (s *menuState) toggleFavorite(id string) bool {
+	if i := slices.Index(s.Favorites, id); i >= 0 {
+		s.Favorites = slices.Delete(s.Favorites, i, i+1)
+		return false
+	}
+	s.Favorites = append(s.Favorites, id)
+	return true
+}
+
+// visit moves a tool to the front of the recently used tools.
+func (s *menuState) visit(id string) {
+	s.Recent = slices.DeleteFunc(s.Recent, func(recent string) bool { return recent == id })
+	s.Recent = slices.Insert(s.Recent, 0, id)
+	if len(s.Recent) > maxRecent {
+		s.Recent = s.Recent[:maxRecent]
+	}
+}
+
+// section names the part of the menu a tool belongs to.
+func (s *menuState) section(id string) string {
+	switch {
+	case s.isFavorite(id):
+		return "Favorites"
+	case s.isRecent(id):
+		return "Recently used"
+	default:
+		return "All tools"
+	}
+}
+
+// order sorts tools into favorites in pinning order, then recently used
+// tools, newest first, then the rest by usage count.
+func (s *menuState) order(items []MenuOption) []MenuOption {
+	rank := func(item MenuOption) (int, int) {
+		if i := slices.Index(s.Favorites, item.id); i >= 0 {
+			return 0, i
+		}
+		if i := slices.Index(s.Recent, item.id); i >= 0 {
+			return 1, i
+		}
+		return 2, -item.usageCount
+	}
+
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b MenuOption) int {
+		groupA, posA := rank(a)
+		groupB, posB := rank(b)
+		if groupA != groupB {
+			return groupA - groupB
+		}
+		return posA - posB
+	})
+	return ordered
+}
+
+func menuStatePath() string {
+	return filepath.Join(xdg.ConfigHome, "devtui", "menu.json")
+}
+
+func loadMenuState() (menuState, error) {
+	var state menuState
+	data, err := os.ReadFile(menuStatePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+func saveMenuState(state menuState) error {
+	path := menuStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,144 @@
+package timestamp
+
+import (
+	"strconv"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/skatkov/devtui/internal/timestamp"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+const Title = "Unix Timestamp Converter"
+
+type tickMsg time.Time
+
+type TimestampModel struct {
+	common *ui.CommonModel
+	input  textinput.Model
+	now    time.Time
+	result timestamp.Result
+	err    error
+}
+
+func NewTimestampModel(common *ui.CommonModel) *TimestampModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "epoch value or date, e.g. 1700000000 or 2024-02-29T12:00:00Z"
+
+	m := &TimestampModel{
+		common: common,
+		input:  input,
+		now:    time.Now(),
+	}
+	m.convert()
+
+	return m
+}
+
+func (m *TimestampModel) Init() tea.Cmd {
+	return tea.Batch(m.input.Focus(), tick())
+}
+
+func (m *TimestampModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// Window size is received when starting up and on every resize
+	case tea.WindowSizeMsg:
+		m.common.Width = msg.Width
+		m.common.Height = msg.Height
+		m.input.SetWidth(msg.Width - 10)
+	case tickMsg:
+		m.now = time.Time(msg)
+		m.convert()
+		return m, tick()
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg {
+				return ui.ReturnToListMsg{
+					Common: m.common,
+				}
+			}
+		case "ctrl+c":
+			return m, tea.Quit
+		case "ctrl+n":
+			m.input.SetValue(strconv.FormatInt(m.now.Unix(), 10))
+			m.input.CursorEnd()
+			m.convert()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.convert()
+
+	return m, cmd
+}
+
+func (m *TimestampModel) View() tea.View {
+	s := m.common.Styles
+	header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
+		ui.AppTitle,
+		" :: ",
+		lipgloss.NewStyle().Bold(true).Render(Title),
+	))
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4")).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB"))
+	now := labelStyle.Render("Now: ") + valueStyle.Render(
+		strconv.FormatInt(m.now.Unix(), 10)+"  "+m.now.UTC().Format(timestamp.DisplayLayout),
+	)
+
+	var body string
+	if m.err != nil {
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")).Render(m.err.Error())
+	} else {
+		body = table.New().
+			Border(lipgloss.RoundedBorder()).
+			Rows(timestamp.ResultToRows(m.result)...).
+			String()
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		"",
+		now,
+		"",
+		m.input.View(),
+		"",
+		body,
+	)
+	help := s.Help.Render("ctrl+n insert now • esc back • ctrl+c quit")
+	view := lipgloss.JoinVertical(lipgloss.Left,
+		content,
+		lipgloss.PlaceVertical(max(m.common.Height-lipgloss.Height(content)-2, 1), lipgloss.Bottom, help),
+	)
+
+	return ui.AltScreenView(s.Base.Render(view))
+}
+
+// SetContent pre-fills the input with a value to convert.
+func (m *TimestampModel) SetContent(content string) error {
+	m.input.SetValue(content)
+	m.convert()
+	return m.err
+}
+
+func (m *TimestampModel) convert() {
+	value := m.input.Value()
+	if value == "" {
+		value = "now"
+	}
+
+	m.result, m.err = timestamp.Convert(value, timestamp.UnitAuto, timestamp.DefaultZones, m.now)
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
@@ -0,0 +1,54 @@
+package timestamp
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/skatkov/devtui/internal/ui"
+)
+
+func TestTypingUpdatesResult(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewTimestampModel(common)
+	model.input.Focus()
+
+	for _, r := range "1700000000" {
+		next, _ := model.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+		model = next.(*TimestampModel)
+	}
+
+	if model.err != nil {
+		t.Fatalf("unexpected error: %v", model.err)
+	}
+	if model.result.Unix != 1700000000 {
+		t.Fatalf("expected unix 1700000000, got %d", model.result.Unix)
+	}
+}
+
+func TestTickRefreshesNow(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewTimestampModel(common)
+	later := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	next, cmd := model.Update(tickMsg(later))
+	model = next.(*TimestampModel)
+
+	if !model.now.Equal(later) {
+		t.Fatalf("expected now to be %v, got %v", later, model.now)
+	}
+	if model.result.Unix != later.Unix() {
+		t.Fatalf("expected empty input to show now, got %d", model.result.Unix)
+	}
+	if cmd == nil {
+		t.Fatal("expected tick to schedule the next tick")
+	}
+}
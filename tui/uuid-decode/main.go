@@ -20,6 +20,7 @@ const Title = "UUID Decoder"
 type UUIDDecode struct {
 	common *ui.CommonModel
 	form   *huh.Form
+	input  *huh.Input
 	uuid   string
 }
 
@@ -27,21 +28,27 @@ func NewUUIDDecodeModel(common *ui.CommonModel) *UUIDDecode {
 	m := UUIDDecode{common: common}
 	accessible, _ := strconv.ParseBool(os.Getenv("ACCESSIBLE"))
 
+	m.input = huh.NewInput().
+		Title("UUID").
+		Placeholder("Enter a UUID").
+		Validate(func(value string) error {
+			_, err := uuid.Parse(value)
+			return err
+		}).Value(&m.uuid)
 	m.form = huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().
-				Title("UUID").
-				Placeholder("Enter a UUID").
-				Validate(func(value string) error {
-					_, err := uuid.Parse(value)
-					return err
-				}).Value(&m.uuid),
-		),
+		huh.NewGroup(m.input),
 	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithAccessible(accessible).WithShowHelp(false)
 
 	return &m
 }
 
+// SetContent fills in the UUID, so the form opens with it.
+func (m *UUIDDecode) SetContent(content string) error {
+	m.uuid = strings.TrimSpace(content)
+	m.input.Value(&m.uuid)
+	return nil
+}
+
 func (m *UUIDDecode) Init() tea.Cmd {
 	return m.form.Init()
 }
@@ -1,15 +1,16 @@
 package uuidgenerate
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
+	"charm.land/bubbles/v2/viewport"
 	"charm.land/huh/v2"
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/table"
-	"github.com/google/uuid"
+	"github.com/skatkov/devtui/internal/clipboard"
 	"github.com/skatkov/devtui/internal/ui"
 	"github.com/skatkov/devtui/internal/uuidutil"
 
@@ -19,18 +20,74 @@ import (
 const Title = "UUID Generator"
 
 type UUIDGenerate struct {
-	common        *ui.CommonModel
-	form          *huh.Form
-	version       int
-	namespace     string
-	generatedUUID uuid.UUID
+	common    *ui.CommonModel
+	form      *huh.Form
+	kind      string
+	version   int
+	namespace string
+	styles    []string
+	alphabet  string
+	length    string
+	node      string
+	count     string
+	format    string
+
+	// ids are the generated identifiers, and output them in the chosen
+	// format, shown in the viewport.
+	ids      []string
+	output   string
+	err      error
+	status   string
+	viewport viewport.Model
 }
 
 func NewUUIDGenerateModel(common *ui.CommonModel) *UUIDGenerate {
-	m := UUIDGenerate{common: common}
+	// A version of 0 leaves the first option selected.
+	m := UUIDGenerate{
+		common:    common,
+		kind:      common.Config.String("uuidgenerate.kind", uuidutil.KindUUID),
+		version:   common.Config.Int("uuidgenerate.uuid-version", 0),
+		namespace: common.Config.String("uuidgenerate.namespace", ""),
+		alphabet:  common.Config.String("uuidgenerate.alphabet", ""),
+		length:    strconv.Itoa(common.Config.Int("uuidgenerate.length", 0)),
+		node:      strconv.Itoa(common.Config.Int("uuidgenerate.node", 0)),
+		count:     strconv.Itoa(common.Config.Int("uuidgenerate.count", 1)),
+		format:    common.Config.String("uuidgenerate.format", "plain"),
+	}
+	if styles := common.Config.String("uuidgenerate.style", ""); styles != "" {
+		m.styles = strings.Split(styles, ",")
+	}
+	m.newForm()
+
+	return &m
+}
+
+func (m *UUIDGenerate) newForm() {
 	accessible, _ := strconv.ParseBool(os.Getenv("ACCESSIBLE"))
 
+	styles := make([]huh.Option[string], len(uuidutil.Styles))
+	for i, style := range uuidutil.Styles {
+		styles[i] = huh.NewOption(style, style)
+	}
+	formats := make([]huh.Option[string], len(uuidutil.Formats))
+	for i, format := range uuidutil.Formats {
+		formats[i] = huh.NewOption(format, format)
+	}
+
 	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Kind").
+				Options(
+					huh.NewOption("UUID", uuidutil.KindUUID),
+					huh.NewOption("ULID (sortable, base32)", uuidutil.KindULID),
+					huh.NewOption("KSUID (sortable, base62)", uuidutil.KindKSUID),
+					huh.NewOption("NanoID (custom alphabet and length)", uuidutil.KindNanoID),
+					huh.NewOption("CUID2", uuidutil.KindCUID2),
+					huh.NewOption("Snowflake (Twitter-style)", uuidutil.KindSnowflake),
+				).
+				Value(&m.kind),
+		),
 		huh.NewGroup(
 			huh.NewSelect[int]().
 				Title("UUID Version").
@@ -44,18 +101,66 @@ func NewUUIDGenerateModel(common *ui.CommonModel) *UUIDGenerate {
 					huh.NewOption("Version 7 (Time-based)", 7),
 				).
 				Value(&m.version),
-		),
+		).WithHideFunc(func() bool { return m.kind != uuidutil.KindUUID }),
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Namespace").
 				Value(&m.namespace),
 		).WithHideFunc(func() bool { return m.hideNamespace() }),
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Styles").
+				Options(styles...).
+				Validate(func(styles []string) error {
+					_, err := uuidutil.NewGenerator(uuidutil.Options{Styles: styles})
+					return err
+				}).
+				Value(&m.styles),
+		).WithHideFunc(func() bool { return m.kind != uuidutil.KindUUID }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Alphabet").
+				Placeholder(uuidutil.NanoIDAlphabet).
+				Value(&m.alphabet),
+		).WithHideFunc(func() bool { return m.kind != uuidutil.KindNanoID }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Length").
+				Description("0 for the default").
+				Value(&m.length).
+				Validate(number),
+		).WithHideFunc(func() bool { return m.kind != uuidutil.KindNanoID && m.kind != uuidutil.KindCUID2 }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Node").
+				Description(fmt.Sprintf("Worker ID, 0-%d", uuidutil.MaxSnowflakeNode)).
+				Value(&m.node).
+				Validate(number),
+		).WithHideFunc(func() bool { return m.kind != uuidutil.KindSnowflake }),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Count").
+				Value(&m.count).
+				Validate(number),
+			huh.NewSelect[string]().
+				Title("Output Format").
+				Options(formats...).
+				Value(&m.format),
+		),
 	).WithTheme(huh.ThemeFunc(huh.ThemeCharm)).WithAccessible(accessible).WithShowHelp(false)
+}
 
-	return &m
+func number(s string) error {
+	if _, err := strconv.Atoi(strings.TrimSpace(s)); err != nil {
+		return errors.New("enter a number")
+	}
+	return nil
 }
 
 func (m *UUIDGenerate) hideNamespace() bool {
+	if m.kind != uuidutil.KindUUID {
+		return true
+	}
 	switch m.version {
 	case 3:
 		return false
@@ -66,33 +171,68 @@ func (m *UUIDGenerate) hideNamespace() bool {
 	}
 }
 
+// generate fills the identifiers from the form's settings.
+func (m *UUIDGenerate) generate() {
+	m.ids, m.output, m.err, m.status = nil, "", nil, ""
+
+	length, _ := strconv.Atoi(strings.TrimSpace(m.length))
+	node, _ := strconv.ParseInt(strings.TrimSpace(m.node), 10, 64)
+	count, _ := strconv.Atoi(strings.TrimSpace(m.count))
+	opts := uuidutil.Options{
+		Kind:     m.kind,
+		Version:  m.version,
+		Alphabet: m.alphabet,
+		Length:   length,
+		Node:     node,
+	}
+	if m.kind == uuidutil.KindUUID {
+		opts.Namespace = m.namespace
+		opts.Styles = m.styles
+	}
+
+	m.ids, m.err = uuidutil.GenerateN(opts, count)
+	if m.err == nil {
+		m.output, m.err = uuidutil.FormatIDs(m.ids, m.format)
+	}
+	m.viewport = viewport.New(
+		viewport.WithWidth(m.common.Width),
+		viewport.WithHeight(m.viewportHeight()),
+	)
+	m.viewport.SetContent(m.output)
+}
+
+func (m *UUIDGenerate) viewportHeight() int {
+	// Header, its margin, the status and help lines.
+	return max(m.common.Height-6, 1)
+}
+
 func (m *UUIDGenerate) Init() tea.Cmd {
 	return m.form.Init()
 }
 
 func (m *UUIDGenerate) View() tea.View {
 	s := m.common.Styles
+	header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
+		ui.AppTitle,
+		" :: ",
+		lipgloss.NewStyle().Bold(true).Render(Title),
+	))
+
 	switch m.form.State {
 	case huh.StateCompleted:
-		var rows [][]string
-		rows = append(rows, []string{"Version", strconv.Itoa(m.version)})
-		if m.namespace != "" {
-			rows = append(rows, []string{"Namespace", m.namespace})
+		var body string
+		if m.err != nil {
+			body = fmt.Sprintf("Error generating %s: %v", m.kind, m.err)
+		} else {
+			body = m.viewport.View()
 		}
-		rows = append(rows, []string{"Generated UUID", m.generatedUUID.String()})
-
-		tableOutput := table.New().
-			Border(lipgloss.RoundedBorder()).
-			Width(100).
-			Rows(rows...)
-
-		return ui.AltScreenView(s.Base.Render(tableOutput.String()))
+		status := fmt.Sprintf("%d %s generated", len(m.ids), m.kind)
+		if m.status != "" {
+			status += " • " + m.status
+		}
+		help := s.Help.Render("↑/↓ scroll • c copy • r regenerate • s settings • esc back • q quit")
+		return ui.AltScreenView(s.Base.Render(header + "\n\n" + body + "\n\n" + status + "\n" + help))
 	default:
-		header := s.Title.Render(lipgloss.JoinHorizontal(lipgloss.Left,
-			ui.AppTitle,
-			" :: ",
-			lipgloss.NewStyle().Bold(true).Render(Title),
-		))
 		v := strings.TrimSuffix(m.form.View(), "\n\n")
 		form := lipgloss.NewStyle().Margin(1, 0).Render(v)
 		body := lipgloss.JoinVertical(
@@ -114,9 +254,11 @@ func (m *UUIDGenerate) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.common.Width = msg.Width
 		m.common.Height = msg.Height
+		m.viewport.SetWidth(msg.Width)
+		m.viewport.SetHeight(m.viewportHeight())
 	case tea.KeyPressMsg:
 		switch msg.String() {
-		case "q", "ctrl+c":
+		case "ctrl+c":
 			return m, tea.Quit
 		case "esc":
 			return m, func() tea.Msg {
@@ -125,6 +267,30 @@ func (m *UUIDGenerate) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+
+		// The namespace and alphabet inputs may contain these keys.
+		if m.form.State == huh.StateCompleted {
+			switch msg.String() {
+			case "q":
+				return m, tea.Quit
+			case "r":
+				m.generate()
+				return m, nil
+			case "s":
+				m.newForm()
+				return m, m.form.Init()
+			case "c":
+				if err := clipboard.Copy(m.output); err != nil {
+					m.status = err.Error()
+				} else {
+					m.status = "Copied."
+				}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
 	}
 
 	form, cmd := m.form.Update(msg)
@@ -132,12 +298,7 @@ func (m *UUIDGenerate) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.form = f
 
 		if m.form.State == huh.StateCompleted {
-			var err error
-			m.generatedUUID, err = uuidutil.Generate(m.version, m.namespace)
-			if err != nil {
-				// Handle error appropriately
-				fmt.Println("Error generating UUID:", err)
-			}
+			m.generate()
 		}
 	}
 	return m, cmd
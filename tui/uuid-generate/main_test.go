@@ -1,12 +1,15 @@
 package uuidgenerate
 
 import (
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/huh/v2"
 	"github.com/google/uuid"
+	"github.com/skatkov/devtui/internal/config"
 	"github.com/skatkov/devtui/internal/ui"
+	"github.com/skatkov/devtui/internal/uuidutil"
 )
 
 func TestUUIDVersionSelectRespondsToKeyPresses(t *testing.T) {
@@ -19,6 +22,11 @@ func TestUUIDVersionSelectRespondsToKeyPresses(t *testing.T) {
 	model = batchUpdate(model, model.Init()).(*UUIDGenerate)
 	model = updateModel(model, tea.WindowSizeMsg{Width: 80, Height: 24}).(*UUIDGenerate)
 
+	if model.kind != uuidutil.KindUUID {
+		t.Fatalf("expected initial kind uuid, got %q", model.kind)
+	}
+	model = updateModel(model, codeKeypress(tea.KeyEnter)).(*UUIDGenerate)
+
 	if model.version != 1 {
 		t.Fatalf("expected initial version 1, got %d", model.version)
 	}
@@ -28,12 +36,86 @@ func TestUUIDVersionSelectRespondsToKeyPresses(t *testing.T) {
 		t.Fatalf("expected version 2 after pressing down, got %d", model.version)
 	}
 
-	model = updateModel(model, codeKeypress(tea.KeyEnter)).(*UUIDGenerate)
+	// Accept the styles, count and format.
+	for range 4 {
+		model = updateModel(model, codeKeypress(tea.KeyEnter)).(*UUIDGenerate)
+	}
 	if model.form.State != huh.StateCompleted {
 		t.Fatalf("expected form to complete after pressing enter, got state %v", model.form.State)
 	}
-	if model.generatedUUID == uuid.Nil {
-		t.Fatal("expected UUID to be generated after form completion")
+	if len(model.ids) != 1 {
+		t.Fatalf("expected a UUID to be generated after form completion, got %v (%v)", model.ids, model.err)
+	}
+	if id, err := uuid.Parse(model.ids[0]); err != nil || id.Version() != 2 {
+		t.Fatalf("expected a version 2 UUID, got %q", model.ids[0])
+	}
+}
+
+func TestGenerateBulk(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewUUIDGenerateModel(common)
+	model.kind = uuidutil.KindULID
+	model.count = "30"
+	model.format = "csv"
+	model.generate()
+	model.form.State = huh.StateCompleted
+
+	if model.err != nil {
+		t.Fatalf("unexpected error: %v", model.err)
+	}
+	if len(model.ids) != 30 || !strings.HasPrefix(model.output, "id\n"+model.ids[0]) {
+		t.Fatalf("unexpected output for 30 ULIDs:\n%s", model.output)
+	}
+	if view := model.View().Content; !strings.Contains(view, "30 ulid generated") {
+		t.Errorf("view lacks the count:\n%s", view)
+	}
+
+	first := model.ids[0]
+	model = updateModel(model, tea.KeyPressMsg(tea.Key{Code: 'r', Text: "r"})).(*UUIDGenerate)
+	if model.ids[0] == first {
+		t.Errorf("expected r to generate new ids, still %q", first)
+	}
+}
+
+func TestGenerateInvalidSettings(t *testing.T) {
+	t.Parallel()
+
+	common := &ui.CommonModel{Width: 80, Height: 24}
+	common.Styles = ui.NewStyle()
+
+	model := NewUUIDGenerateModel(common)
+	model.kind = uuidutil.KindSnowflake
+	model.node = "5000"
+	model.generate()
+	model.form.State = huh.StateCompleted
+
+	if model.err == nil {
+		t.Fatal("expected an error for an out of range node")
+	}
+	if view := model.View().Content; !strings.Contains(view, "Error generating snowflake") {
+		t.Errorf("view lacks the error:\n%s", view)
+	}
+}
+
+func TestUUIDVersionDefaultsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadFiles([]string{"DEVTUI_UUIDGENERATE_UUID_VERSION=7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	common := &ui.CommonModel{Width: 80, Height: 24, Config: cfg}
+	common.Styles = ui.NewStyle()
+
+	model := NewUUIDGenerateModel(common)
+	model = batchUpdate(model, model.Init()).(*UUIDGenerate)
+
+	if model.version != 7 {
+		t.Fatalf("expected configured version 7, got %d", model.version)
 	}
 }
 
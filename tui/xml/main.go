@@ -99,7 +99,11 @@ func (m XMLFormatterModel) View() tea.View {
 
 func (m *XMLFormatterModel) SetContent(content string) error {
 	m.Content = content
-	m.FormattedContent = xmlfmt.FormatXML(content, "\t", "  ")
+	cfg := m.Common.Config
+	m.FormattedContent = xmlfmt.FormatXML(content,
+		cfg.String("xmlfmt.prefix", "\t"),
+		cfg.String("xmlfmt.indent", "  "),
+		cfg.Bool("xmlfmt.nested", false))
 
 	var buf bytes.Buffer
 	err := quick.Highlight(&buf, m.FormattedContent, "xml", "terminal", "nord")
@@ -3,11 +3,11 @@ package yamlstruct
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/alecthomas/chroma/quick"
-	"github.com/mattn/go-runewidth"
 
 	"github.com/skatkov/devtui/internal/clipboard"
 	"github.com/skatkov/devtui/internal/editor"
@@ -15,16 +15,19 @@ import (
 	"github.com/skatkov/devtui/internal/ui"
 )
 
-const Title = "YAML to Go Struct Converter"
+const Title = "YAML to Struct Converter"
 
 type YamlStructModel struct {
 	ui.BasePagerModel
+	lang structgen.Language
 }
 
 func NewYamlStructModel(common *ui.CommonModel) YamlStructModel {
 	model := YamlStructModel{
 		BasePagerModel: ui.NewBasePagerModel(common, Title),
+		lang:           structgen.LanguageGo,
 	}
+	model.Title = model.title()
 
 	return model
 }
@@ -47,6 +50,14 @@ func (m YamlStructModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "e":
 			return m, editor.OpenEditor(m.Content, "yaml")
+		case "L":
+			m.lang = m.nextLanguage()
+			m.Title = m.title()
+			if strings.TrimSpace(m.Content) != "" {
+				if err := m.SetContent(m.Content); err != nil {
+					cmds = append(cmds, m.ShowErrorMessage(err.Error()))
+				}
+			}
 		case "v":
 			content, err := clipboard.Paste()
 			if err == nil {
@@ -100,14 +111,14 @@ func (m YamlStructModel) View() tea.View {
 
 func (m *YamlStructModel) SetContent(content string) error {
 	m.Content = content
-	converted, err := structgen.YAMLToGoStruct(strings.NewReader(content))
+	converted, err := structgen.YAMLToStruct(strings.NewReader(content), m.lang)
 	if err != nil {
 		return err
 	}
 	m.FormattedContent = converted
 	var buf bytes.Buffer
 
-	err = quick.Highlight(&buf, m.FormattedContent, "go", "terminal", "nord")
+	err = quick.Highlight(&buf, m.FormattedContent, m.lang.Lexer(), "terminal", "nord")
 	if err != nil {
 		return err
 	}
@@ -115,38 +126,25 @@ func (m *YamlStructModel) SetContent(content string) error {
 	return nil
 }
 
-func (m YamlStructModel) helpView() (s string) {
-	col1 := []string{
-		"c              copy Go struct",
-		"e              edit JSON",
-		"v              paste JSON to convert",
-		"q/ctrl+c       quit",
-	}
-
-	s += "\n"
-	s += "k/↑      up                  " + col1[0] + "\n"
-	s += "j/↓      down                " + col1[1] + "\n"
-	s += "b/pgup   page up             " + col1[2] + "\n"
-	s += "f/pgdn   page down           " + col1[3] + "\n"
-	s += "u        ½ page up           " + "\n"
-	s += "d        ½ page down         "
-
-	if len(col1) > 5 {
-		s += col1[5]
-	}
-
-	s = ui.Indent(s, 2)
+// nextLanguage returns the language after the current one, wrapping around.
+func (m YamlStructModel) nextLanguage() structgen.Language {
+	languages := structgen.Languages()
+	i := slices.Index(languages, m.lang)
+	return languages[(i+1)%len(languages)]
+}
 
-	if m.Common.Width > 0 {
-		lines := strings.Split(s, "\n")
-		for i := range lines {
-			l := runewidth.StringWidth(lines[i])
-			n := max(m.Common.Width-l, 0)
-			lines[i] += strings.Repeat(" ", n)
-		}
+func (m YamlStructModel) title() string {
+	return fmt.Sprintf("%s (%s)", Title, m.lang)
+}
 
-		s = strings.Join(lines, "\n")
+func (m YamlStructModel) helpView() string {
+	col1 := []string{
+		"c              copy result",
+		"e              edit YAML",
+		"v              paste YAML to convert",
+		"L              switch language",
+		"q/ctrl+c       quit",
 	}
 
-	return ui.HelpViewStyle(s)
+	return m.FormatHelpColumns(col1)
 }